@@ -0,0 +1,91 @@
+package acl
+
+import (
+	"strings"
+
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/gojek/kat/ui"
+	"github.com/spf13/cobra"
+)
+
+type aclFilterer interface {
+	FilterACLs(filter client.ACLFilter) ([]client.ACL, error)
+}
+
+type userInput interface {
+	AskForConfirmation(string) bool
+}
+
+var ACLCmd = &cobra.Command{
+	Use:   "acl",
+	Short: "Admin commands on acls",
+}
+
+func init() {
+	ACLCmd.PersistentFlags().StringP("broker-list", "b", "", "Comma separated list of broker ips")
+	if err := ACLCmd.MarkPersistentFlagRequired("broker-list"); err != nil {
+		logger.Fatal(err)
+	}
+
+	ACLCmd.AddCommand(listACLCmd)
+	ACLCmd.AddCommand(createACLCmd)
+	ACLCmd.AddCommand(deleteACLCmd)
+	ACLCmd.AddCommand(exportACLCmd)
+	ACLCmd.AddCommand(importACLCmd)
+}
+
+func newClient(cobraUtil *base.CobraUtil) *client.SaramaClient {
+	return client.NewSaramaClient(strings.Split(cobraUtil.GetStringArg("broker-list"), ","))
+}
+
+// addFilterFlags adds the flags selecting the acls, the acls match all the flags that are set
+func addFilterFlags(command *cobra.Command) {
+	command.PersistentFlags().String("resource-type", "", "Resource type of the acls, one of topic|group|cluster|transactionalid|delegationtoken")
+	command.PersistentFlags().String("resource-name", "", "Resource name of the acls")
+	command.PersistentFlags().String("pattern-type", "",
+		"Pattern type of the acls, one of literal|prefixed|match. match selects the literal and prefixed acls applying to the resource name")
+	command.PersistentFlags().String("principal", "", "Principal of the acls, like User:alice")
+	command.PersistentFlags().String("host", "", "Host of the acls")
+	command.PersistentFlags().String("operation", "", "Operation of the acls, like read|write|create|delete|alter|describe|all")
+	command.PersistentFlags().String("permission", "", "Permission type of the acls, one of allow|deny")
+}
+
+func getFilter(cobraUtil *base.CobraUtil) client.ACLFilter {
+	return client.ACLFilter{
+		ResourceType:   cobraUtil.GetStringArg("resource-type"),
+		ResourceName:   cobraUtil.GetStringArg("resource-name"),
+		PatternType:    cobraUtil.GetStringArg("pattern-type"),
+		Principal:      cobraUtil.GetStringArg("principal"),
+		Host:           cobraUtil.GetStringArg("host"),
+		Operation:      cobraUtil.GetStringArg("operation"),
+		PermissionType: cobraUtil.GetStringArg("permission"),
+	}
+}
+
+// filterACLs returns the acls matching the filter, sorted
+func filterACLs(filterer aclFilterer, filter client.ACLFilter) []client.ACL {
+	acls, err := filterer.FilterACLs(filter)
+	if err != nil {
+		logger.Fatalf("Error while listing the acls - %v\n", err)
+	}
+	model.SortACLs(acls)
+	return acls
+}
+
+func aclRows(acls []client.ACL) []ui.Row {
+	rows := make([]ui.Row, 0, len(acls))
+	for _, acl := range acls {
+		rows = append(rows, ui.ACL(acl.ResourceType, acl.ResourceName, acl.PatternType, acl.Principal, acl.Host, acl.Operation, acl.PermissionType))
+	}
+	return rows
+}
+
+// previewACLs shows the acls before they are changed
+func previewACLs(renderer *ui.Renderer, acls []client.ACL) {
+	if err := renderer.Render(aclRows(acls)); err != nil {
+		logger.Fatalf("Error while writing the acls - %v\n", err)
+	}
+}
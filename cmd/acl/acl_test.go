@@ -0,0 +1,61 @@
+package acl
+
+import (
+	"io/ioutil"
+
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/ui"
+	"github.com/stretchr/testify/mock"
+)
+
+func init() {
+	logger.SetDummyLogger()
+}
+
+type mockACLAdmin struct {
+	mock.Mock
+}
+
+func (m *mockACLAdmin) FilterACLs(filter client.ACLFilter) ([]client.ACL, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]client.ACL), args.Error(1)
+}
+
+func (m *mockACLAdmin) ListACLs() ([]client.ACL, error) {
+	args := m.Called()
+	return args.Get(0).([]client.ACL), args.Error(1)
+}
+
+func (m *mockACLAdmin) CreateACLs(acls []client.ACL) error {
+	args := m.Called(acls)
+	return args.Error(0)
+}
+
+func (m *mockACLAdmin) DeleteACLs(acls []client.ACL) error {
+	args := m.Called(acls)
+	return args.Error(0)
+}
+
+type mockUserInput struct {
+	mock.Mock
+}
+
+func (m *mockUserInput) AskForConfirmation(question string) bool {
+	args := m.Called(question)
+	return args.Bool(0)
+}
+
+var (
+	readACL = client.ACL{ResourceType: "Topic", ResourceName: "orders", PatternType: "Literal", Principal: "User:alice",
+		Host: "*", Operation: "Read", PermissionType: "Allow"}
+	writeACL = client.ACL{ResourceType: "Topic", ResourceName: "orders", PatternType: "Literal", Principal: "User:alice",
+		Host: "*", Operation: "Write", PermissionType: "Allow"}
+	groupACL = client.ACL{ResourceType: "Group", ResourceName: "billing", PatternType: "Prefixed", Principal: "User:bob",
+		Host: "*", Operation: "Read", PermissionType: "Allow"}
+)
+
+func tableRenderer() *ui.Renderer {
+	renderer, _ := ui.NewRenderer(ui.OutputTable, ioutil.Discard)
+	return renderer
+}
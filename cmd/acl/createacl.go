@@ -0,0 +1,79 @@
+package acl
+
+import (
+	"strings"
+
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// clusterResourceName is the name of the cluster resource, which is the same on all clusters
+const clusterResourceName = "kafka-cluster"
+
+type createACL struct {
+	creator    client.ACLCreator
+	acl        client.ACL
+	operations []string
+}
+
+var createACLCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create acls granting or denying operations on a resource to a principal",
+	Run: func(command *cobra.Command, args []string) {
+		cobraUtil := base.NewCobraUtil(command)
+		c := createACL{
+			creator: newClient(cobraUtil),
+			acl: client.ACL{
+				ResourceType:   cobraUtil.GetStringArg("resource-type"),
+				ResourceName:   cobraUtil.GetStringArg("resource-name"),
+				PatternType:    cobraUtil.GetStringArg("pattern-type"),
+				Principal:      cobraUtil.GetStringArg("principal"),
+				Host:           cobraUtil.GetStringArg("host"),
+				PermissionType: cobraUtil.GetStringArg("permission"),
+			},
+			operations: cobraUtil.GetStringSliceArg("operation"),
+		}
+		c.createACLs()
+	},
+}
+
+func init() {
+	createACLCmd.PersistentFlags().String("resource-type", "", "Resource type, one of topic|group|cluster|transactionalid|delegationtoken")
+	createACLCmd.PersistentFlags().String("resource-name", "", "Resource name, or the prefix of the names for the prefixed pattern type. "+
+		"Not needed for the cluster resource")
+	createACLCmd.PersistentFlags().String("pattern-type", "literal", "Pattern type of the resource name, one of literal|prefixed")
+	createACLCmd.PersistentFlags().String("principal", "", "Principal, like User:alice")
+	createACLCmd.PersistentFlags().String("host", "*", "Host the principal connects from")
+	createACLCmd.PersistentFlags().StringSlice("operation", []string{}, "Comma separated list of operations, like read,write,describe")
+	createACLCmd.PersistentFlags().String("permission", "allow", "Permission type, one of allow|deny")
+	for _, flag := range []string{"resource-type", "principal", "operation"} {
+		if err := createACLCmd.MarkPersistentFlagRequired(flag); err != nil {
+			logger.Fatal(err)
+		}
+	}
+}
+
+// createACLs creates one acl per operation
+func (c *createACL) createACLs() {
+	if strings.EqualFold(c.acl.ResourceType, "cluster") && c.acl.ResourceName == "" {
+		c.acl.ResourceName = clusterResourceName
+	}
+	if c.acl.ResourceName == "" {
+		logger.Fatalf("--resource-name is required for resource type %v\n", c.acl.ResourceType)
+	}
+
+	acls := make([]client.ACL, 0, len(c.operations))
+	for _, operation := range c.operations {
+		acl := c.acl
+		acl.Operation = operation
+		acls = append(acls, acl)
+	}
+	if err := c.creator.CreateACLs(acls); err != nil {
+		logger.Fatalf("Error while creating the acls - %v\n", err)
+	}
+	for _, acl := range acls {
+		logger.Infof("Created acl %v\n", acl)
+	}
+}
@@ -0,0 +1,63 @@
+package acl
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateACLs_CreatesOneACLPerOperation(t *testing.T) {
+	admin := &mockACLAdmin{}
+	admin.On("CreateACLs", []client.ACL{readACL, writeACL}).Return(nil)
+	acl := readACL
+	acl.Operation = ""
+	c := createACL{creator: admin, acl: acl, operations: []string{"Read", "Write"}}
+
+	c.createACLs()
+
+	admin.AssertExpectations(t)
+}
+
+func TestCreateACLs_DefaultsTheNameOfTheClusterResource(t *testing.T) {
+	admin := &mockACLAdmin{}
+	admin.On("CreateACLs", []client.ACL{{ResourceType: "cluster", ResourceName: "kafka-cluster", PatternType: "literal",
+		Principal: "User:alice", Host: "*", Operation: "alter", PermissionType: "allow"}}).Return(nil)
+	c := createACL{creator: admin, acl: client.ACL{ResourceType: "cluster", PatternType: "literal", Principal: "User:alice", Host: "*",
+		PermissionType: "allow"}, operations: []string{"alter"}}
+
+	c.createACLs()
+
+	admin.AssertExpectations(t)
+}
+
+func TestCreateACLs_ExitsWithoutResourceName(t *testing.T) {
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	admin := &mockACLAdmin{}
+	c := createACL{creator: admin, acl: client.ACL{ResourceType: "topic", Principal: "User:alice"}, operations: []string{"read"}}
+
+	assert.PanicsWithValue(t, "os.Exit called", c.createACLs)
+	admin.AssertNotCalled(t, "CreateACLs", []client.ACL{})
+}
+
+func TestCreateACLs_ExitsWhenTheCreateFails(t *testing.T) {
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	admin := &mockACLAdmin{}
+	admin.On("CreateACLs", []client.ACL{readACL}).Return(errors.New("error"))
+	acl := readACL
+	acl.Operation = ""
+	c := createACL{creator: admin, acl: acl, operations: []string{"Read"}}
+
+	assert.PanicsWithValue(t, "os.Exit called", c.createACLs)
+}
@@ -0,0 +1,59 @@
+package acl
+
+import (
+	"fmt"
+
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/ui"
+	"github.com/spf13/cobra"
+)
+
+type aclDeleter interface {
+	aclFilterer
+	DeleteACLs(acls []client.ACL) error
+}
+
+type deleteACL struct {
+	deleter   aclDeleter
+	userInput userInput
+	renderer  *ui.Renderer
+	filter    client.ACLFilter
+}
+
+var deleteACLCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete the acls matching the filters, on confirmation",
+	Run: func(command *cobra.Command, args []string) {
+		cobraUtil := base.NewCobraUtil(command)
+		d := deleteACL{deleter: newClient(cobraUtil), userInput: &ui.UserInput{}, renderer: cobraUtil.GetRenderer(),
+			filter: getFilter(cobraUtil)}
+		d.deleteACLs()
+	},
+}
+
+func init() {
+	addFilterFlags(deleteACLCmd)
+}
+
+// deleteACLs previews the acls matching the filter, and deletes exactly the previewed acls on confirmation
+func (d *deleteACL) deleteACLs() {
+	if d.filter == (client.ACLFilter{}) {
+		logger.Fatal("At least one filter is required to delete acls")
+	}
+	acls := filterACLs(d.deleter, d.filter)
+	if len(acls) == 0 {
+		logger.Info("No acls match the filters")
+		return
+	}
+	previewACLs(d.renderer, acls)
+
+	if !d.userInput.AskForConfirmation(fmt.Sprintf("Do you really want to delete %d acls?", len(acls))) {
+		return
+	}
+	if err := d.deleter.DeleteACLs(acls); err != nil {
+		logger.Fatalf("Error while deleting the acls - %v\n", err)
+	}
+	logger.Infof("Deleted %d acls\n", len(acls))
+}
@@ -0,0 +1,83 @@
+package acl
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDeleteACLs_DeletesTheMatchingACLsOnConfirmation(t *testing.T) {
+	filter := client.ACLFilter{ResourceName: "orders"}
+	admin := &mockACLAdmin{}
+	admin.On("FilterACLs", filter).Return([]client.ACL{writeACL, readACL}, nil)
+	admin.On("DeleteACLs", []client.ACL{readACL, writeACL}).Return(nil)
+	input := &mockUserInput{}
+	input.On("AskForConfirmation", "Do you really want to delete 2 acls?").Return(true)
+	d := deleteACL{deleter: admin, userInput: input, filter: filter, renderer: tableRenderer()}
+
+	d.deleteACLs()
+
+	admin.AssertExpectations(t)
+	input.AssertExpectations(t)
+}
+
+func TestDeleteACLs_DoesNotDeleteWithoutConfirmation(t *testing.T) {
+	filter := client.ACLFilter{ResourceName: "orders"}
+	admin := &mockACLAdmin{}
+	admin.On("FilterACLs", filter).Return([]client.ACL{readACL}, nil)
+	input := &mockUserInput{}
+	input.On("AskForConfirmation", "Do you really want to delete 1 acls?").Return(false)
+	d := deleteACL{deleter: admin, userInput: input, filter: filter, renderer: tableRenderer()}
+
+	d.deleteACLs()
+
+	admin.AssertNotCalled(t, "DeleteACLs", mock.Anything)
+}
+
+func TestDeleteACLs_DoesNothingWhenNoACLMatches(t *testing.T) {
+	filter := client.ACLFilter{ResourceName: "orders"}
+	admin := &mockACLAdmin{}
+	admin.On("FilterACLs", filter).Return([]client.ACL{}, nil)
+	input := &mockUserInput{}
+	d := deleteACL{deleter: admin, userInput: input, filter: filter, renderer: tableRenderer()}
+
+	d.deleteACLs()
+
+	input.AssertNotCalled(t, "AskForConfirmation", mock.Anything)
+	admin.AssertNotCalled(t, "DeleteACLs", mock.Anything)
+}
+
+func TestDeleteACLs_ExitsWithoutFilter(t *testing.T) {
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	admin := &mockACLAdmin{}
+	d := deleteACL{deleter: admin, userInput: &mockUserInput{}, renderer: tableRenderer()}
+
+	assert.PanicsWithValue(t, "os.Exit called", d.deleteACLs)
+	admin.AssertNotCalled(t, "FilterACLs", mock.Anything)
+}
+
+func TestDeleteACLs_ExitsWhenTheDeleteFails(t *testing.T) {
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	filter := client.ACLFilter{Operation: "read"}
+	admin := &mockACLAdmin{}
+	admin.On("FilterACLs", filter).Return([]client.ACL{readACL}, nil)
+	admin.On("DeleteACLs", []client.ACL{readACL}).Return(errors.New("error"))
+	input := &mockUserInput{}
+	input.On("AskForConfirmation", "Do you really want to delete 1 acls?").Return(true)
+	d := deleteACL{deleter: admin, userInput: input, filter: filter, renderer: tableRenderer()}
+
+	assert.PanicsWithValue(t, "os.Exit called", d.deleteACLs)
+}
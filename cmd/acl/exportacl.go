@@ -0,0 +1,42 @@
+package acl
+
+import (
+	"io"
+	"os"
+
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+type exportACL struct {
+	filterer aclFilterer
+	filter   client.ACLFilter
+	out      io.Writer
+}
+
+var exportACLCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Write the acls matching the filters as json to stdout, to be imported on another cluster",
+	Run: func(command *cobra.Command, args []string) {
+		cobraUtil := base.NewCobraUtil(command)
+		// the logs are kept out of the export, which is redirected to a file
+		logger.SetOutput(os.Stderr)
+		e := exportACL{filterer: newClient(cobraUtil), filter: getFilter(cobraUtil), out: os.Stdout}
+		e.exportACLs()
+	},
+}
+
+func init() {
+	addFilterFlags(exportACLCmd)
+}
+
+func (e *exportACL) exportACLs() {
+	acls := filterACLs(e.filterer, e.filter)
+	if err := model.WriteACLs(e.out, acls); err != nil {
+		logger.Fatalf("Error while writing the acls - %v\n", err)
+	}
+	logger.Infof("Exported %d acls\n", len(acls))
+}
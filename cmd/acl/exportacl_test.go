@@ -0,0 +1,36 @@
+package acl
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportACLs_WritesTheSortedACLs(t *testing.T) {
+	filter := client.ACLFilter{ResourceType: "topic"}
+	admin := &mockACLAdmin{}
+	admin.On("FilterACLs", filter).Return([]client.ACL{writeACL, readACL}, nil)
+	var out bytes.Buffer
+	e := exportACL{filterer: admin, filter: filter, out: &out}
+
+	e.exportACLs()
+
+	var acls []client.ACL
+	require.NoError(t, json.Unmarshal(out.Bytes(), &acls))
+	assert.Equal(t, []client.ACL{readACL, writeACL}, acls)
+}
+
+func TestExportACLs_WritesAnEmptyList(t *testing.T) {
+	admin := &mockACLAdmin{}
+	admin.On("FilterACLs", client.ACLFilter{}).Return([]client.ACL{}, nil)
+	var out bytes.Buffer
+	e := exportACL{filterer: admin, out: &out}
+
+	e.exportACLs()
+
+	assert.Equal(t, "[]\n", out.String())
+}
@@ -0,0 +1,74 @@
+package acl
+
+import (
+	"fmt"
+
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/gojek/kat/ui"
+	"github.com/spf13/cobra"
+)
+
+type aclImporter interface {
+	client.ACLLister
+	client.ACLCreator
+}
+
+type importACL struct {
+	importer  aclImporter
+	userInput userInput
+	renderer  *ui.Renderer
+	file      string
+	dryRun    bool
+}
+
+var importACLCmd = &cobra.Command{
+	Use:   "import <acls file>",
+	Short: "Create the acls exported from another cluster, skipping the acls that already exist",
+	Args:  cobra.ExactArgs(1),
+	Run: func(command *cobra.Command, args []string) {
+		cobraUtil := base.NewCobraUtil(command)
+		i := importACL{importer: newClient(cobraUtil), userInput: &ui.UserInput{}, renderer: cobraUtil.GetRenderer(), file: args[0],
+			dryRun: cobraUtil.GetBoolArg("dry-run")}
+		i.importACLs()
+	},
+}
+
+func init() {
+	importACLCmd.PersistentFlags().Bool("dry-run", false, "Only show the acls to be created, without creating them")
+}
+
+// importACLs previews the acls missing on the cluster, and creates them on confirmation
+func (i *importACL) importACLs() {
+	acls, err := model.LoadACLs(i.file)
+	if err != nil {
+		logger.Fatalf("Error while reading the acls - %v\n", err)
+	}
+	existing, err := i.importer.ListACLs()
+	if err != nil {
+		logger.Fatalf("Error while listing the acls - %v\n", err)
+	}
+	missing := model.MissingACLs(acls, existing)
+	if skipped := len(acls) - len(missing); skipped > 0 {
+		logger.Infof("Skipping %d acls that already exist\n", skipped)
+	}
+	if len(missing) == 0 {
+		logger.Info("No acls to import")
+		return
+	}
+	model.SortACLs(missing)
+	previewACLs(i.renderer, missing)
+	if i.dryRun {
+		return
+	}
+
+	if !i.userInput.AskForConfirmation(fmt.Sprintf("Do you really want to create %d acls?", len(missing))) {
+		return
+	}
+	if err = i.importer.CreateACLs(missing); err != nil {
+		logger.Fatalf("Error while creating the acls - %v\n", err)
+	}
+	logger.Infof("Imported %d acls\n", len(missing))
+}
@@ -0,0 +1,109 @@
+package acl
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/gojek/kat/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func writeACLs(t *testing.T, acls []client.ACL) (string, string) {
+	dir, err := ioutil.TempDir("", "acls")
+	require.NoError(t, err)
+	file := filepath.Join(dir, "acls.json")
+	out, err := os.Create(file)
+	require.NoError(t, err)
+	defer out.Close()
+	require.NoError(t, model.WriteACLs(out, acls))
+	return dir, file
+}
+
+func TestImportACLs_CreatesTheMissingACLsOnConfirmation(t *testing.T) {
+	dir, file := writeACLs(t, []client.ACL{readACL, writeACL, groupACL})
+	defer os.RemoveAll(dir)
+	admin := &mockACLAdmin{}
+	admin.On("ListACLs").Return([]client.ACL{readACL}, nil)
+	admin.On("CreateACLs", []client.ACL{groupACL, writeACL}).Return(nil)
+	input := &mockUserInput{}
+	input.On("AskForConfirmation", "Do you really want to create 2 acls?").Return(true)
+	i := importACL{importer: admin, userInput: input, file: file, renderer: tableRenderer()}
+
+	i.importACLs()
+
+	admin.AssertExpectations(t)
+	input.AssertExpectations(t)
+}
+
+func TestImportACLs_DryRunOnlyShowsTheACLs(t *testing.T) {
+	dir, file := writeACLs(t, []client.ACL{readACL})
+	defer os.RemoveAll(dir)
+	admin := &mockACLAdmin{}
+	admin.On("ListACLs").Return([]client.ACL{}, nil)
+	input := &mockUserInput{}
+	var out bytes.Buffer
+	renderer, err := ui.NewRenderer(ui.OutputCSV, &out)
+	require.NoError(t, err)
+	i := importACL{importer: admin, userInput: input, file: file, dryRun: true, renderer: renderer}
+
+	i.importACLs()
+
+	assert.Equal(t, "Resource Type,Resource Name,Pattern Type,Principal,Host,Operation,Permission\n"+
+		"Topic,orders,Literal,User:alice,*,Read,Allow\n", out.String())
+	input.AssertNotCalled(t, "AskForConfirmation", mock.Anything)
+	admin.AssertNotCalled(t, "CreateACLs", mock.Anything)
+}
+
+func TestImportACLs_DoesNothingWhenAllACLsExist(t *testing.T) {
+	dir, file := writeACLs(t, []client.ACL{readACL})
+	defer os.RemoveAll(dir)
+	admin := &mockACLAdmin{}
+	admin.On("ListACLs").Return([]client.ACL{readACL}, nil)
+	input := &mockUserInput{}
+	i := importACL{importer: admin, userInput: input, file: file, renderer: tableRenderer()}
+
+	i.importACLs()
+
+	input.AssertNotCalled(t, "AskForConfirmation", mock.Anything)
+	admin.AssertNotCalled(t, "CreateACLs", mock.Anything)
+}
+
+func TestImportACLs_ExitsForAnInvalidFile(t *testing.T) {
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	admin := &mockACLAdmin{}
+	i := importACL{importer: admin, userInput: &mockUserInput{}, file: "does-not-exist.json", renderer: tableRenderer()}
+
+	assert.PanicsWithValue(t, "os.Exit called", i.importACLs)
+	admin.AssertNotCalled(t, "ListACLs")
+}
+
+func TestImportACLs_ExitsWhenTheCreateFails(t *testing.T) {
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	dir, file := writeACLs(t, []client.ACL{readACL})
+	defer os.RemoveAll(dir)
+	admin := &mockACLAdmin{}
+	admin.On("ListACLs").Return([]client.ACL{}, nil)
+	admin.On("CreateACLs", []client.ACL{readACL}).Return(errors.New("error"))
+	input := &mockUserInput{}
+	input.On("AskForConfirmation", "Do you really want to create 1 acls?").Return(true)
+	i := importACL{importer: admin, userInput: input, file: file, renderer: tableRenderer()}
+
+	assert.PanicsWithValue(t, "os.Exit called", i.importACLs)
+}
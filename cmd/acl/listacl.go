@@ -0,0 +1,36 @@
+package acl
+
+import (
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/ui"
+	"github.com/spf13/cobra"
+)
+
+type listACL struct {
+	filterer aclFilterer
+	renderer *ui.Renderer
+	filter   client.ACLFilter
+}
+
+var listACLCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the acls matching the filters",
+	Run: func(command *cobra.Command, args []string) {
+		cobraUtil := base.NewCobraUtil(command)
+		l := listACL{filterer: newClient(cobraUtil), renderer: cobraUtil.GetRenderer(), filter: getFilter(cobraUtil)}
+		l.listACLs()
+	},
+}
+
+func init() {
+	addFilterFlags(listACLCmd)
+}
+
+func (l *listACL) listACLs() {
+	acls := filterACLs(l.filterer, l.filter)
+	if err := l.renderer.Render(aclRows(acls)); err != nil {
+		logger.Fatalf("Error while writing the acls - %v\n", err)
+	}
+}
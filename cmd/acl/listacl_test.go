@@ -0,0 +1,44 @@
+package acl
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListACLs_RendersTheSortedACLs(t *testing.T) {
+	filter := client.ACLFilter{Principal: "User:alice"}
+	admin := &mockACLAdmin{}
+	admin.On("FilterACLs", filter).Return([]client.ACL{writeACL, readACL}, nil)
+	var out bytes.Buffer
+	renderer, err := ui.NewRenderer(ui.OutputCSV, &out)
+	require.NoError(t, err)
+	l := listACL{filterer: admin, renderer: renderer, filter: filter}
+
+	l.listACLs()
+
+	assert.Equal(t, "Resource Type,Resource Name,Pattern Type,Principal,Host,Operation,Permission\n"+
+		"Topic,orders,Literal,User:alice,*,Read,Allow\nTopic,orders,Literal,User:alice,*,Write,Allow\n", out.String())
+	admin.AssertExpectations(t)
+}
+
+func TestListACLs_ExitsWhenTheListFails(t *testing.T) {
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	admin := &mockACLAdmin{}
+	admin.On("FilterACLs", client.ACLFilter{}).Return([]client.ACL{}, errors.New("error"))
+	renderer, _ := ui.NewRenderer(ui.OutputTable, &bytes.Buffer{})
+	l := listACL{filterer: admin, renderer: renderer}
+
+	assert.PanicsWithValue(t, "os.Exit called", l.listACLs)
+}
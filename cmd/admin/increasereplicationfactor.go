@@ -4,6 +4,7 @@ import (
 	"github.com/gojek/kat/cmd/base"
 	"github.com/gojek/kat/logger"
 	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/pkg/model"
 	"github.com/spf13/cobra"
 )
 
@@ -18,6 +19,7 @@ type increaseReplication struct {
 	timeoutPerBatchInS int
 	pollIntervalInS    int
 	throttle           int
+	safety             base.SafetyChecker
 }
 
 var IncreaseReplicationFactorCmd = &cobra.Command{
@@ -31,7 +33,8 @@ var IncreaseReplicationFactorCmd = &cobra.Command{
 			topics: cobraUtil.GetStringArg("topics"), replicationFactor: cobraUtil.GetIntArg("replication-factor"),
 			numOfBrokers: cobraUtil.GetIntArg("num-of-brokers"), batch: cobraUtil.GetIntArg("batch"),
 			timeoutPerBatchInS: cobraUtil.GetIntArg("timeout-per-batch"),
-			pollIntervalInS:    cobraUtil.GetIntArg("status-poll-interval"), throttle: cobraUtil.GetIntArg("throttle")}
+			pollIntervalInS:    cobraUtil.GetIntArg("status-poll-interval"), throttle: cobraUtil.GetIntArg("throttle"),
+			safety: cobraUtil.GetSafetyGuard()}
 		i.increaseReplicationFactor()
 	},
 }
@@ -71,6 +74,9 @@ func (i *increaseReplication) increaseReplicationFactor() {
 		return
 	}
 
+	if err = i.safety.Check(model.OperationReassign, topics); err != nil {
+		logger.Fatal(err)
+	}
 	topicMetadata, err := i.Describe(topics)
 	if err != nil {
 		logger.Fatalf("Error while fetching topic metadata - %v\n", err)
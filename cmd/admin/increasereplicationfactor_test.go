@@ -5,6 +5,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/gojek/kat/cmd/base"
 	"github.com/gojek/kat/pkg/client"
 	"github.com/stretchr/testify/mock"
 
@@ -29,7 +30,7 @@ func TestIncreaseReplicationFactor_Success(t *testing.T) {
 	mockLister.On("ListOnly", topicRegex, true).Return(topics, nil).Times(1)
 	mockDescriber.On("Describe", topics).Return(topicMetadata, nil).Times(1)
 	mockPartitioner.On("IncreaseReplication", topicMetadata, replicationFactor, numBrokers, batch, timeoutPerBatch, pollInterval, throttle).Return(nil).Times(1)
-	i := increaseReplication{Lister: mockLister, Describer: mockDescriber, Partitioner: mockPartitioner, replicationFactor: replicationFactor, topics: topicRegex, numOfBrokers: numBrokers, batch: batch, timeoutPerBatchInS: timeoutPerBatch, pollIntervalInS: pollInterval, throttle: throttle}
+	i := increaseReplication{Lister: mockLister, Describer: mockDescriber, Partitioner: mockPartitioner, replicationFactor: replicationFactor, topics: topicRegex, numOfBrokers: numBrokers, batch: batch, timeoutPerBatchInS: timeoutPerBatch, pollIntervalInS: pollInterval, throttle: throttle, safety: noSafetyPolicy()}
 	i.increaseReplicationFactor()
 	mockLister.AssertExpectations(t)
 	mockDescriber.AssertExpectations(t)
@@ -55,7 +56,7 @@ func TestIncreaseReplicationFactor_ListFailure(t *testing.T) {
 	}
 	patch := monkey.Patch(os.Exit, fakeExit)
 	defer patch.Unpatch()
-	i := increaseReplication{Lister: mockLister, Describer: mockDescriber, Partitioner: mockPartitioner, replicationFactor: replicationFactor, topics: topicRegex, numOfBrokers: numBrokers, batch: batch, timeoutPerBatchInS: timeoutPerBatch, pollIntervalInS: pollInterval, throttle: throttle}
+	i := increaseReplication{Lister: mockLister, Describer: mockDescriber, Partitioner: mockPartitioner, replicationFactor: replicationFactor, topics: topicRegex, numOfBrokers: numBrokers, batch: batch, timeoutPerBatchInS: timeoutPerBatch, pollIntervalInS: pollInterval, throttle: throttle, safety: noSafetyPolicy()}
 	assert.PanicsWithValue(t, "os.Exit called", i.increaseReplicationFactor, "os.Exit was not called")
 
 	mockDescriber.AssertNotCalled(t, "Describe", mock.Anything)
@@ -85,7 +86,7 @@ func TestIncreaseReplicationFactor_DescribeFailure(t *testing.T) {
 	}
 	patch := monkey.Patch(os.Exit, fakeExit)
 	defer patch.Unpatch()
-	i := increaseReplication{Lister: mockLister, Describer: mockDescriber, Partitioner: mockPartitioner, replicationFactor: replicationFactor, topics: topicRegex, numOfBrokers: numBrokers, batch: batch, timeoutPerBatchInS: timeoutPerBatch, pollIntervalInS: pollInterval, throttle: throttle}
+	i := increaseReplication{Lister: mockLister, Describer: mockDescriber, Partitioner: mockPartitioner, replicationFactor: replicationFactor, topics: topicRegex, numOfBrokers: numBrokers, batch: batch, timeoutPerBatchInS: timeoutPerBatch, pollIntervalInS: pollInterval, throttle: throttle, safety: noSafetyPolicy()}
 	assert.PanicsWithValue(t, "os.Exit called", i.increaseReplicationFactor, "os.Exit was not called")
 
 	mockPartitioner.AssertNotCalled(t, "IncreaseReplication", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
@@ -116,7 +117,7 @@ func TestIncreaseReplicationFactor_IncreaseReplicationFailure(t *testing.T) {
 	}
 	patch := monkey.Patch(os.Exit, fakeExit)
 	defer patch.Unpatch()
-	i := increaseReplication{Lister: mockLister, Describer: mockDescriber, Partitioner: mockPartitioner, replicationFactor: replicationFactor, topics: topicRegex, numOfBrokers: numBrokers, batch: batch, timeoutPerBatchInS: timeoutPerBatch, pollIntervalInS: pollInterval, throttle: throttle}
+	i := increaseReplication{Lister: mockLister, Describer: mockDescriber, Partitioner: mockPartitioner, replicationFactor: replicationFactor, topics: topicRegex, numOfBrokers: numBrokers, batch: batch, timeoutPerBatchInS: timeoutPerBatch, pollIntervalInS: pollInterval, throttle: throttle, safety: noSafetyPolicy()}
 	assert.PanicsWithValue(t, "os.Exit called", i.increaseReplicationFactor, "os.Exit was not called")
 
 	mockLister.AssertExpectations(t)
@@ -138,7 +139,7 @@ func TestIncreaseReplicationFactor_NoMatch(t *testing.T) {
 	topicRegex := "topic1|topic2"
 
 	mockLister.On("ListOnly", topicRegex, true).Return(topics, nil).Times(1)
-	i := increaseReplication{Lister: mockLister, Describer: mockDescriber, Partitioner: mockPartitioner, replicationFactor: replicationFactor, topics: topicRegex, numOfBrokers: numBrokers, batch: batch, timeoutPerBatchInS: timeoutPerBatch, pollIntervalInS: pollInterval, throttle: throttle}
+	i := increaseReplication{Lister: mockLister, Describer: mockDescriber, Partitioner: mockPartitioner, replicationFactor: replicationFactor, topics: topicRegex, numOfBrokers: numBrokers, batch: batch, timeoutPerBatchInS: timeoutPerBatch, pollIntervalInS: pollInterval, throttle: throttle, safety: noSafetyPolicy()}
 	i.increaseReplicationFactor()
 	mockDescriber.AssertNotCalled(t, "Describe", mock.Anything)
 	mockPartitioner.AssertNotCalled(t, "IncreaseReplication", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
@@ -146,3 +147,9 @@ func TestIncreaseReplicationFactor_NoMatch(t *testing.T) {
 	mockDescriber.AssertExpectations(t)
 	mockPartitioner.AssertExpectations(t)
 }
+
+func noSafetyPolicy() *base.MockSafetyGuard {
+	safety := &base.MockSafetyGuard{}
+	safety.On("Check", mock.Anything, mock.Anything).Return(nil)
+	return safety
+}
@@ -27,6 +27,7 @@ type reassignPartitions struct {
 	pollIntervalInS    int
 	throttle           int
 	resumptionFile     string
+	safety             base.SafetyChecker
 }
 
 var ReassignPartitionsCmd = &cobra.Command{
@@ -40,7 +41,7 @@ var ReassignPartitionsCmd = &cobra.Command{
 			brokerIds: cobraUtil.GetStringArg("broker-ids"), topicBatchSize: cobraUtil.GetIntArg("topic-batch-size"),
 			partitionBatchSize: cobraUtil.GetIntArg("partition-batch-size"), timeoutPerBatchInS: cobraUtil.GetIntArg("timeout-per-batch"),
 			pollIntervalInS: cobraUtil.GetIntArg("status-poll-interval"), throttle: cobraUtil.GetIntArg("throttle"),
-			resumptionFile: cobraUtil.GetStringArg("resume")}
+			resumptionFile: cobraUtil.GetStringArg("resume"), safety: cobraUtil.GetSafetyGuard()}
 		r.reassignPartitions()
 	},
 }
@@ -89,6 +90,9 @@ func (r *reassignPartitions) reassignPartitions() {
 		}
 	}
 
+	if err = r.safety.Check(model.OperationReassign, topics); err != nil {
+		logger.Fatal(err)
+	}
 	err = r.ReassignPartitions(topics, r.brokerIds, r.topicBatchSize, r.timeoutPerBatchInS, r.pollIntervalInS, r.throttle, r.partitionBatchSize)
 	if err != nil {
 		logger.Errorf("Error while reassigning partitions: %s", err)
@@ -0,0 +1,19 @@
+package audit
+
+import (
+	"github.com/gojek/kat/logger"
+	"github.com/spf13/cobra"
+)
+
+var AuditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Audit the cluster against a baseline policy",
+}
+
+func init() {
+	AuditCmd.PersistentFlags().StringP("broker-list", "b", "", "Comma separated list of broker ips")
+	if err := AuditCmd.MarkPersistentFlagRequired("broker-list"); err != nil {
+		logger.Fatal(err)
+	}
+	AuditCmd.AddCommand(auditConfigCmd)
+}
@@ -0,0 +1,154 @@
+package audit
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/gojek/kat/ui"
+	"github.com/spf13/cobra"
+)
+
+type auditConfig struct {
+	client.Lister
+	client.Configurer
+	policy   *model.ConfigPolicy
+	renderer *ui.Renderer
+	fix      bool
+	safety   base.SafetyChecker
+}
+
+var auditConfigCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Audit the topic configs against the policy and optionally fix the violations",
+	Run: func(command *cobra.Command, args []string) {
+		cobraUtil := base.NewCobraUtil(command)
+		policy, err := model.LoadConfigPolicy(cobraUtil.GetStringArg("policy"))
+		if err != nil {
+			logger.Fatalf("Error while loading policy - %v\n", err)
+		}
+		baseCmd := base.Init(cobraUtil)
+		a := auditConfig{
+			Lister:     baseCmd.GetTopic(),
+			Configurer: baseCmd.GetTopic(),
+			policy:     policy,
+			renderer:   cobraUtil.GetRenderer(),
+			fix:        cobraUtil.GetBoolArg("fix"),
+			safety:     cobraUtil.GetSafetyGuard(),
+		}
+		a.auditConfig()
+	},
+}
+
+func init() {
+	auditConfigCmd.PersistentFlags().StringP("policy", "p", "", "Path to the policy file")
+	auditConfigCmd.PersistentFlags().Bool("fix", false, "Apply the corrections for the violations that can be fixed")
+	if err := auditConfigCmd.MarkPersistentFlagRequired("policy"); err != nil {
+		logger.Fatal(err)
+	}
+}
+
+func (a *auditConfig) auditConfig() {
+	violations, err := a.evaluate()
+	if err != nil {
+		logger.Fatalf("Error while auditing configs - %v\n", err)
+	}
+	if len(violations) == 0 {
+		logger.Info("No config violations found")
+		return
+	}
+
+	fixErrors := make(map[string]error)
+	if a.fix {
+		fixErrors = a.applyFixes(violations)
+	}
+	a.print(violations, fixErrors)
+
+	// the audit fails when violations remain, so that it can gate deployments
+	if remaining := a.remaining(violations, fixErrors); remaining > 0 {
+		logger.Fatalf("%d of %d config violations remain\n", remaining, len(violations))
+	}
+	logger.Infof("Fixed all %d config violations\n", len(violations))
+}
+
+// remaining returns the number of violations that are not fixed
+func (a *auditConfig) remaining(violations []model.ConfigViolation, fixErrors map[string]error) int {
+	remaining := 0
+	for _, violation := range violations {
+		if !a.fixed(violation, fixErrors) {
+			remaining++
+		}
+	}
+	return remaining
+}
+
+func (a *auditConfig) fixed(violation model.ConfigViolation, fixErrors map[string]error) bool {
+	return a.fix && violation.Fix != nil && fixErrors[violation.Topic] == nil
+}
+
+func (a *auditConfig) evaluate() ([]model.ConfigViolation, error) {
+	topicDetails, err := a.List()
+	if err != nil {
+		return nil, fmt.Errorf("err while fetching topics - %v", err)
+	}
+	topics := make([]string, 0, len(topicDetails))
+	for topic := range topicDetails {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+
+	var violations []model.ConfigViolation
+	for _, topic := range topics {
+		configs, configErr := a.GetConfig(topic)
+		if configErr != nil {
+			return nil, fmt.Errorf("err while reading config for topic %v - %v", topic, configErr)
+		}
+		violations = append(violations, a.policy.Evaluate(topic, topicDetails[topic], configs)...)
+	}
+	return violations, nil
+}
+
+func (a *auditConfig) applyFixes(violations []model.ConfigViolation) map[string]error {
+	var topics []string
+	topicEntries := make(map[string]map[string]client.IncrementalConfigEntry)
+	for _, violation := range violations {
+		if violation.Fix == nil {
+			continue
+		}
+		if topicEntries[violation.Topic] == nil {
+			topicEntries[violation.Topic] = make(map[string]client.IncrementalConfigEntry)
+			topics = append(topics, violation.Topic)
+		}
+		topicEntries[violation.Topic][violation.Config] = *violation.Fix
+	}
+
+	fixErrors := make(map[string]error)
+	if len(topics) == 0 {
+		return fixErrors
+	}
+	if err := a.safety.Check(model.OperationAlterConfig, topics); err != nil {
+		logger.Fatal(err)
+	}
+	for _, topic := range topics {
+		err := a.IncrementalUpdateConfig([]string{topic}, topicEntries[topic], false)
+		if err != nil {
+			logger.Errorf("Err while fixing config for topic %v - %v\n", topic, err)
+			fixErrors[topic] = err
+		}
+	}
+	return fixErrors
+}
+
+func (a *auditConfig) print(violations []model.ConfigViolation, fixErrors map[string]error) {
+	rows := make([]ui.Row, 0, len(violations))
+	for _, violation := range violations {
+		rows = append(rows, ui.ConfigViolation(violation.Topic, violation.Config, violation.Value, violation.Expected,
+			violation.Fix != nil, a.fix, fixErrors[violation.Topic]))
+	}
+	if err := a.renderer.Render(rows); err != nil {
+		logger.Fatalf("Error while printing the config violations - %v\n", err)
+	}
+}
@@ -0,0 +1,138 @@
+package audit
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/gojek/kat/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	logger.SetDummyLogger()
+}
+
+type mockListerConfigurer struct {
+	client.MockLister
+	client.MockConfigurer
+}
+
+func testPolicy(t *testing.T) *model.ConfigPolicy {
+	file, err := ioutil.TempFile("", "policy-*.yaml")
+	require.NoError(t, err)
+	defer os.Remove(file.Name())
+	_, err = file.WriteString("rules:\n  - topics: \".*\"\n    configs:\n      retention.ms:\n        min: 3600000\n      segment.bytes:\n        required: true\n")
+	require.NoError(t, err)
+	policy, err := model.LoadConfigPolicy(file.Name())
+	require.NoError(t, err)
+	return policy
+}
+
+func TestAuditConfig_Violations(t *testing.T) {
+	cli := &mockListerConfigurer{}
+	cli.MockLister.On("List").Return(map[string]client.TopicDetail{"topic1": {}, "topic2": {}}, nil)
+	cli.MockConfigurer.On("GetConfig", "topic1").Return([]client.ConfigEntry{
+		{Name: "retention.ms", Value: "100", Source: client.ConfigSourceTopic},
+		{Name: "segment.bytes", Value: "100", Source: client.ConfigSourceTopic},
+	}, nil)
+	cli.MockConfigurer.On("GetConfig", "topic2").Return([]client.ConfigEntry{
+		{Name: "retention.ms", Value: "3600000", Source: client.ConfigSourceTopic},
+		{Name: "segment.bytes", Value: "100", Source: client.ConfigSourceDefault, Default: true},
+	}, nil)
+	a := auditConfig{Lister: cli, Configurer: cli, policy: testPolicy(t), renderer: tableRenderer(), safety: noSafetyPolicy()}
+
+	violations, err := a.evaluate()
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(violations))
+	assert.Equal(t, "topic1", violations[0].Topic)
+	assert.Equal(t, "retention.ms", violations[0].Config)
+	assert.Equal(t, "topic2", violations[1].Topic)
+	assert.Equal(t, "segment.bytes", violations[1].Config)
+
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	assert.PanicsWithValue(t, "os.Exit called", a.auditConfig, "os.Exit was not called")
+	cli.MockConfigurer.AssertNotCalled(t, "IncrementalUpdateConfig", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAuditConfig_FixAppliesOnlyFixableViolations(t *testing.T) {
+	cli := &mockListerConfigurer{}
+	cli.MockLister.On("List").Return(map[string]client.TopicDetail{"topic1": {}}, nil)
+	cli.MockConfigurer.On("GetConfig", "topic1").Return([]client.ConfigEntry{
+		{Name: "retention.ms", Value: "100", Source: client.ConfigSourceTopic},
+		{Name: "segment.bytes", Value: "100", Source: client.ConfigSourceDefault, Default: true},
+	}, nil)
+	value := "3600000"
+	entries := map[string]client.IncrementalConfigEntry{"retention.ms": {Operation: client.ConfigOperationSet, Value: &value}}
+	cli.MockConfigurer.On("IncrementalUpdateConfig", []string{"topic1"}, entries, false).Return(errors.New("error"))
+	out := &bytes.Buffer{}
+	renderer, _ := ui.NewRenderer(ui.OutputJSON, out)
+	a := auditConfig{Lister: cli, Configurer: cli, policy: testPolicy(t), renderer: renderer, fix: true, safety: noSafetyPolicy()}
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+
+	assert.PanicsWithValue(t, "os.Exit called", a.auditConfig, "os.Exit was not called")
+	cli.MockConfigurer.AssertExpectations(t)
+	assert.Contains(t, out.String(), `"config": "retention.ms"`)
+	assert.Contains(t, out.String(), `"status": "FixFailure"`)
+	assert.Contains(t, out.String(), `"reason": "error"`)
+	assert.Contains(t, out.String(), `"status": "NotFixable"`)
+}
+
+func TestAuditConfig_SucceedsWhenAllViolationsAreFixed(t *testing.T) {
+	cli := &mockListerConfigurer{}
+	cli.MockLister.On("List").Return(map[string]client.TopicDetail{"topic1": {}}, nil)
+	cli.MockConfigurer.On("GetConfig", "topic1").Return([]client.ConfigEntry{
+		{Name: "retention.ms", Value: "100", Source: client.ConfigSourceTopic},
+		{Name: "segment.bytes", Value: "100", Source: client.ConfigSourceTopic},
+	}, nil)
+	value := "3600000"
+	entries := map[string]client.IncrementalConfigEntry{"retention.ms": {Operation: client.ConfigOperationSet, Value: &value}}
+	cli.MockConfigurer.On("IncrementalUpdateConfig", []string{"topic1"}, entries, false).Return(nil)
+	a := auditConfig{Lister: cli, Configurer: cli, policy: testPolicy(t), renderer: tableRenderer(), fix: true, safety: noSafetyPolicy()}
+
+	a.auditConfig()
+
+	cli.MockConfigurer.AssertExpectations(t)
+}
+
+func TestAuditConfig_ListFailure(t *testing.T) {
+	cli := &mockListerConfigurer{}
+	cli.MockLister.On("List").Return(map[string]client.TopicDetail{}, errors.New("error"))
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	a := auditConfig{Lister: cli, Configurer: cli, policy: testPolicy(t), renderer: tableRenderer(), safety: noSafetyPolicy()}
+
+	assert.PanicsWithValue(t, "os.Exit called", a.auditConfig, "os.Exit was not called")
+	cli.MockLister.AssertExpectations(t)
+}
+
+func tableRenderer() *ui.Renderer {
+	renderer, _ := ui.NewRenderer(ui.OutputTable, ioutil.Discard)
+	return renderer
+}
+
+func noSafetyPolicy() *base.MockSafetyGuard {
+	safety := &base.MockSafetyGuard{}
+	safety.On("Check", mock.Anything, mock.Anything).Return(nil)
+	return safety
+}
@@ -11,10 +11,18 @@ import (
 	"github.com/mitchellh/go-homedir"
 )
 
+// Sources of the last write time of the topics, for finding the stale topics
+const (
+	LastWriteSourceSSH = "ssh"
+	LastWriteSourceAPI = "api"
+)
+
 type Cmd struct {
 	cobraUtil  *CobraUtil
 	enableSSH  bool
+	enableAPI  bool
 	brokerAddr string
+	client     *client.SaramaClient
 	topic      *model.Topic
 	partition  *model.Partition
 }
@@ -38,6 +46,20 @@ func WithSSH() Opts {
 	}
 }
 
+// WithLastWriteSource finds the stale topics by sshing into the brokers, or with the broker api
+func WithLastWriteSource(source string) Opts {
+	return func(baseCmd *Cmd) {
+		switch source {
+		case LastWriteSourceSSH:
+			WithSSH()(baseCmd)
+		case LastWriteSourceAPI:
+			baseCmd.enableAPI = true
+		default:
+			logger.Fatalf("Invalid last write source %v, expected one of %v|%v\n", source, LastWriteSourceSSH, LastWriteSourceAPI)
+		}
+	}
+}
+
 func WithPartition(zookeeper string) Opts {
 	return func(baseCmd *Cmd) {
 		baseCmd.partition = model.NewPartition(zookeeper)
@@ -60,7 +82,11 @@ func (b *Cmd) setTopic() {
 		}
 		opts = append(opts, model.WithSSHClient(ssh_config.Get("*", "User"), b.cobraUtil.GetStringArg("ssh-port"), keyFile))
 	}
-	topic, err := model.NewTopic(client.NewSaramaClient(addr), opts...)
+	b.client = client.NewSaramaClient(addr)
+	if b.enableAPI {
+		opts = append(opts, model.WithBrokerAPIStaleTopics(b.client, b.client))
+	}
+	topic, err := model.NewTopic(b.client, opts...)
 	if err != nil {
 		logger.Fatalf("Err on creating topic client - %v\n", err)
 	}
@@ -72,6 +98,11 @@ func (b *Cmd) GetTopic() *model.Topic {
 	return b.topic
 }
 
+// GetClient returns the kafka client the topic is created with, for the apis the topic does not wrap
+func (b *Cmd) GetClient() *client.SaramaClient {
+	return b.client
+}
+
 func (b *Cmd) GetPartition() *model.Partition {
 	return b.partition
 }
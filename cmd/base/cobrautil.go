@@ -4,6 +4,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gojek/kat/logger"
 
@@ -51,6 +52,20 @@ func (u *CobraUtil) GetBoolArg(argName string) bool {
 
 }
 
+func (u *CobraUtil) GetDurationArg(argName string) time.Duration {
+	strVal := u.GetStringArg(argName)
+	if strVal == "" {
+		return 0
+	}
+
+	val, err := time.ParseDuration(strVal)
+	if err != nil {
+		logger.Errorf("Error while retrieving duration argument: %v\n", err)
+		os.Exit(1)
+	}
+	return val
+}
+
 func (u *CobraUtil) GetStringSliceArg(argName string) []string {
 	stringSlice, err := u.cmd.Flags().GetStringSlice(argName)
 	if err != nil {
@@ -61,6 +76,19 @@ func (u *CobraUtil) GetStringSliceArg(argName string) []string {
 
 }
 
+func (u *CobraUtil) GetInt32SliceArg(argName string) []int32 {
+	intSlice, err := u.cmd.Flags().GetIntSlice(argName)
+	if err != nil {
+		logger.Errorf("Error while retrieving int slice argument: %v\n", err)
+		os.Exit(1)
+	}
+	int32Slice := make([]int32, 0, len(intSlice))
+	for _, val := range intSlice {
+		int32Slice = append(int32Slice, int32(val))
+	}
+	return int32Slice
+}
+
 func (u *CobraUtil) GetTopicNames() []string {
 	return strings.Split(u.GetStringArg("topics"), ",")
 }
@@ -2,6 +2,7 @@ package base
 
 import (
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
@@ -16,6 +17,8 @@ func init() {
 	testCmd.PersistentFlags().StringP("key2", "", "", "test key 2")
 	testCmd.PersistentFlags().StringP("topics", "t", "", "topics")
 	testCmd.PersistentFlags().Bool("increase-partitions", false, "partitions")
+	testCmd.PersistentFlags().Duration("interval", 0, "interval")
+	testCmd.PersistentFlags().IntSlice("partitions", []int{}, "partitions")
 }
 
 func TestCobraUtil_GetCmdArgReturnsValue(t *testing.T) {
@@ -85,3 +88,27 @@ func TestCobraUtil_GetBoolArgsReturnsTrue(t *testing.T) {
 
 	assert.Equal(t, true, val)
 }
+
+func TestCobraUtil_GetDurationArgReturnsDurationValue(t *testing.T) {
+	testCmd.SetArgs([]string{
+		"--interval=5m",
+	})
+	testCmd.Execute()
+
+	util := NewCobraUtil(testCmd)
+	value := util.GetDurationArg("interval")
+
+	assert.Equal(t, 5*time.Minute, value)
+}
+
+func TestCobraUtil_GetInt32SliceArgReturnsSliceValue(t *testing.T) {
+	testCmd.SetArgs([]string{
+		"--partitions=1,3",
+	})
+	testCmd.Execute()
+
+	util := NewCobraUtil(testCmd)
+	value := util.GetInt32SliceArg("partitions")
+
+	assert.Equal(t, []int32{1, 3}, value)
+}
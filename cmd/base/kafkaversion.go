@@ -0,0 +1,20 @@
+package base
+
+import (
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// AddKafkaVersionFlag adds the flag of the version of the brokers the clients are created for
+func AddKafkaVersionFlag(command *cobra.Command) {
+	command.PersistentFlags().String("kafka-version", client.DefaultKafkaVersion,
+		"Version of the kafka brokers, configs are changed with the AlterConfigs api on brokers older than 2.3.0")
+}
+
+// SetKafkaVersion sets the version of the brokers passed with the kafka version flag
+func (u *CobraUtil) SetKafkaVersion() {
+	if err := client.SetKafkaVersion(u.GetStringArg("kafka-version")); err != nil {
+		logger.Fatalf("Error while parsing the kafka version - %v\n", err)
+	}
+}
@@ -0,0 +1,12 @@
+package base
+
+import "github.com/stretchr/testify/mock"
+
+type MockSafetyGuard struct {
+	mock.Mock
+}
+
+func (m *MockSafetyGuard) Check(operation string, topics []string) error {
+	args := m.Called(operation, topics)
+	return args.Error(0)
+}
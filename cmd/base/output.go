@@ -0,0 +1,27 @@
+package base
+
+import (
+	"os"
+
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/ui"
+	"github.com/spf13/cobra"
+)
+
+// AddOutputFlag adds the flag of the output format of the results
+func AddOutputFlag(command *cobra.Command) {
+	command.PersistentFlags().StringP("output", "o", ui.OutputTable, "Output format of the results, one of table|wide|json|yaml|csv|markdown")
+}
+
+// GetRenderer returns the renderer of the results to stdout.
+// The logs are written to stderr for the machine readable formats, so that stdout can be parsed.
+func (u *CobraUtil) GetRenderer() *ui.Renderer {
+	renderer, err := ui.NewRenderer(u.GetStringArg("output"), os.Stdout)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	if renderer.IsMachineReadable() {
+		logger.SetOutput(os.Stderr)
+	}
+	return renderer
+}
@@ -0,0 +1,83 @@
+package base
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/gojek/kat/ui"
+	"github.com/spf13/cobra"
+)
+
+const safetyPolicyEnv = "KAT_SAFETY_POLICY"
+
+type textInput interface {
+	AskForText(question string) string
+}
+
+// SafetyChecker checks whether a destructive operation on the topics is permitted, it is implemented by SafetyGuard
+type SafetyChecker interface {
+	Check(operation string, topics []string) error
+}
+
+// SafetyGuard checks the destructive operations against the safety policy of the cluster.
+// The confirmation phrase of a production cluster is asked only once per command.
+type SafetyGuard struct {
+	policy    *model.SafetyPolicy
+	user      string
+	phrase    string
+	input     textInput
+	confirmed bool
+}
+
+func NewSafetyGuard(policy *model.SafetyPolicy, user, phrase string, input textInput) *SafetyGuard {
+	return &SafetyGuard{policy: policy, user: user, phrase: phrase, input: input}
+}
+
+// AddSafetyPolicyFlags adds the flags of the safety policy file and the confirmation phrase
+func AddSafetyPolicyFlags(command *cobra.Command) {
+	command.PersistentFlags().String("safety-policy", os.Getenv(safetyPolicyEnv),
+		"Path to the safety policy file checked before destructive operations, defaults to $"+safetyPolicyEnv)
+	command.PersistentFlags().String("confirmation-phrase", "",
+		"Confirmation phrase of the safety policy for production clusters, asked interactively when not passed")
+}
+
+// GetSafetyGuard returns the guard for the safety policy file, or a guard that permits everything when no file is passed
+func (u *CobraUtil) GetSafetyGuard() *SafetyGuard {
+	policy := &model.SafetyPolicy{}
+	if fileName := u.GetStringArg("safety-policy"); fileName != "" {
+		var err error
+		if policy, err = model.LoadSafetyPolicy(fileName); err != nil {
+			logger.Fatalf("Error while loading the safety policy - %v\n", err)
+		}
+	}
+	return NewSafetyGuard(policy, currentUser(), u.GetStringArg("confirmation-phrase"), &ui.UserInput{})
+}
+
+// Check returns an error if the operation on the topics is not permitted by the policy, or is not confirmed
+func (g *SafetyGuard) Check(operation string, topics []string) error {
+	if err := g.policy.Check(operation, g.user, topics); err != nil {
+		return fmt.Errorf("refused by the safety policy - %v", err)
+	}
+	if !g.policy.RequiresConfirmation() || g.confirmed {
+		return nil
+	}
+	phrase := g.phrase
+	if phrase == "" {
+		phrase = g.input.AskForText("This is a production cluster, type the confirmation phrase to continue")
+	}
+	if !g.policy.Confirmed(phrase) {
+		return fmt.Errorf("refused by the safety policy - the confirmation phrase does not match")
+	}
+	g.confirmed = true
+	return nil
+}
+
+func currentUser() string {
+	if current, err := user.Current(); err == nil {
+		return current.Username
+	}
+	return os.Getenv("USER")
+}
@@ -0,0 +1,76 @@
+package base
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/gojek/kat/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTextInput struct {
+	mock.Mock
+}
+
+func (m *mockTextInput) AskForText(question string) string {
+	args := m.Called(question)
+	return args.String(0)
+}
+
+func productionPolicy(t *testing.T) *model.SafetyPolicy {
+	file, err := ioutil.TempFile("", "safety-*.yaml")
+	require.NoError(t, err)
+	defer os.Remove(file.Name())
+	_, err = file.WriteString("protectedTopics: [\"^payments\"]\nproduction: true\nconfirmationPhrase: kafka-prod\nallowedUsers: [alice]\n")
+	require.NoError(t, err)
+	policy, err := model.LoadSafetyPolicy(file.Name())
+	require.NoError(t, err)
+	return policy
+}
+
+func TestSafetyGuard_AsksForTheConfirmationPhraseOnce(t *testing.T) {
+	input := &mockTextInput{}
+	input.On("AskForText", mock.Anything).Return("kafka-prod").Once()
+	guard := NewSafetyGuard(productionPolicy(t), "alice", "", input)
+
+	assert.NoError(t, guard.Check(model.OperationDelete, []string{"orders"}))
+	assert.NoError(t, guard.Check(model.OperationAlterConfig, []string{"orders"}))
+	input.AssertExpectations(t)
+}
+
+func TestSafetyGuard_UsesThePassedConfirmationPhrase(t *testing.T) {
+	input := &mockTextInput{}
+	guard := NewSafetyGuard(productionPolicy(t), "alice", "kafka-prod", input)
+
+	assert.NoError(t, guard.Check(model.OperationDelete, []string{"orders"}))
+	input.AssertNotCalled(t, "AskForText", mock.Anything)
+}
+
+func TestSafetyGuard_RefusesAWrongConfirmationPhrase(t *testing.T) {
+	input := &mockTextInput{}
+	input.On("AskForText", mock.Anything).Return("yes")
+	guard := NewSafetyGuard(productionPolicy(t), "alice", "", input)
+
+	err := guard.Check(model.OperationDelete, []string{"orders"})
+
+	assert.EqualError(t, err, "refused by the safety policy - the confirmation phrase does not match")
+}
+
+func TestSafetyGuard_RefusesBeforeAskingForTheConfirmationPhrase(t *testing.T) {
+	input := &mockTextInput{}
+	guard := NewSafetyGuard(productionPolicy(t), "alice", "", input)
+
+	err := guard.Check(model.OperationDelete, []string{"payments"})
+
+	assert.EqualError(t, err, "refused by the safety policy - topics [payments] are protected from delete")
+	input.AssertNotCalled(t, "AskForText", mock.Anything)
+}
+
+func TestSafetyGuard_EmptyPolicyPermitsEverything(t *testing.T) {
+	guard := NewSafetyGuard(&model.SafetyPolicy{}, "carol", "", nil)
+
+	assert.NoError(t, guard.Check(model.OperationDelete, []string{"payments"}))
+}
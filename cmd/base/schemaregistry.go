@@ -0,0 +1,26 @@
+package base
+
+import (
+	"github.com/gojek/kat/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// AddSchemaRegistryFlags adds the flags of the schema registry url and its basic auth credentials
+func AddSchemaRegistryFlags(command *cobra.Command) {
+	command.PersistentFlags().String("schema-registry-url", "", "Url of the schema registry, eg: http://localhost:8081")
+	command.PersistentFlags().String("schema-registry-user", "", "User for the basic auth of the schema registry")
+	command.PersistentFlags().String("schema-registry-password", "", "Password for the basic auth of the schema registry")
+}
+
+// GetSchemaRegistry returns the schema registry client, or nil when the url is not passed
+func (u *CobraUtil) GetSchemaRegistry() *client.HTTPSchemaRegistry {
+	url := u.GetStringArg("schema-registry-url")
+	if url == "" {
+		return nil
+	}
+	return client.NewHTTPSchemaRegistry(client.SchemaRegistryConfig{
+		URL:      url,
+		Username: u.GetStringArg("schema-registry-user"),
+		Password: u.GetStringArg("schema-registry-password"),
+	})
+}
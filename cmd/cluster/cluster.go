@@ -0,0 +1,16 @@
+package cluster
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var ClusterCmd = &cobra.Command{
+	Use:   "cluster",
+	Short: "Admin commands on clusters",
+}
+
+func init() {
+	ClusterCmd.AddCommand(diffCmd)
+	ClusterCmd.AddCommand(snapshotCmd)
+	ClusterCmd.AddCommand(restoreCmd)
+}
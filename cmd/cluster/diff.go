@@ -0,0 +1,102 @@
+package cluster
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/gojek/kat/ui"
+	"github.com/spf13/cobra"
+)
+
+type diff struct {
+	source                cluster
+	destination           cluster
+	includeACLs           bool
+	includeConsumerGroups bool
+	renderer              *ui.Renderer
+	// out is where the summary of the markdown report is written, before the differences
+	out io.Writer
+}
+
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare the topics, configs and optionally the acls and consumer groups of two clusters",
+	Run: func(command *cobra.Command, args []string) {
+		cobraUtil := base.NewCobraUtil(command)
+		d := diff{
+			source:                newCluster(cobraUtil, "source-broker-ips"),
+			destination:           newCluster(cobraUtil, "destination-broker-ips"),
+			includeACLs:           cobraUtil.GetBoolArg("include-acls"),
+			includeConsumerGroups: cobraUtil.GetBoolArg("include-consumer-groups"),
+			renderer:              cobraUtil.GetRenderer(),
+			out:                   os.Stdout,
+		}
+		d.diff()
+	},
+}
+
+func init() {
+	diffCmd.PersistentFlags().StringP("source-broker-ips", "b", "", "Comma separated list of source broker ips")
+	diffCmd.PersistentFlags().StringP("destination-broker-ips", "d", "", "Comma separated list of broker ips to compare the source with")
+	diffCmd.PersistentFlags().Bool("include-acls", false, "Compare the acls of the clusters")
+	diffCmd.PersistentFlags().Bool("include-consumer-groups", false, "Compare the consumer groups of the clusters")
+	if err := diffCmd.MarkPersistentFlagRequired("source-broker-ips"); err != nil {
+		logger.Fatal(err)
+	}
+	if err := diffCmd.MarkPersistentFlagRequired("destination-broker-ips"); err != nil {
+		logger.Fatal(err)
+	}
+}
+
+func (d *diff) diff() {
+	sourceState, err := d.source.readState(d.includeACLs, d.includeConsumerGroups)
+	if err != nil {
+		logger.Fatalf("Error while reading the source cluster - %v\n", err)
+	}
+	destinationState, err := d.destination.readState(d.includeACLs, d.includeConsumerGroups)
+	if err != nil {
+		logger.Fatalf("Error while reading the destination cluster - %v\n", err)
+	}
+
+	diffs := model.DiffClusters(sourceState, destinationState)
+	if d.renderer.Format() == ui.OutputMarkdown {
+		printSummary(d.out, diffs)
+	} else if len(diffs) == 0 {
+		logger.Info("No differences found between the clusters")
+	}
+	rows := make([]ui.Row, 0, len(diffs))
+	for _, difference := range diffs {
+		rows = append(rows, ui.ClusterDifference(difference.Type, difference.Resource, difference.Field, difference.Source,
+			difference.Destination))
+	}
+	if err = d.renderer.Render(rows); err != nil {
+		logger.Fatalf("Error while writing the differences - %v\n", err)
+	}
+}
+
+// printSummary prints the title of the markdown report with the count of differences per type
+func printSummary(out io.Writer, diffs []model.Difference) {
+	fmt.Fprintln(out, "## Cluster diff")
+	fmt.Fprintln(out)
+	if len(diffs) == 0 {
+		fmt.Fprintln(out, "No differences found between the clusters.")
+		return
+	}
+
+	var types []string
+	counts := make(map[string]int)
+	for _, d := range diffs {
+		if counts[d.Type] == 0 {
+			types = append(types, d.Type)
+		}
+		counts[d.Type]++
+	}
+	for _, diffType := range types {
+		fmt.Fprintf(out, "- %v: %d\n", diffType, counts[diffType])
+	}
+	fmt.Fprintln(out)
+}
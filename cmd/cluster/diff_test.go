@@ -0,0 +1,171 @@
+package cluster
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/Shopify/sarama"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/gojek/kat/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	logger.SetDummyLogger()
+}
+
+type mockTopicReader struct {
+	client.MockLister
+	client.MockConfigurer
+}
+
+type mockClusterAdmin struct {
+	mock.Mock
+}
+
+func (m *mockClusterAdmin) ListACLs() ([]client.ACL, error) {
+	args := m.Called()
+	return args.Get(0).([]client.ACL), args.Error(1)
+}
+
+func (m *mockClusterAdmin) ListConsumerGroups() (map[string]string, error) {
+	args := m.Called()
+	return args.Get(0).(map[string]string), args.Error(1)
+}
+
+func (m *mockClusterAdmin) ListCommittedOffsets() (map[string]map[string]map[int32]int64, error) {
+	args := m.Called()
+	return args.Get(0).(map[string]map[string]map[int32]int64), args.Error(1)
+}
+
+func (m *mockClusterAdmin) ListDynamicBrokerConfigs() (map[string]map[string]string, error) {
+	args := m.Called()
+	return args.Get(0).(map[string]map[string]string), args.Error(1)
+}
+
+func (m *mockClusterAdmin) DescribeBrokers() ([]client.Broker, error) {
+	args := m.Called()
+	return args.Get(0).([]client.Broker), args.Error(1)
+}
+
+func TestCluster_ReadState(t *testing.T) {
+	topicCli := &mockTopicReader{}
+	adminCli := &mockClusterAdmin{}
+	topics := map[string]client.TopicDetail{
+		"topic1": {NumPartitions: 3, ReplicationFactor: 2},
+		"topic2": {NumPartitions: 1, ReplicationFactor: 2},
+	}
+	acls := []client.ACL{{ResourceType: "Topic", ResourceName: "topic1", Principal: "User:alice"}}
+	topicCli.MockLister.On("List").Return(topics, nil)
+	topicCli.MockConfigurer.On("GetConfigs", []string{"topic1", "topic2"}).Return(map[string][]client.ConfigEntry{
+		"topic1": {
+			{Name: "retention.ms", Value: "1000", Source: client.ConfigSourceTopic},
+			{Name: "segment.bytes", Value: "100", Source: client.ConfigSourceDefault},
+		},
+		"topic2": {{Name: "retention.ms", Value: "2000", Source: client.ConfigSourceDefault}},
+	}, nil)
+	adminCli.On("ListACLs").Return(acls, nil)
+	adminCli.On("ListConsumerGroups").Return(map[string]string{"group2": "consumer", "group1": "consumer"}, nil)
+	c := cluster{topicCli: topicCli, adminCli: adminCli}
+
+	state, err := c.readState(true, true)
+
+	require.NoError(t, err)
+	assert.Equal(t, &model.ClusterState{
+		Topics:         topics,
+		Configs:        map[string]map[string]string{"topic1": {"retention.ms": "1000"}},
+		ACLs:           acls,
+		ConsumerGroups: []string{"group1", "group2"},
+	}, state)
+	topicCli.MockLister.AssertExpectations(t)
+	topicCli.MockConfigurer.AssertExpectations(t)
+	adminCli.AssertExpectations(t)
+}
+
+func TestCluster_ReadStateWithoutACLsAndConsumerGroups(t *testing.T) {
+	topicCli := &mockTopicReader{}
+	topicCli.MockLister.On("List").Return(map[string]client.TopicDetail{}, nil)
+	c := cluster{topicCli: topicCli}
+
+	state, err := c.readState(false, false)
+
+	require.NoError(t, err)
+	assert.Empty(t, state.Topics)
+	assert.Nil(t, state.ACLs)
+	assert.Nil(t, state.ConsumerGroups)
+	topicCli.MockLister.AssertExpectations(t)
+	topicCli.MockConfigurer.AssertNotCalled(t, "GetConfigs", mock.Anything)
+}
+
+func TestCluster_ReadStateWithoutAuthorizer(t *testing.T) {
+	topicCli := &mockTopicReader{}
+	adminCli := &mockClusterAdmin{}
+	topicCli.MockLister.On("List").Return(map[string]client.TopicDetail{}, nil)
+	adminCli.On("ListACLs").Return([]client.ACL{}, sarama.ErrSecurityDisabled)
+	c := cluster{topicCli: topicCli, adminCli: adminCli}
+
+	state, err := c.readState(true, false)
+
+	require.NoError(t, err)
+	assert.Equal(t, []client.ACL{}, state.ACLs)
+	adminCli.AssertExpectations(t)
+}
+
+func diffClusters() (cluster, cluster) {
+	sourceCli := &mockTopicReader{}
+	destinationCli := &mockTopicReader{}
+	sourceCli.MockLister.On("List").Return(map[string]client.TopicDetail{"topic1": {NumPartitions: 3}}, nil)
+	sourceCli.MockConfigurer.On("GetConfigs", []string{"topic1"}).Return(map[string][]client.ConfigEntry{}, nil)
+	destinationCli.MockLister.On("List").Return(map[string]client.TopicDetail{"topic2": {NumPartitions: 3}}, nil)
+	destinationCli.MockConfigurer.On("GetConfigs", []string{"topic2"}).Return(map[string][]client.ConfigEntry{}, nil)
+	return cluster{topicCli: sourceCli}, cluster{topicCli: destinationCli}
+}
+
+func TestDiff_RendersDifferences(t *testing.T) {
+	source, destination := diffClusters()
+	var out bytes.Buffer
+	renderer, err := ui.NewRenderer(ui.OutputJSON, &out)
+	require.NoError(t, err)
+	d := diff{source: source, destination: destination, renderer: renderer, out: &out}
+
+	d.diff()
+
+	assert.JSONEq(t, `[{"type": "Topic", "resource": "topic1", "source": "present", "destination": "missing"},
+		{"type": "Topic", "resource": "topic2", "source": "missing", "destination": "present"}]`, out.String())
+}
+
+func TestDiff_RendersMarkdownReport(t *testing.T) {
+	source, destination := diffClusters()
+	var out bytes.Buffer
+	renderer, err := ui.NewRenderer(ui.OutputMarkdown, &out)
+	require.NoError(t, err)
+	d := diff{source: source, destination: destination, renderer: renderer, out: &out}
+
+	d.diff()
+
+	assert.True(t, strings.HasPrefix(out.String(), "## Cluster diff\n\n- Topic: 2\n\n| Type  | Resource |"))
+	assert.Contains(t, out.String(), "| Topic | topic1   |       | present | missing     |")
+}
+
+func TestDiff_SourceClusterListError(t *testing.T) {
+	sourceCli := &mockTopicReader{}
+	sourceCli.MockLister.On("List").Return(map[string]client.TopicDetail{}, errors.New("error"))
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	renderer, _ := ui.NewRenderer(ui.OutputTable, &bytes.Buffer{})
+	d := diff{source: cluster{topicCli: sourceCli}, renderer: renderer}
+
+	assert.PanicsWithValue(t, "os.Exit called", d.diff, "os.Exit was not called")
+	sourceCli.MockLister.AssertExpectations(t)
+}
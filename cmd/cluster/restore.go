@@ -0,0 +1,118 @@
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/gojek/kat/ui"
+	"github.com/spf13/cobra"
+)
+
+type planApplier interface {
+	Apply(plan *model.ClusterRestorePlan) error
+}
+
+type userInput interface {
+	AskForConfirmation(string) bool
+}
+
+type restore struct {
+	cluster          cluster
+	applier          planApplier
+	userInput        userInput
+	safety           base.SafetyChecker
+	renderer         *ui.Renderer
+	file             string
+	ignoreAssignment bool
+	dryRun           bool
+}
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore <snapshot file>",
+	Short: "Recreate the topics, acls and dynamic broker configs of a snapshot on a cluster",
+	Args:  cobra.ExactArgs(1),
+	Run: func(command *cobra.Command, args []string) {
+		cobraUtil := base.NewCobraUtil(command)
+		topicCli, kafkaClient := newClients(cobraUtil, "broker-list")
+		r := restore{
+			cluster:          cluster{topicCli: topicCli, adminCli: kafkaClient},
+			applier:          model.NewClusterRestorer(topicCli, kafkaClient, kafkaClient),
+			userInput:        &ui.UserInput{},
+			safety:           cobraUtil.GetSafetyGuard(),
+			renderer:         cobraUtil.GetRenderer(),
+			file:             args[0],
+			ignoreAssignment: cobraUtil.GetBoolArg("ignore-assignment"),
+			dryRun:           cobraUtil.GetBoolArg("dry-run"),
+		}
+		r.restore()
+	},
+}
+
+func init() {
+	restoreCmd.PersistentFlags().StringP("broker-list", "b", "", "Comma separated list of broker ips")
+	restoreCmd.PersistentFlags().Bool("ignore-assignment", false,
+		"Let the cluster place the replicas, instead of using the replica assignment of the snapshot")
+	restoreCmd.PersistentFlags().Bool("dry-run", false, "Only show the changes, without applying them")
+	if err := restoreCmd.MarkPersistentFlagRequired("broker-list"); err != nil {
+		logger.Fatal(err)
+	}
+}
+
+// restore previews the changes that recreate the snapshot on the cluster, and applies them on confirmation
+func (r *restore) restore() {
+	plan := r.plan()
+	var rows []ui.Row
+	for _, action := range plan.Actions() {
+		rows = append(rows, ui.RestoreAction(action.Action, action.ResourceType, action.Resource, action.Detail))
+	}
+	if err := r.renderer.Render(rows); err != nil {
+		logger.Fatalf("Error while printing the restore actions - %v\n", err)
+	}
+	if !plan.HasChanges() {
+		logger.Info("Nothing to restore, the cluster already has the topics and acls of the snapshot")
+		return
+	}
+	if r.dryRun {
+		return
+	}
+
+	if err := r.safety.Check(model.OperationCreate, plan.TopicNames()); err != nil {
+		logger.Fatal(err)
+	}
+	question := fmt.Sprintf("Do you really want to create %d topics and %d acls, and set the configs of %d brokers?", len(plan.Topics),
+		len(plan.ACLs), len(plan.BrokerConfigs))
+	if !r.userInput.AskForConfirmation(question) {
+		return
+	}
+	if err := r.applier.Apply(plan); err != nil {
+		logger.Fatalf("Error while restoring the snapshot - %v\n", err)
+	}
+	logger.Infof("Restored the snapshot %v\n", r.file)
+}
+
+// plan compares the snapshot with the topics, acls and brokers of the cluster
+func (r *restore) plan() *model.ClusterRestorePlan {
+	snapshot, err := model.LoadClusterSnapshot(r.file)
+	if err != nil {
+		logger.Fatalf("Error while reading the snapshot - %v\n", err)
+	}
+	topics, err := r.cluster.topicCli.List()
+	if err != nil {
+		logger.Fatalf("Error while listing the topics - %v\n", err)
+	}
+	acls, err := r.cluster.readACLs()
+	if err != nil {
+		logger.Fatalf("Error while listing the acls - %v\n", err)
+	}
+	brokers, err := r.cluster.adminCli.DescribeBrokers()
+	if err != nil {
+		logger.Fatalf("Error while describing the brokers - %v\n", err)
+	}
+	plan, err := model.PlanClusterRestore(snapshot, topics, acls, brokers, r.ignoreAssignment)
+	if err != nil {
+		logger.Fatalf("Error while planning the restore - %v\n", err)
+	}
+	return plan
+}
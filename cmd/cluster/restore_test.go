@@ -0,0 +1,191 @@
+package cluster
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/Shopify/sarama"
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/gojek/kat/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockPlanApplier struct {
+	mock.Mock
+}
+
+func (m *mockPlanApplier) Apply(plan *model.ClusterRestorePlan) error {
+	args := m.Called(plan.TopicNames())
+	return args.Error(0)
+}
+
+type mockUserInput struct {
+	mock.Mock
+}
+
+func (m *mockUserInput) AskForConfirmation(question string) bool {
+	args := m.Called(question)
+	return args.Bool(0)
+}
+
+func tableRenderer() *ui.Renderer {
+	renderer, _ := ui.NewRenderer(ui.OutputTable, ioutil.Discard)
+	return renderer
+}
+
+func writeSnapshot(t *testing.T) (string, string) {
+	return writeSnapshotData(t, `{"formatVersion": 1, "topics": {"orders": {"numPartitions": 1, "replicationFactor": 1},
+		"payments": {"numPartitions": 1, "replicationFactor": 1}}}`)
+}
+
+func writeSnapshotData(t *testing.T, data string) (string, string) {
+	dir, err := ioutil.TempDir("", "snapshot")
+	require.NoError(t, err)
+	file := filepath.Join(dir, "snapshot.json")
+	require.NoError(t, ioutil.WriteFile(file, []byte(data), 0644))
+	return dir, file
+}
+
+func setupRestoreCluster(existingTopics map[string]client.TopicDetail) cluster {
+	topicCli := &mockTopicReader{}
+	adminCli := &mockClusterAdmin{}
+	topicCli.MockLister.On("List").Return(existingTopics, nil)
+	adminCli.On("ListACLs").Return([]client.ACL{}, nil)
+	adminCli.On("DescribeBrokers").Return([]client.Broker{{ID: 1}}, nil)
+	return cluster{topicCli: topicCli, adminCli: adminCli}
+}
+
+func TestRestore_AppliesThePlanOnConfirmation(t *testing.T) {
+	dir, file := writeSnapshot(t)
+	defer os.RemoveAll(dir)
+	applier := &mockPlanApplier{}
+	applier.On("Apply", []string{"orders"}).Return(nil)
+	input := &mockUserInput{}
+	input.On("AskForConfirmation", "Do you really want to create 1 topics and 0 acls, and set the configs of 0 brokers?").Return(true)
+	safety := &base.MockSafetyGuard{}
+	safety.On("Check", model.OperationCreate, []string{"orders"}).Return(nil)
+	r := restore{cluster: setupRestoreCluster(map[string]client.TopicDetail{"payments": {}}), applier: applier, userInput: input,
+		safety: safety, file: file, renderer: tableRenderer()}
+
+	r.restore()
+
+	applier.AssertExpectations(t)
+	input.AssertExpectations(t)
+	safety.AssertExpectations(t)
+}
+
+func TestRestore_DryRunOnlyShowsThePlan(t *testing.T) {
+	dir, file := writeSnapshot(t)
+	defer os.RemoveAll(dir)
+	applier := &mockPlanApplier{}
+	input := &mockUserInput{}
+	r := restore{cluster: setupRestoreCluster(map[string]client.TopicDetail{}), applier: applier, userInput: input,
+		safety: &base.MockSafetyGuard{}, file: file, dryRun: true, renderer: tableRenderer()}
+
+	r.restore()
+
+	input.AssertNotCalled(t, "AskForConfirmation", mock.Anything)
+	applier.AssertNotCalled(t, "Apply", mock.Anything)
+}
+
+func TestRestore_DoesNotApplyWithoutConfirmation(t *testing.T) {
+	dir, file := writeSnapshot(t)
+	defer os.RemoveAll(dir)
+	applier := &mockPlanApplier{}
+	input := &mockUserInput{}
+	input.On("AskForConfirmation", mock.Anything).Return(false)
+	safety := &base.MockSafetyGuard{}
+	safety.On("Check", model.OperationCreate, []string{"orders", "payments"}).Return(nil)
+	r := restore{cluster: setupRestoreCluster(map[string]client.TopicDetail{}), applier: applier, userInput: input, safety: safety,
+		file: file, renderer: tableRenderer()}
+
+	r.restore()
+
+	applier.AssertNotCalled(t, "Apply", mock.Anything)
+}
+
+func TestRestore_ExitsWhenRefusedBySafetyPolicy(t *testing.T) {
+	dir, file := writeSnapshot(t)
+	defer os.RemoveAll(dir)
+	applier := &mockPlanApplier{}
+	input := &mockUserInput{}
+	safety := &base.MockSafetyGuard{}
+	safety.On("Check", model.OperationCreate, []string{"orders", "payments"}).Return(errors.New("refused"))
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	r := restore{cluster: setupRestoreCluster(map[string]client.TopicDetail{}), applier: applier, userInput: input, safety: safety,
+		file: file, renderer: tableRenderer()}
+
+	assert.PanicsWithValue(t, "os.Exit called", r.restore, "os.Exit was not called")
+	input.AssertNotCalled(t, "AskForConfirmation", mock.Anything)
+	applier.AssertNotCalled(t, "Apply", mock.Anything)
+}
+
+func TestRestore_ExitsForMissingSnapshot(t *testing.T) {
+	applier := &mockPlanApplier{}
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	r := restore{cluster: setupRestoreCluster(map[string]client.TopicDetail{}), applier: applier, file: "missing.json", renderer: tableRenderer()}
+
+	assert.PanicsWithValue(t, "os.Exit called", r.restore, "os.Exit was not called")
+	applier.AssertNotCalled(t, "Apply", mock.Anything)
+}
+
+func TestRestore_ComparesWithNoACLsWhenTheClusterHasNoAuthorizer(t *testing.T) {
+	dir, file := writeSnapshotData(t, `{"formatVersion": 1, "acls": [{"resourceType": "Topic", "resourceName": "orders",
+		"patternType": "Literal", "principal": "User:alice", "host": "*", "operation": "Read", "permissionType": "Allow"}]}`)
+	defer os.RemoveAll(dir)
+	topicCli := &mockTopicReader{}
+	adminCli := &mockClusterAdmin{}
+	topicCli.MockLister.On("List").Return(map[string]client.TopicDetail{}, nil)
+	adminCli.On("ListACLs").Return([]client.ACL{}, sarama.ErrSecurityDisabled)
+	adminCli.On("DescribeBrokers").Return([]client.Broker{{ID: 1}}, nil)
+	r := restore{cluster: cluster{topicCli: topicCli, adminCli: adminCli}, file: file}
+
+	plan := r.plan()
+
+	assert.Len(t, plan.ACLs, 1)
+	adminCli.AssertExpectations(t)
+}
+
+func TestRestore_ExitsForAssignmentOnUnknownBrokers(t *testing.T) {
+	dir, file := writeSnapshotData(t, `{"formatVersion": 1, "topics": {"orders": {"numPartitions": 1, "replicationFactor": 1,
+		"replicaAssignment": {"0": [2]}}}}`)
+	defer os.RemoveAll(dir)
+	applier := &mockPlanApplier{}
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	r := restore{cluster: setupRestoreCluster(map[string]client.TopicDetail{}), applier: applier, file: file, renderer: tableRenderer()}
+
+	assert.PanicsWithValue(t, "os.Exit called", r.restore, "os.Exit was not called")
+	applier.AssertNotCalled(t, "Apply", mock.Anything)
+}
+
+func TestRestore_IgnoresTheAssignmentOnUnknownBrokers(t *testing.T) {
+	dir, file := writeSnapshotData(t, `{"formatVersion": 1, "topics": {"orders": {"numPartitions": 1, "replicationFactor": 1,
+		"replicaAssignment": {"0": [2]}}}}`)
+	defer os.RemoveAll(dir)
+	r := restore{cluster: setupRestoreCluster(map[string]client.TopicDetail{}), file: file, ignoreAssignment: true}
+
+	plan := r.plan()
+
+	assert.Equal(t, []string{"orders"}, plan.TopicNames())
+	assert.Nil(t, plan.Topics["orders"].ReplicaAssignment)
+}
@@ -0,0 +1,47 @@
+package cluster
+
+import (
+	"io"
+	"os"
+
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+type snapshot struct {
+	cluster cluster
+	out     io.Writer
+}
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Write the topics, configs, acls, consumer group offsets and dynamic broker configs of a cluster as json to stdout",
+	Run: func(command *cobra.Command, args []string) {
+		cobraUtil := base.NewCobraUtil(command)
+		// the logs are kept out of the snapshot, which is redirected to a file
+		logger.SetOutput(os.Stderr)
+		s := snapshot{cluster: newCluster(cobraUtil, "broker-list"), out: os.Stdout}
+		s.snapshot()
+	},
+}
+
+func init() {
+	snapshotCmd.PersistentFlags().StringP("broker-list", "b", "", "Comma separated list of broker ips")
+	if err := snapshotCmd.MarkPersistentFlagRequired("broker-list"); err != nil {
+		logger.Fatal(err)
+	}
+}
+
+func (s *snapshot) snapshot() {
+	clusterSnapshot, err := s.cluster.readSnapshot()
+	if err != nil {
+		logger.Fatalf("Error while reading the cluster - %v\n", err)
+	}
+	if err = model.WriteClusterSnapshot(s.out, clusterSnapshot); err != nil {
+		logger.Fatalf("Error while writing the snapshot - %v\n", err)
+	}
+	logger.Infof("Wrote the snapshot of %d topics, %d acls and %d consumer groups\n", len(clusterSnapshot.Topics),
+		len(clusterSnapshot.ACLs), len(clusterSnapshot.ConsumerGroupOffsets))
+}
@@ -0,0 +1,81 @@
+package cluster
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/Shopify/sarama"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshot_WritesTheSnapshotOfTheCluster(t *testing.T) {
+	topicCli := &mockTopicReader{}
+	adminCli := &mockClusterAdmin{}
+	topicCli.MockLister.On("List").Return(map[string]client.TopicDetail{"orders": {NumPartitions: 1, ReplicationFactor: 1}}, nil)
+	topicCli.MockConfigurer.On("GetConfigs", []string{"orders"}).Return(map[string][]client.ConfigEntry{
+		"orders": {{Name: "retention.ms", Value: "1000", Source: client.ConfigSourceTopic}},
+	}, nil)
+	adminCli.On("ListACLs").Return([]client.ACL{}, nil)
+	adminCli.On("ListConsumerGroups").Return(map[string]string{"group-1": "consumer"}, nil)
+	adminCli.On("ListCommittedOffsets").Return(map[string]map[string]map[int32]int64{"orders": {"group-1": {0: 5}}}, nil)
+	adminCli.On("ListDynamicBrokerConfigs").Return(map[string]map[string]string{"1": {"log.cleaner.threads": "2"}}, nil)
+	var out bytes.Buffer
+	s := snapshot{cluster: cluster{topicCli: topicCli, adminCli: adminCli}, out: &out}
+
+	s.snapshot()
+
+	written := &model.ClusterSnapshot{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), written))
+	assert.Equal(t, model.ClusterSnapshotFormatVersion, written.FormatVersion)
+	assert.Equal(t, map[string]model.SnapshotTopic{"orders": {NumPartitions: 1, ReplicationFactor: 1,
+		Configs: map[string]string{"retention.ms": "1000"}}}, written.Topics)
+	assert.Equal(t, map[string]map[string]map[int32]int64{"group-1": {"orders": {0: 5}}}, written.ConsumerGroupOffsets)
+	assert.Equal(t, map[string]map[string]string{"1": {"log.cleaner.threads": "2"}}, written.BrokerConfigs)
+	adminCli.AssertExpectations(t)
+}
+
+func TestSnapshot_WritesNoACLsWhenTheClusterHasNoAuthorizer(t *testing.T) {
+	topicCli := &mockTopicReader{}
+	adminCli := &mockClusterAdmin{}
+	topicCli.MockLister.On("List").Return(map[string]client.TopicDetail{}, nil)
+	adminCli.On("ListACLs").Return([]client.ACL{}, sarama.ErrSecurityDisabled)
+	adminCli.On("ListConsumerGroups").Return(map[string]string{}, nil)
+	adminCli.On("ListCommittedOffsets").Return(map[string]map[string]map[int32]int64{}, nil)
+	adminCli.On("ListDynamicBrokerConfigs").Return(map[string]map[string]string{}, nil)
+	var out bytes.Buffer
+	s := snapshot{cluster: cluster{topicCli: topicCli, adminCli: adminCli}, out: &out}
+
+	s.snapshot()
+
+	written := &model.ClusterSnapshot{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), written))
+	assert.Equal(t, []client.ACL{}, written.ACLs)
+	adminCli.AssertExpectations(t)
+}
+
+func TestSnapshot_ExitsWhenBrokerConfigsCannotBeRead(t *testing.T) {
+	topicCli := &mockTopicReader{}
+	adminCli := &mockClusterAdmin{}
+	topicCli.MockLister.On("List").Return(map[string]client.TopicDetail{}, nil)
+	adminCli.On("ListACLs").Return([]client.ACL{}, nil)
+	adminCli.On("ListConsumerGroups").Return(map[string]string{}, nil)
+	adminCli.On("ListCommittedOffsets").Return(map[string]map[string]map[int32]int64{}, nil)
+	adminCli.On("ListDynamicBrokerConfigs").Return(map[string]map[string]string{}, errors.New("not authorized"))
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	var out bytes.Buffer
+	s := snapshot{cluster: cluster{topicCli: topicCli, adminCli: adminCli}, out: &out}
+
+	assert.PanicsWithValue(t, "os.Exit called", s.snapshot, "os.Exit was not called")
+	assert.Empty(t, out.String())
+}
@@ -0,0 +1,130 @@
+package cluster
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/pkg/model"
+)
+
+type topicReader interface {
+	client.Lister
+	client.Configurer
+}
+
+type clusterAdmin interface {
+	client.ACLLister
+	client.BrokerConfigLister
+	ListConsumerGroups() (map[string]string, error)
+	ListCommittedOffsets() (map[string]map[string]map[int32]int64, error)
+	DescribeBrokers() ([]client.Broker, error)
+}
+
+type cluster struct {
+	topicCli topicReader
+	adminCli clusterAdmin
+}
+
+// newCluster creates the clients for the cluster with the broker ips passed in the addrFlag
+func newCluster(cobraUtil *base.CobraUtil, addrFlag string) cluster {
+	topicCli, saramaClient := newClients(cobraUtil, addrFlag)
+	return cluster{topicCli: topicCli, adminCli: saramaClient}
+}
+
+// newClients creates a single client for the cluster, shared by the topic and the admin calls
+func newClients(cobraUtil *base.CobraUtil, addrFlag string) (*model.Topic, *client.SaramaClient) {
+	saramaClient := client.NewSaramaClient(strings.Split(cobraUtil.GetStringArg(addrFlag), ","))
+	topicCli, err := model.NewTopic(saramaClient)
+	if err != nil {
+		logger.Fatalf("Err on creating topic client - %v\n", err)
+	}
+	return topicCli, saramaClient
+}
+
+// readState reads the topics with their overridden configs, and optionally the acls and consumer groups of the cluster
+func (c cluster) readState(includeACLs, includeConsumerGroups bool) (*model.ClusterState, error) {
+	topics, err := c.topicCli.List()
+	if err != nil {
+		return nil, err
+	}
+	state := &model.ClusterState{Topics: topics}
+	if state.Configs, err = c.readConfigOverrides(topics); err != nil {
+		return nil, err
+	}
+
+	if includeACLs {
+		if state.ACLs, err = c.readACLs(); err != nil {
+			return nil, err
+		}
+	}
+	if includeConsumerGroups {
+		groups, groupErr := c.adminCli.ListConsumerGroups()
+		if groupErr != nil {
+			return nil, groupErr
+		}
+		for group := range groups {
+			state.ConsumerGroups = append(state.ConsumerGroups, group)
+		}
+		sort.Strings(state.ConsumerGroups)
+	}
+	return state, nil
+}
+
+// readACLs lists the acls of the cluster, a cluster without an authorizer has no acls
+func (c cluster) readACLs() ([]client.ACL, error) {
+	acls, err := c.adminCli.ListACLs()
+	if client.IsSecurityDisabled(err) {
+		logger.Infof("The cluster has no authorizer, its acls are taken as empty\n")
+		return []client.ACL{}, nil
+	}
+	return acls, err
+}
+
+// readSnapshot reads the state of the cluster along with the consumer group offsets and the dynamic broker configs
+func (c cluster) readSnapshot() (*model.ClusterSnapshot, error) {
+	state, err := c.readState(true, true)
+	if err != nil {
+		return nil, err
+	}
+	offsets, err := c.adminCli.ListCommittedOffsets()
+	if err != nil {
+		return nil, err
+	}
+	brokerConfigs, err := c.adminCli.ListDynamicBrokerConfigs()
+	if err != nil {
+		return nil, err
+	}
+	return model.NewClusterSnapshot(state, offsets, brokerConfigs), nil
+}
+
+// readConfigOverrides returns the configs overridden at the topic level, for the topics having any
+func (c cluster) readConfigOverrides(topics map[string]client.TopicDetail) (map[string]map[string]string, error) {
+	overridesByTopic := make(map[string]map[string]string)
+	if len(topics) == 0 {
+		return overridesByTopic, nil
+	}
+	names := make([]string, 0, len(topics))
+	for topic := range topics {
+		names = append(names, topic)
+	}
+	sort.Strings(names)
+	topicConfigs, err := c.topicCli.GetConfigs(names)
+	if err != nil {
+		return nil, err
+	}
+	for topic, configs := range topicConfigs {
+		overrides := make(map[string]string)
+		for _, config := range configs {
+			if config.IsOverridden() {
+				overrides[config.Name] = config.Value
+			}
+		}
+		if len(overrides) > 0 {
+			overridesByTopic[topic] = overrides
+		}
+	}
+	return overridesByTopic, nil
+}
@@ -1,9 +1,11 @@
 package config
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/pkg/model"
 
 	"github.com/gojek/kat/cmd/base"
 
@@ -13,41 +15,115 @@ import (
 
 type alterConfig struct {
 	client.Configurer
-	config string
-	topics []string
+	config         string
+	appendConfig   string
+	subtractConfig string
+	topics         []string
+	safety         base.SafetyChecker
 }
 
 var alterConfigCmd = &cobra.Command{
 	Use:   "alter",
-	Short: "alter the config for the given topics",
+	Short: "alter the config for the given topics. Configs that are not passed are left untouched",
 	Run: func(command *cobra.Command, args []string) {
 		cobraUtil := base.NewCobraUtil(command)
-		a := alterConfig{Configurer: base.Init(cobraUtil).GetTopic(), config: cobraUtil.GetStringArg("config"), topics: cobraUtil.GetTopicNames()}
+		a := alterConfig{Configurer: base.Init(cobraUtil).GetTopic(), config: cobraUtil.GetStringArg("config"),
+			appendConfig: cobraUtil.GetStringArg("append-config"), subtractConfig: cobraUtil.GetStringArg("subtract-config"),
+			topics: cobraUtil.GetTopicNames(), safety: cobraUtil.GetSafetyGuard()}
 		a.alterConfig()
 	},
 }
 
 func init() {
-	alterConfigCmd.PersistentFlags().StringP("config", "c", "", "Comma separated list of configs, eg: key1=val1,key2=val2")
-	if err := alterConfigCmd.MarkPersistentFlagRequired("config"); err != nil {
-		logger.Fatal(err)
-	}
+	alterConfigCmd.PersistentFlags().StringP("config", "c", "", "Comma separated list of configs to set, eg: key1=val1,key2=val2")
+	alterConfigCmd.PersistentFlags().String("append-config", "",
+		"Comma separated list of values to append to list configs, eg: follower.replication.throttled.replicas=[0:1,1:2]")
+	alterConfigCmd.PersistentFlags().String("subtract-config", "",
+		"Comma separated list of values to remove from list configs, eg: follower.replication.throttled.replicas=0:1")
 }
 
 func (a *alterConfig) alterConfig() {
-	configMap := configMap(a.config)
-	err := a.UpdateConfig(a.topics, configMap, false)
+	entries, err := a.configEntries()
+	if err != nil {
+		logger.Fatalf("Error while parsing config - %v\n", err)
+	}
+	if err = a.safety.Check(model.OperationAlterConfig, a.topics); err != nil {
+		logger.Fatal(err)
+	}
+	err = a.IncrementalUpdateConfig(a.topics, entries, false)
 	if err != nil {
 		logger.Fatalf("Error while altering config - %v\n", err)
 	}
 }
 
-func configMap(configStr string) map[string]*string {
+func (a *alterConfig) configEntries() (map[string]client.IncrementalConfigEntry, error) {
+	entries := make(map[string]client.IncrementalConfigEntry)
+	operations := []struct {
+		config    string
+		operation client.ConfigOperation
+	}{
+		{a.config, client.ConfigOperationSet},
+		{a.appendConfig, client.ConfigOperationAppend},
+		{a.subtractConfig, client.ConfigOperationSubtract},
+	}
+	for _, op := range operations {
+		if op.config == "" {
+			continue
+		}
+		cm, err := configMap(op.config)
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range cm {
+			if _, ok := entries[key]; ok {
+				return nil, fmt.Errorf("config %v is passed for more than one operation", key)
+			}
+			entries[key] = client.IncrementalConfigEntry{Operation: op.operation, Value: value}
+		}
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("any one of config, append-config or subtract-config should be passed")
+	}
+	return entries, nil
+}
+
+// configMap parses configs of the form key1=val1,key2=val2. The key and value are separated by the first '=',
+// so values can contain '='. As with kafka-configs.sh, list values containing commas are wrapped in square brackets,
+// like follower.replication.throttled.replicas=[0:1,1:2], and the brackets are removed from the value.
+func configMap(configStr string) (map[string]*string, error) {
 	configMap := make(map[string]*string)
-	configs := strings.Split(configStr, ",")
-	for _, config := range configs {
-		configArr := strings.Split(config, "=")
-		configMap[configArr[0]] = &configArr[1]
+	for _, config := range splitConfigs(configStr) {
+		configArr := strings.SplitN(config, "=", 2)
+		if len(configArr) == 1 {
+			return nil, fmt.Errorf("invalid config %v, expected the format key=value, list values are wrapped in [] like key=[v1,v2]", config)
+		}
+		key := strings.TrimSpace(configArr[0])
+		if key == "" {
+			return nil, fmt.Errorf("invalid config %v, key cannot be empty", config)
+		}
+		value := configArr[1]
+		if strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]") {
+			value = value[1 : len(value)-1]
+		}
+		configMap[key] = &value
+	}
+	return configMap, nil
+}
+
+// splitConfigs splits the configs on the commas that are not within square brackets
+func splitConfigs(configStr string) []string {
+	var configs []string
+	depth, start := 0, 0
+	for i, c := range configStr {
+		switch {
+		case c == '[':
+			depth++
+		case c == ']' && depth > 0:
+			depth--
+		case c == ',' && depth == 0:
+			configs = append(configs, configStr[start:i])
+			start = i + 1
+		}
 	}
-	return configMap
+	return append(configs, configStr[start:])
 }
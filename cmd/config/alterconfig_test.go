@@ -6,9 +6,11 @@ import (
 	"testing"
 
 	"bou.ke/monkey"
+	"github.com/gojek/kat/cmd/base"
 	"github.com/gojek/kat/logger"
 	"github.com/gojek/kat/pkg/client"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func init() {
@@ -20,9 +22,28 @@ func TestAlter_Success(t *testing.T) {
 	topics := []string{"topic1", "topic2"}
 	config := "key1=val1"
 	value := "val1"
-	configMap := map[string]*string{"key1": &value}
-	mockConfigurer.On("UpdateConfig", topics, configMap, false).Return(nil).Times(1)
-	a := alterConfig{Configurer: mockConfigurer, topics: topics, config: config}
+	entries := map[string]client.IncrementalConfigEntry{"key1": {Operation: client.ConfigOperationSet, Value: &value}}
+	mockConfigurer.On("IncrementalUpdateConfig", topics, entries, false).Return(nil).Times(1)
+	a := alterConfig{Configurer: mockConfigurer, topics: topics, config: config, safety: noSafetyPolicy()}
+	a.alterConfig()
+	mockConfigurer.AssertExpectations(t)
+}
+
+func TestAlter_SuccessWithAppendAndSubtract(t *testing.T) {
+	mockConfigurer := &client.MockConfigurer{}
+	topics := []string{"topic1"}
+	value := "val1"
+	appendValue := "0:1,1:2"
+	subtractValue := "2:3"
+	entries := map[string]client.IncrementalConfigEntry{
+		"key1": {Operation: client.ConfigOperationSet, Value: &value},
+		"follower.replication.throttled.replicas": {Operation: client.ConfigOperationAppend, Value: &appendValue},
+		"leader.replication.throttled.replicas":   {Operation: client.ConfigOperationSubtract, Value: &subtractValue},
+	}
+	mockConfigurer.On("IncrementalUpdateConfig", topics, entries, false).Return(nil).Times(1)
+	a := alterConfig{Configurer: mockConfigurer, topics: topics, config: "key1=val1",
+		appendConfig:   "follower.replication.throttled.replicas=[0:1,1:2]",
+		subtractConfig: "leader.replication.throttled.replicas=2:3", safety: noSafetyPolicy()}
 	a.alterConfig()
 	mockConfigurer.AssertExpectations(t)
 }
@@ -32,14 +53,59 @@ func TestAlter_Failure(t *testing.T) {
 	topics := []string{"topic1", "topic2"}
 	config := "key1=val1"
 	value := "val1"
-	configMap := map[string]*string{"key1": &value}
-	mockConfigurer.On("UpdateConfig", topics, configMap, false).Return(errors.New("error")).Times(1)
+	entries := map[string]client.IncrementalConfigEntry{"key1": {Operation: client.ConfigOperationSet, Value: &value}}
+	mockConfigurer.On("IncrementalUpdateConfig", topics, entries, false).Return(errors.New("error")).Times(1)
 	fakeExit := func(int) {
 		panic("os.Exit called")
 	}
 	patch := monkey.Patch(os.Exit, fakeExit)
 	defer patch.Unpatch()
-	a := alterConfig{Configurer: mockConfigurer, topics: topics, config: config}
+	a := alterConfig{Configurer: mockConfigurer, topics: topics, config: config, safety: noSafetyPolicy()}
 	assert.PanicsWithValue(t, "os.Exit called", a.alterConfig, "os.Exit was not called")
 	mockConfigurer.AssertExpectations(t)
 }
+
+func TestAlter_FailureWhenNoConfigIsPassed(t *testing.T) {
+	mockConfigurer := &client.MockConfigurer{}
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	a := alterConfig{Configurer: mockConfigurer, topics: []string{"topic1"}, safety: noSafetyPolicy()}
+	assert.PanicsWithValue(t, "os.Exit called", a.alterConfig, "os.Exit was not called")
+	mockConfigurer.AssertNotCalled(t, "IncrementalUpdateConfig")
+}
+
+func TestAlter_FailureWhenConfigIsPassedForMultipleOperations(t *testing.T) {
+	a := alterConfig{config: "key1=val1", appendConfig: "key1=val2", safety: noSafetyPolicy()}
+	_, err := a.configEntries()
+	assert.EqualError(t, err, "config key1 is passed for more than one operation")
+}
+
+func TestConfigMap_ValuesWithSeparators(t *testing.T) {
+	configs, err := configMap("follower.replication.throttled.replicas=[0:1,1:2],sasl.jaas.config=a=b,retention.ms=1000")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(configs))
+	assert.Equal(t, "0:1,1:2", *configs["follower.replication.throttled.replicas"])
+	assert.Equal(t, "a=b", *configs["sasl.jaas.config"])
+	assert.Equal(t, "1000", *configs["retention.ms"])
+}
+
+func TestConfigMap_InvalidConfig(t *testing.T) {
+	_, err := configMap("retention.ms")
+	assert.Error(t, err)
+
+	_, err = configMap("=1000")
+	assert.Error(t, err)
+
+	_, err = configMap("follower.replication.throttled.replicas=0:1,1:2")
+	assert.EqualError(t, err, "invalid config 1:2, expected the format key=value, list values are wrapped in [] like key=[v1,v2]")
+}
+
+func noSafetyPolicy() *base.MockSafetyGuard {
+	safety := &base.MockSafetyGuard{}
+	safety.On("Check", mock.Anything, mock.Anything).Return(nil)
+	return safety
+}
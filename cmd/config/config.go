@@ -17,4 +17,5 @@ func init() {
 	}
 	ConfigCmd.AddCommand(showConfigCmd)
 	ConfigCmd.AddCommand(alterConfigCmd)
+	ConfigCmd.AddCommand(deleteConfigCmd)
 }
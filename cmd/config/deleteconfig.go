@@ -0,0 +1,50 @@
+package config
+
+import (
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/pkg/model"
+
+	"github.com/gojek/kat/cmd/base"
+
+	"github.com/gojek/kat/logger"
+	"github.com/spf13/cobra"
+)
+
+type deleteConfig struct {
+	client.Configurer
+	keys   []string
+	topics []string
+	safety base.SafetyChecker
+}
+
+var deleteConfigCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "deletes the config overrides for the given topics, reverting them to the cluster defaults",
+	Run: func(command *cobra.Command, args []string) {
+		cobraUtil := base.NewCobraUtil(command)
+		d := deleteConfig{Configurer: base.Init(cobraUtil).GetTopic(), keys: cobraUtil.GetStringSliceArg("keys"),
+			topics: cobraUtil.GetTopicNames(), safety: cobraUtil.GetSafetyGuard()}
+		d.deleteConfig()
+	},
+}
+
+func init() {
+	deleteConfigCmd.PersistentFlags().StringSlice("keys", []string{}, "Comma separated list of config keys to delete, eg: key1,key2")
+	if err := deleteConfigCmd.MarkPersistentFlagRequired("keys"); err != nil {
+		logger.Fatal(err)
+	}
+}
+
+func (d *deleteConfig) deleteConfig() {
+	if err := d.safety.Check(model.OperationAlterConfig, d.topics); err != nil {
+		logger.Fatal(err)
+	}
+	entries := make(map[string]client.IncrementalConfigEntry)
+	for _, key := range d.keys {
+		entries[key] = client.IncrementalConfigEntry{Operation: client.ConfigOperationDelete}
+	}
+	err := d.IncrementalUpdateConfig(d.topics, entries, false)
+	if err != nil {
+		logger.Fatalf("Error while deleting config - %v\n", err)
+	}
+}
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	logger.SetDummyLogger()
+}
+
+func TestDelete_Success(t *testing.T) {
+	mockConfigurer := &client.MockConfigurer{}
+	topics := []string{"topic1", "topic2"}
+	entries := map[string]client.IncrementalConfigEntry{
+		"retention.ms":  {Operation: client.ConfigOperationDelete},
+		"segment.bytes": {Operation: client.ConfigOperationDelete},
+	}
+	mockConfigurer.On("IncrementalUpdateConfig", topics, entries, false).Return(nil).Times(1)
+	d := deleteConfig{Configurer: mockConfigurer, topics: topics, keys: []string{"retention.ms", "segment.bytes"}, safety: noSafetyPolicy()}
+	d.deleteConfig()
+	mockConfigurer.AssertExpectations(t)
+}
+
+func TestDelete_Failure(t *testing.T) {
+	mockConfigurer := &client.MockConfigurer{}
+	topics := []string{"topic1"}
+	entries := map[string]client.IncrementalConfigEntry{"retention.ms": {Operation: client.ConfigOperationDelete}}
+	mockConfigurer.On("IncrementalUpdateConfig", topics, entries, false).Return(errors.New("error")).Times(1)
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	d := deleteConfig{Configurer: mockConfigurer, topics: topics, keys: []string{"retention.ms"}, safety: noSafetyPolicy()}
+	assert.PanicsWithValue(t, "os.Exit called", d.deleteConfig, "os.Exit was not called")
+	mockConfigurer.AssertExpectations(t)
+}
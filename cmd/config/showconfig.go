@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/ui"
 
 	"github.com/gojek/kat/cmd/base"
 
@@ -13,7 +14,10 @@ import (
 
 type showConfig struct {
 	client.Configurer
-	topics []string
+	renderer      *ui.Renderer
+	topics        []string
+	overridesOnly bool
+	keys          []string
 }
 
 var showConfigCmd = &cobra.Command{
@@ -21,27 +25,85 @@ var showConfigCmd = &cobra.Command{
 	Short: "shows the config for the given topics",
 	Run: func(command *cobra.Command, args []string) {
 		cobraUtil := base.NewCobraUtil(command)
-		s := showConfig{Configurer: base.Init(cobraUtil).GetTopic(), topics: cobraUtil.GetTopicNames()}
+		s := showConfig{Configurer: base.Init(cobraUtil).GetTopic(), renderer: cobraUtil.GetRenderer(), topics: cobraUtil.GetTopicNames(),
+			overridesOnly: cobraUtil.GetBoolArg("overrides-only"), keys: cobraUtil.GetStringSliceArg("keys")}
 		s.showConfig()
 	},
 }
 
+func init() {
+	showConfigCmd.PersistentFlags().Bool("overrides-only", false, "Show only the configs overridden at the topic level")
+	showConfigCmd.PersistentFlags().StringSlice("keys", []string{},
+		"Comma separated list of config keys to compare across the topics, shown as a topic x config matrix")
+}
+
 func (s *showConfig) showConfig() {
+	if len(s.keys) > 0 {
+		s.showConfigMatrix()
+		return
+	}
+
+	var rows []ui.Row
 	for _, topicName := range s.topics {
 		configs, err := s.GetConfig(topicName)
 		if err != nil {
 			logger.Fatalf("Error while fetching config for topic %v - %v\n", topicName, err)
 			return
 		}
+		configs = filterConfigs(configs, s.overridesOnly)
 		if len(configs) == 0 {
 			logger.Infof("Configs not found for topic - %v\n", topicName)
 			continue
 		}
-		fmt.Println("---------------------------------------------")
-		fmt.Printf("Configuration for topic - %v\n", topicName)
-		fmt.Println("---------------------------------------------")
 		for _, config := range configs {
-			fmt.Printf("%+v\n", config)
+			rows = append(rows, ui.TopicConfigEntry(topicName, config.Name, config.Value, config.Source, config.Sensitive, synonyms(config)))
+		}
+	}
+	s.render(rows)
+}
+
+func (s *showConfig) showConfigMatrix() {
+	var rows []ui.Row
+	for _, topicName := range s.topics {
+		configs, err := s.GetConfig(topicName)
+		if err != nil {
+			logger.Fatalf("Error while fetching config for topic %v - %v\n", topicName, err)
+			return
 		}
+		values := make(map[string]string)
+		sensitive := make(map[string]bool)
+		for _, config := range filterConfigs(configs, s.overridesOnly) {
+			values[config.Name] = config.Value
+			sensitive[config.Name] = config.Sensitive
+		}
+		rows = append(rows, ui.ConfigMatrix(topicName, s.keys, values, sensitive))
+	}
+	s.render(rows)
+}
+
+func (s *showConfig) render(rows []ui.Row) {
+	if err := s.renderer.Render(rows); err != nil {
+		logger.Fatalf("Error while writing the configs - %v\n", err)
+	}
+}
+
+func filterConfigs(configs []client.ConfigEntry, overridesOnly bool) []client.ConfigEntry {
+	if !overridesOnly {
+		return configs
+	}
+	var overrides []client.ConfigEntry
+	for _, config := range configs {
+		if config.IsOverridden() {
+			overrides = append(overrides, config)
+		}
+	}
+	return overrides
+}
+
+func synonyms(config client.ConfigEntry) []string {
+	var result []string
+	for _, synonym := range config.Synonyms {
+		result = append(result, fmt.Sprintf("%v=%v(%v)", synonym.ConfigName, synonym.ConfigValue, synonym.Source))
 	}
+	return result
 }
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"bytes"
 	"errors"
 	"os"
 	"testing"
@@ -9,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/ui"
 
 	"github.com/gojek/kat/logger"
 )
@@ -22,7 +24,7 @@ func TestShow_Success(t *testing.T) {
 	topics := []string{"topic1", "topic2"}
 	mockConfigurer.On("GetConfig", "topic1").Return([]client.ConfigEntry{}, nil).Times(1)
 	mockConfigurer.On("GetConfig", "topic2").Return([]client.ConfigEntry{}, nil).Times(1)
-	s := showConfig{Configurer: mockConfigurer, topics: topics}
+	s := showConfig{Configurer: mockConfigurer, renderer: newRenderer(ui.OutputTable, &bytes.Buffer{}), topics: topics}
 	s.showConfig()
 	mockConfigurer.AssertExpectations(t)
 }
@@ -36,7 +38,70 @@ func TestShow_Failure(t *testing.T) {
 	}
 	patch := monkey.Patch(os.Exit, fakeExit)
 	defer patch.Unpatch()
-	s := showConfig{Configurer: mockConfigurer, topics: topics}
+	s := showConfig{Configurer: mockConfigurer, renderer: newRenderer(ui.OutputTable, &bytes.Buffer{}), topics: topics}
 	assert.PanicsWithValue(t, "os.Exit called", s.showConfig, "os.Exit was not called")
 	mockConfigurer.AssertExpectations(t)
 }
+
+func TestShow_OverridesOnly(t *testing.T) {
+	mockConfigurer := &client.MockConfigurer{}
+	topics := []string{"topic1"}
+	mockConfigurer.On("GetConfig", "topic1").Return([]client.ConfigEntry{
+		{Name: "retention.ms", Value: "1000", Source: client.ConfigSourceTopic},
+		{Name: "segment.bytes", Value: "1000", Source: client.ConfigSourceDefault, Default: true},
+	}, nil).Times(1)
+	var out bytes.Buffer
+	s := showConfig{Configurer: mockConfigurer, renderer: newRenderer(ui.OutputJSON, &out), topics: topics, overridesOnly: true}
+	s.showConfig()
+	assert.JSONEq(t, `[{"topic": "topic1", "name": "retention.ms", "value": "1000", "source": "Topic", "synonyms": null}]`, out.String())
+	mockConfigurer.AssertExpectations(t)
+}
+
+func TestShow_Synonyms(t *testing.T) {
+	mockConfigurer := &client.MockConfigurer{}
+	mockConfigurer.On("GetConfig", "topic1").Return([]client.ConfigEntry{
+		{Name: "retention.ms", Value: "1000", Source: client.ConfigSourceTopic, Synonyms: []*client.ConfigSynonym{
+			{ConfigName: "retention.ms", ConfigValue: "1000", Source: client.ConfigSourceTopic},
+			{ConfigName: "log.retention.ms", ConfigValue: "604800000", Source: "StaticBroker"},
+		}},
+	}, nil).Times(1)
+	var out bytes.Buffer
+	s := showConfig{Configurer: mockConfigurer, renderer: newRenderer(ui.OutputJSON, &out), topics: []string{"topic1"}}
+	s.showConfig()
+	assert.JSONEq(t, `[{"topic": "topic1", "name": "retention.ms", "value": "1000", "source": "Topic",
+		"synonyms": ["retention.ms=1000(Topic)", "log.retention.ms=604800000(StaticBroker)"]}]`, out.String())
+	mockConfigurer.AssertExpectations(t)
+}
+
+func TestShow_Matrix(t *testing.T) {
+	mockConfigurer := &client.MockConfigurer{}
+	topics := []string{"topic1", "topic2"}
+	mockConfigurer.On("GetConfig", "topic1").Return([]client.ConfigEntry{
+		{Name: "retention.ms", Value: "1000", Source: client.ConfigSourceTopic},
+	}, nil).Times(1)
+	mockConfigurer.On("GetConfig", "topic2").Return([]client.ConfigEntry{
+		{Name: "retention.ms", Value: "2000", Source: client.ConfigSourceTopic},
+	}, nil).Times(1)
+	var out bytes.Buffer
+	s := showConfig{Configurer: mockConfigurer, renderer: newRenderer(ui.OutputYAML, &out), topics: topics, keys: []string{"retention.ms"}}
+	s.showConfig()
+	assert.Equal(t, "- topic: topic1\n  configs:\n    retention.ms: \"1000\"\n- topic: topic2\n  configs:\n    retention.ms: \"2000\"\n", out.String())
+	mockConfigurer.AssertExpectations(t)
+}
+
+func newRenderer(format string, out *bytes.Buffer) *ui.Renderer {
+	renderer, _ := ui.NewRenderer(format, out)
+	return renderer
+}
+
+func TestFilterConfigs(t *testing.T) {
+	configs := []client.ConfigEntry{
+		{Name: "retention.ms", Value: "1000", Source: client.ConfigSourceTopic},
+		{Name: "segment.bytes", Value: "1000", Source: client.ConfigSourceDefault, Default: true},
+		{Name: "min.insync.replicas", Value: "2", Source: "DynamicBroker"},
+		{Name: "cleanup.policy", Value: "compact", Source: client.ConfigSourceUnknown},
+	}
+
+	assert.Equal(t, configs, filterConfigs(configs, false))
+	assert.Equal(t, []client.ConfigEntry{configs[0], configs[3]}, filterConfigs(configs, true))
+}
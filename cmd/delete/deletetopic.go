@@ -1,9 +1,13 @@
 package delete
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"syscall"
 
 	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/pkg/io"
 
 	"github.com/gojek/kat/pkg/model"
 
@@ -18,19 +22,38 @@ import (
 type deleteTopic struct {
 	client.Lister
 	client.Deleter
-	lastWrite      int64
-	dataDir        string
-	topicWhitelist string
-	topicBlacklist string
-	sshPort        string
-	sshKeyFilePath string
-	userInput      userInput
+	file
+	groups            client.SubscriptionLister
+	safety            base.SafetyChecker
+	backup            topicBackup
+	backupDir         string
+	backupData        bool
+	lastWrite         int64
+	dataDir           string
+	topicWhitelist    string
+	topicBlacklist    string
+	fromFile          string
+	replicationFactor int
+	size              int64
+	force             bool
+	sshPort           string
+	sshKeyFilePath    string
+	userInput         userInput
+	renderer          *ui.Renderer
 }
 
 type userInput interface {
 	AskForConfirmation(string) bool
 }
 
+type topicBackup interface {
+	Backup(ctx context.Context, topics []string, dir string, withData bool) ([]*model.TopicBackup, error)
+}
+
+type file interface {
+	Read(fileName string) ([]byte, error)
+}
+
 var DeleteTopicCmd = &cobra.Command{
 	Use:   "delete",
 	Short: "Delete the topics satisfying the passed criteria if any",
@@ -41,18 +64,30 @@ var DeleteTopicCmd = &cobra.Command{
 		if lastWrite == 0 {
 			baseCmd = base.Init(cobraUtil)
 		} else {
-			baseCmd = base.Init(cobraUtil, base.WithSSH())
+			baseCmd = base.Init(cobraUtil, base.WithLastWriteSource(cobraUtil.GetStringArg("last-write-source")))
 		}
+		kafkaClient := baseCmd.GetClient()
 		d := deleteTopic{
-			Lister:         baseCmd.GetTopic(),
-			Deleter:        baseCmd.GetTopic(),
-			lastWrite:      lastWrite,
-			dataDir:        cobraUtil.GetStringArg("data-dir"),
-			topicWhitelist: cobraUtil.GetStringArg("topic-whitelist"),
-			topicBlacklist: cobraUtil.GetStringArg("topic-blacklist"),
-			sshPort:        cobraUtil.GetStringArg("ssh-port"),
-			sshKeyFilePath: cobraUtil.GetStringArg("ssh-key-file-path"),
-			userInput:      &ui.UserInput{},
+			Lister:            baseCmd.GetTopic(),
+			Deleter:           baseCmd.GetTopic(),
+			file:              &io.File{},
+			groups:            kafkaClient,
+			safety:            cobraUtil.GetSafetyGuard(),
+			backup:            model.NewTopicBackupWriter(baseCmd.GetTopic(), baseCmd.GetTopic(), kafkaClient, kafkaClient, kafkaClient),
+			backupDir:         cobraUtil.GetStringArg("backup-dir"),
+			backupData:        cobraUtil.GetBoolArg("backup-data"),
+			lastWrite:         lastWrite,
+			dataDir:           cobraUtil.GetStringArg("data-dir"),
+			topicWhitelist:    cobraUtil.GetStringArg("topic-whitelist"),
+			topicBlacklist:    cobraUtil.GetStringArg("topic-blacklist"),
+			fromFile:          cobraUtil.GetStringArg("from-file"),
+			replicationFactor: cobraUtil.GetIntArg("replication-factor"),
+			size:              int64(cobraUtil.GetIntArg("size")),
+			force:             cobraUtil.GetBoolArg("force"),
+			sshPort:           cobraUtil.GetStringArg("ssh-port"),
+			sshKeyFilePath:    cobraUtil.GetStringArg("ssh-key-file-path"),
+			userInput:         &ui.UserInput{},
+			renderer:          cobraUtil.GetRenderer(),
 		}
 		d.deleteTopic()
 	},
@@ -63,77 +98,192 @@ func init() {
 	DeleteTopicCmd.PersistentFlags().StringP("data-dir", "d", "/var/log/kafka", "Data directory for kafka logs")
 	DeleteTopicCmd.PersistentFlags().StringP("topic-whitelist", "", "", "Regex pattern to include topics")
 	DeleteTopicCmd.PersistentFlags().StringP("topic-blacklist", "", "", "Regex pattern to exclude topics")
+	DeleteTopicCmd.PersistentFlags().String("from-file", "",
+		"File with the topics to delete, as the csv or json output of topic usage, or one topic per line")
+	DeleteTopicCmd.PersistentFlags().IntP("replication-factor", "r", 0, "Replication Factor of the topic")
+	DeleteTopicCmd.PersistentFlags().Int64P("size", "s", -1,
+		"Size less than or equal to specified in bytes. Compares the true size utilized by topic on disk. ie dataProduced*replicationFactor")
+	DeleteTopicCmd.PersistentFlags().Bool("force", false, "Delete the topics even when consumer groups have members assigned to them")
+	DeleteTopicCmd.PersistentFlags().String("backup-dir", "",
+		"Directory to back up the partitions, configs, acls and consumer group offsets of the topics to before deleting them")
+	DeleteTopicCmd.PersistentFlags().Bool("backup-data", false, "Export the messages of the topics to the backup directory too")
+	DeleteTopicCmd.PersistentFlags().String("last-write-source", base.LastWriteSourceSSH,
+		"Source of the last write time, one of ssh|api. api uses the timestamps of the last messages and the consumer group assignments and committed offsets")
 	DeleteTopicCmd.PersistentFlags().StringP("ssh-port", "p", ssh_config.Default("Port"), "Ssh port on the kafka brokers")
 	DeleteTopicCmd.PersistentFlags().StringP("ssh-key-file-path", "k", "~/.ssh/id_rsa", "Path to ssh key file")
 }
 
 func (d *deleteTopic) deleteTopic() {
-	regex, include, err := d.filterCriteria()
-	if err != nil {
-		logger.Fatal(err)
-	}
-	topics, err := d.getTopics(regex, include)
-	if err != nil {
-		logger.Fatal(err)
-	}
+	d.validateArgs()
+	topics := d.topicsToDelete()
 	if len(topics) == 0 {
 		return
 	}
-	fmt.Println("------------------------------------------------------------")
-	for _, topic := range topics {
-		fmt.Println(topic)
+	if err := d.safety.Check(model.OperationDelete, topics); err != nil {
+		logger.Fatal(err)
 	}
-	fmt.Println("------------------------------------------------------------")
+	d.previewTopics(topics)
 	confirmDelete := d.userInput.AskForConfirmation("Do you really want to delete the above topics?")
 	if confirmDelete {
-		err = d.Delete(topics)
+		d.backupTopics(topics)
+		err := d.Delete(topics)
+		d.printDeletionResults(topics, err)
 		if err != nil {
 			logger.Fatalf("Error while deleting topics - %v\n", err)
 		}
 	}
 }
 
-func (d *deleteTopic) filterCriteria() (regex string, include bool, err error) {
-	if ((d.topicWhitelist == "") && (d.topicBlacklist == "")) || ((d.topicWhitelist != "") && (d.topicBlacklist != "")) {
-		return regex, include, fmt.Errorf("any one of blacklist or whitelist should be passed")
+func (d *deleteTopic) validateArgs() {
+	if d.topicWhitelist == "" && d.topicBlacklist == "" && d.fromFile == "" {
+		logger.Fatal("any of blacklist, whitelist or from-file should be passed")
 	}
-	if d.topicWhitelist != "" {
-		include = true
-		regex = d.topicWhitelist
-	} else if d.topicBlacklist != "" {
-		include = false
-		regex = d.topicBlacklist
+	if d.backupData && d.backupDir == "" {
+		logger.Fatal("backup-data requires backup-dir to be passed")
 	}
-	return regex, include, nil
 }
 
-func (d *deleteTopic) getLastWrittenTopics() ([]string, error) {
-	topics, err := d.ListLastWrittenTopics(d.lastWrite, d.dataDir)
+// previewTopics shows the topics with their partitions and replication factor before they are deleted
+func (d *deleteTopic) previewTopics(topics []string) {
+	details, err := d.List()
 	if err != nil {
-		logger.Errorf("Error while fetching topic list - %v\n", err)
-		return nil, err
+		logger.Fatalf("Error while fetching topic list - %v\n", err)
+	}
+	rows := make([]ui.Row, 0, len(topics))
+	for _, topic := range topics {
+		rows = append(rows, ui.Topic(topic, details[topic].NumPartitions, details[topic].ReplicationFactor))
+	}
+	if err = d.renderer.Render(rows); err != nil {
+		logger.Fatalf("Error while printing the topics to delete - %v\n", err)
 	}
-	return topics, nil
 }
 
-func (d *deleteTopic) getTopics(regex string, include bool) ([]string, error) {
-	if d.lastWrite != 0 {
-		lastWrittenTopics, err := d.getLastWrittenTopics()
+// backupTopics writes the backups of the topics when a backup directory is passed, none of the topics is deleted if it fails
+func (d *deleteTopic) backupTopics(topics []string) {
+	if d.backupDir == "" {
+		return
+	}
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	sigTermHandler := io.SignalHandler{}
+	sigTermHandler.SetListener(ctx, cancelFunc, syscall.SIGTERM)
+	sigTermHandler.SetListener(ctx, cancelFunc, syscall.SIGINT)
+	defer sigTermHandler.Close()
+	defer cancelFunc()
+
+	if _, err := d.backup.Backup(ctx, topics, d.backupDir, d.backupData); err != nil {
+		logger.Fatalf("Error while backing up topics, no topic was deleted - %v\n", err)
+	}
+}
+
+// printDeletionResults prints the result of each topic, all the topics failed when the error is not per topic
+func (d *deleteTopic) printDeletionResults(topics []string, err error) {
+	var deleteErr *client.DeleteTopicsError
+	isDeleteErr := errors.As(err, &deleteErr)
+	rows := make([]ui.Row, 0, len(topics))
+	for _, topic := range topics {
+		topicErr := err
+		if isDeleteErr {
+			topicErr = deleteErr.Errors[topic]
+		}
+		rows = append(rows, ui.TopicDeletion(topic, topicErr))
+	}
+	if renderErr := d.renderer.Render(rows); renderErr != nil {
+		logger.Errorf("Error while printing the deletion results - %v\n", renderErr)
+	}
+}
+
+// topicsToDelete returns the topics matching the criteria, without the topics that are refused
+func (d *deleteTopic) topicsToDelete() []string {
+	topics, err := d.getTopics()
+	if err != nil {
+		logger.Fatal(err)
+	}
+	topics, err = d.refuseTopics(topics)
+	if err != nil {
+		logger.Fatalf("Error while fetching consumer groups - %v\n", err)
+	}
+	return topics
+}
+
+// candidateTopics returns the topics of the file and the topics not written since the last write time,
+// or nil when neither is passed
+func (d *deleteTopic) candidateTopics() ([]string, error) {
+	var topics []string
+	if d.fromFile != "" {
+		data, err := d.Read(d.fromFile)
 		if err != nil {
+			return nil, fmt.Errorf("err while reading the topics file %v - %v", d.fromFile, err)
+		}
+		if topics, err = model.ParseTopicList(data); err != nil {
 			return nil, err
 		}
-		topics, err := model.ListUtil{List: lastWrittenTopics}.Filter(regex, include)
+	}
+	if d.lastWrite != 0 {
+		lastWrittenTopics, err := d.getLastWrittenTopics()
 		if err != nil {
-			logger.Errorf("Error while fetching topic list - %v\n", err)
 			return nil, err
 		}
-		return topics, nil
+		if topics == nil {
+			return append([]string{}, lastWrittenTopics...), nil
+		}
+		return model.Intersect(topics, lastWrittenTopics), nil
+	}
+	return topics, nil
+}
+
+func (d *deleteTopic) getLastWrittenTopics() ([]string, error) {
+	topics, err := d.ListLastWrittenTopics(d.lastWrite, d.dataDir)
+	if err != nil {
+		logger.Errorf("Error while fetching topic list - %v\n", err)
+		return nil, err
 	}
+	return topics, nil
+}
 
-	topics, err := d.ListOnly(regex, include)
+func (d *deleteTopic) getTopics() ([]string, error) {
+	candidates, err := d.candidateTopics()
+	if err != nil {
+		return nil, err
+	}
+	selection := model.TopicSelection{
+		Include:           d.topicWhitelist,
+		Exclude:           d.topicBlacklist,
+		ReplicationFactor: d.replicationFactor,
+		MaxSize:           d.size,
+	}
+	var topics []string
+	if candidates == nil {
+		topics, err = selection.Select(d.Lister)
+	} else {
+		topics, err = selection.SelectFrom(d.Lister, candidates)
+	}
 	if err != nil {
 		logger.Errorf("Error while fetching topic list - %v\n", err)
 		return nil, err
 	}
-	return topics, err
+	return topics, nil
+}
+
+// refuseTopics drops the internal topics, and the topics with active consumer groups unless forced
+func (d *deleteTopic) refuseTopics(topics []string) ([]string, error) {
+	var subscribedTopics map[string][]string
+	if !d.force {
+		var err error
+		if subscribedTopics, err = d.groups.ListSubscribedTopics(); err != nil {
+			return nil, err
+		}
+	}
+	allowed := make([]string, 0, len(topics))
+	for _, topic := range topics {
+		if model.IsInternalTopic(topic) {
+			logger.Warnf("Refusing to delete internal topic %v\n", topic)
+			continue
+		}
+		if groups := subscribedTopics[topic]; len(groups) > 0 {
+			logger.Warnf("Refusing to delete topic %v with active consumer groups %v, pass --force to delete it\n", topic, groups)
+			continue
+		}
+		allowed = append(allowed, topic)
+	}
+	return allowed, nil
 }
@@ -1,11 +1,18 @@
 package delete
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
+	"io/ioutil"
 	"os"
 	"testing"
 
+	"github.com/gojek/kat/cmd/base"
 	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/gojek/kat/ui"
 
 	"github.com/gojek/kat/logger"
 
@@ -18,6 +25,11 @@ func init() {
 	logger.SetDummyLogger()
 }
 
+func tableRenderer() *ui.Renderer {
+	renderer, _ := ui.NewRenderer(ui.OutputTable, ioutil.Discard)
+	return renderer
+}
+
 func TestDelete_ReturnWhenWhiteListAndBlackListAreEmpty(t *testing.T) {
 	mockLister := &client.MockLister{}
 	fakeExit := func(int) {
@@ -25,25 +37,28 @@ func TestDelete_ReturnWhenWhiteListAndBlackListAreEmpty(t *testing.T) {
 	}
 	patch := monkey.Patch(os.Exit, fakeExit)
 	defer patch.Unpatch()
-	d := deleteTopic{Lister: mockLister}
+	d := deleteTopic{Lister: mockLister, safety: noSafetyPolicy(), renderer: tableRenderer()}
 	assert.PanicsWithValue(t, "os.Exit called", d.deleteTopic, "os.Exit was not called")
 	mockLister.AssertNotCalled(t, "ListOnly", mock.Anything, mock.Anything)
 	mockLister.AssertNotCalled(t, "ListLastWrittenTopics", mock.Anything, mock.Anything)
 	mockLister.AssertExpectations(t)
 }
 
-func TestDelete_ReturnWhenWhiteListAndBlackListAreBothPassed(t *testing.T) {
+func TestDelete_CombinesWhiteListAndBlackList(t *testing.T) {
 	mockLister := &client.MockLister{}
-	fakeExit := func(int) {
-		panic("os.Exit called")
-	}
-	patch := monkey.Patch(os.Exit, fakeExit)
-	defer patch.Unpatch()
-	d := deleteTopic{Lister: mockLister, topicWhitelist: "some", topicBlacklist: "some"}
-	assert.PanicsWithValue(t, "os.Exit called", d.deleteTopic, "os.Exit was not called")
-	mockLister.AssertNotCalled(t, "ListOnly", mock.Anything, mock.Anything)
-	mockLister.AssertNotCalled(t, "ListLastWrittenTopics", mock.Anything, mock.Anything)
+	mockDeleter := &client.MockDeleter{}
+	mockUserInput := &MockUserInput{}
+
+	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, groups: noSubscriptions(), topicWhitelist: "test-.*",
+		topicBlacklist: "test-2", size: -1, userInput: mockUserInput, safety: noSafetyPolicy(), renderer: tableRenderer()}
+	mockLister.On("List").Return(topicDetails("test-1", "test-2", "other"), nil)
+	mockDeleter.On("Delete", []string{"test-1"}).Return(nil)
+	mockUserInput.On("AskForConfirmation", mock.Anything).Return(true)
+
+	d.deleteTopic()
 	mockLister.AssertExpectations(t)
+	mockDeleter.AssertExpectations(t)
+	mockUserInput.AssertExpectations(t)
 }
 
 func TestDelete_WhenLastWriteIsNotPassed_DeletesWhiteListedTopicsOnConfirmation(t *testing.T) {
@@ -52,8 +67,9 @@ func TestDelete_WhenLastWriteIsNotPassed_DeletesWhiteListedTopicsOnConfirmation(
 	mockUserInput := &MockUserInput{}
 	topics := []string{"test-1", "test-2"}
 
-	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, topicWhitelist: "test-1|test-2", userInput: mockUserInput}
-	mockLister.On("ListOnly", d.topicWhitelist, true).Return(topics, nil)
+	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, topicWhitelist: "test-1|test-2",
+		userInput: mockUserInput, groups: noSubscriptions(), size: -1, safety: noSafetyPolicy(), renderer: tableRenderer()}
+	mockLister.On("List").Return(topicDetails("test-1", "test-2", "test-3"), nil)
 	mockDeleter.On("Delete", topics).Return(nil)
 	mockUserInput.On("AskForConfirmation", mock.Anything).Return(true)
 
@@ -70,8 +86,9 @@ func TestDelete_WhenLastWriteIsNotPassed_DeletesWhiteListedTopicsOnNo(t *testing
 	mockUserInput := &MockUserInput{}
 	topics := []string{"test-1", "test-2"}
 
-	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, topicWhitelist: "test-1|test-2", userInput: mockUserInput}
-	mockLister.On("ListOnly", d.topicWhitelist, true).Return(topics, nil)
+	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, topicWhitelist: "test-1|test-2",
+		userInput: mockUserInput, groups: noSubscriptions(), size: -1, safety: noSafetyPolicy(), renderer: tableRenderer()}
+	mockLister.On("List").Return(topicDetails("test-1", "test-2", "test-3"), nil)
 	mockUserInput.On("AskForConfirmation", mock.Anything).Return(false)
 
 	d.deleteTopic()
@@ -88,8 +105,9 @@ func TestDelete_WhenLastWriteIsNotPassed_DeletesBlackListedTopicsOnConfirmation(
 	mockUserInput := &MockUserInput{}
 	topics := []string{"test-3", "test-4"}
 
-	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, topicBlacklist: "test-1|test-2", userInput: mockUserInput}
-	mockLister.On("ListOnly", d.topicBlacklist, false).Return(topics, nil)
+	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, topicBlacklist: "test-1|test-2",
+		userInput: mockUserInput, groups: noSubscriptions(), size: -1, safety: noSafetyPolicy(), renderer: tableRenderer()}
+	mockLister.On("List").Return(topicDetails("test-1", "test-2", "test-3", "test-4"), nil)
 	mockDeleter.On("Delete", topics).Return(nil)
 	mockUserInput.On("AskForConfirmation", mock.Anything).Return(true)
 
@@ -106,8 +124,9 @@ func TestDelete_WhenLastWriteIsNotPassed_DeletesBlackListedTopicsOnNo(t *testing
 	mockUserInput := &MockUserInput{}
 	topics := []string{"test-3", "test-4"}
 
-	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, topicBlacklist: "test-1|test-2", userInput: mockUserInput}
-	mockLister.On("ListOnly", d.topicBlacklist, false).Return(topics, nil)
+	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, topicBlacklist: "test-1|test-2",
+		userInput: mockUserInput, groups: noSubscriptions(), size: -1, safety: noSafetyPolicy(), renderer: tableRenderer()}
+	mockLister.On("List").Return(topicDetails("test-1", "test-2", "test-3", "test-4"), nil)
 	mockUserInput.On("AskForConfirmation", mock.Anything).Return(false)
 
 	d.deleteTopic()
@@ -124,8 +143,10 @@ func TestDelete_WhenLastWriteIsPassed_DeletesWhiteListedTopicsOnConfirmation(t *
 	mockUserInput := &MockUserInput{}
 	topics := []string{"test-3", "test-2"}
 
-	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, topicWhitelist: "test-1|test-2", userInput: mockUserInput, lastWrite: 123}
+	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, topicWhitelist: "test-1|test-2", userInput: mockUserInput, lastWrite: 123,
+		groups: noSubscriptions(), size: -1, safety: noSafetyPolicy(), renderer: tableRenderer()}
 	mockLister.On("ListLastWrittenTopics", d.lastWrite, d.dataDir).Return(topics, nil)
+	mockLister.On("List").Return(topicDetails("test-1", "test-2", "test-3", "test-4"), nil)
 	mockDeleter.On("Delete", []string{"test-2"}).Return(nil)
 	mockUserInput.On("AskForConfirmation", mock.Anything).Return(true)
 
@@ -142,8 +163,10 @@ func TestDelete_WhenLastWriteIsPassed_DeletesWhiteListedTopicsOnNo(t *testing.T)
 	mockUserInput := &MockUserInput{}
 	topics := []string{"test-1", "test-2"}
 
-	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, topicWhitelist: "test-1|test-2", userInput: mockUserInput, lastWrite: 123}
+	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, topicWhitelist: "test-1|test-2", userInput: mockUserInput, lastWrite: 123,
+		groups: noSubscriptions(), size: -1, safety: noSafetyPolicy(), renderer: tableRenderer()}
 	mockLister.On("ListLastWrittenTopics", d.lastWrite, d.dataDir).Return(topics, nil)
+	mockLister.On("List").Return(topicDetails("test-1", "test-2", "test-3", "test-4"), nil)
 	mockUserInput.On("AskForConfirmation", mock.Anything).Return(false)
 
 	d.deleteTopic()
@@ -160,8 +183,10 @@ func TestDelete_WhenLastWriteIsPassed_DeletesBlackListedTopicsOnConfirmation(t *
 	mockUserInput := &MockUserInput{}
 	topics := []string{"test-3", "test-2"}
 
-	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, topicBlacklist: "test-1|test-2", userInput: mockUserInput, lastWrite: 123}
+	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, topicBlacklist: "test-1|test-2", userInput: mockUserInput, lastWrite: 123,
+		groups: noSubscriptions(), size: -1, safety: noSafetyPolicy(), renderer: tableRenderer()}
 	mockLister.On("ListLastWrittenTopics", d.lastWrite, d.dataDir).Return(topics, nil)
+	mockLister.On("List").Return(topicDetails("test-1", "test-2", "test-3", "test-4"), nil)
 	mockDeleter.On("Delete", []string{"test-3"}).Return(nil)
 	mockUserInput.On("AskForConfirmation", mock.Anything).Return(true)
 
@@ -178,8 +203,10 @@ func TestDelete_WhenLastWriteIsPassed_DeletesBlackListedTopicsOnNo(t *testing.T)
 	mockUserInput := &MockUserInput{}
 	topics := []string{"test-3", "test-4"}
 
-	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, topicBlacklist: "test-1|test-2", userInput: mockUserInput, lastWrite: 123}
+	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, topicBlacklist: "test-1|test-2", userInput: mockUserInput, lastWrite: 123,
+		groups: noSubscriptions(), size: -1, safety: noSafetyPolicy(), renderer: tableRenderer()}
 	mockLister.On("ListLastWrittenTopics", d.lastWrite, d.dataDir).Return(topics, nil)
+	mockLister.On("List").Return(topicDetails("test-1", "test-2", "test-3", "test-4"), nil)
 	mockUserInput.On("AskForConfirmation", mock.Anything).Return(false)
 
 	d.deleteTopic()
@@ -196,7 +223,8 @@ func TestDelete_WhenLastWriteIsPassed_DeletesBlackListedTopicsOnError(t *testing
 	mockUserInput := &MockUserInput{}
 	topics := []string{"test-3", "test-4"}
 
-	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, topicBlacklist: "test-1|test-2", userInput: mockUserInput, lastWrite: 123}
+	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, topicBlacklist: "test-1|test-2", userInput: mockUserInput, lastWrite: 123,
+		groups: noSubscriptions(), size: -1, safety: noSafetyPolicy(), renderer: tableRenderer()}
 	mockLister.On("ListLastWrittenTopics", d.lastWrite, d.dataDir).Return(topics, errors.New("test"))
 	fakeExit := func(int) {
 		panic("os.Exit called")
@@ -213,6 +241,222 @@ func TestDelete_WhenLastWriteIsPassed_DeletesBlackListedTopicsOnError(t *testing
 	mockUserInput.AssertExpectations(t)
 }
 
+func TestDelete_FromFile_DeletesTheListedTopicsMatchingTheFilters(t *testing.T) {
+	mockLister := &client.MockLister{}
+	mockDeleter := &client.MockDeleter{}
+	mockUserInput := &MockUserInput{}
+	file := &mockFile{}
+
+	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, file: file, fromFile: "topics.csv", topicBlacklist: "test-3",
+		replicationFactor: 1, size: 100, userInput: mockUserInput, groups: noSubscriptions(), safety: noSafetyPolicy(), renderer: tableRenderer()}
+	file.On("Read", "topics.csv").Return([]byte("topic,usage\ntest-1,unused\ntest-2,unused\ntest-3,unused\nmissing,unused\n"), nil)
+	mockLister.On("List").Return(topicDetails("test-1", "test-2", "test-3", "test-4"), nil)
+	mockLister.On("ListTopicWithSizeLessThanOrEqualTo", int64(100)).Return([]string{"test-1", "test-3", "test-4"}, nil)
+	mockDeleter.On("Delete", []string{"test-1"}).Return(nil)
+	mockUserInput.On("AskForConfirmation", mock.Anything).Return(true)
+
+	d.deleteTopic()
+	mockLister.AssertExpectations(t)
+	mockDeleter.AssertExpectations(t)
+	mockUserInput.AssertExpectations(t)
+	file.AssertExpectations(t)
+}
+
+func TestDelete_FromFile_ExitsOnReadError(t *testing.T) {
+	mockLister := &client.MockLister{}
+	file := &mockFile{}
+	file.On("Read", "topics.txt").Return([]byte{}, errors.New("error"))
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	d := deleteTopic{Lister: mockLister, file: file, fromFile: "topics.txt", size: -1, safety: noSafetyPolicy(), renderer: tableRenderer()}
+
+	assert.PanicsWithValue(t, "os.Exit called", d.deleteTopic, "os.Exit was not called")
+	mockLister.AssertNotCalled(t, "List")
+	file.AssertExpectations(t)
+}
+
+func TestDelete_RefusesInternalTopicsAndTopicsWithActiveConsumerGroups(t *testing.T) {
+	mockLister := &client.MockLister{}
+	mockDeleter := &client.MockDeleter{}
+	mockUserInput := &MockUserInput{}
+	groups := &client.MockSubscriptionLister{}
+
+	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, topicBlacklist: "^$", size: -1, userInput: mockUserInput, groups: groups,
+		safety: noSafetyPolicy(), renderer: tableRenderer()}
+	mockLister.On("List").Return(topicDetails("__consumer_offsets", "_schemas", "test-1", "test-2"), nil)
+	groups.On("ListSubscribedTopics").Return(map[string][]string{"test-2": {"group-1"}}, nil)
+	mockDeleter.On("Delete", []string{"test-1"}).Return(nil)
+	mockUserInput.On("AskForConfirmation", mock.Anything).Return(true)
+
+	d.deleteTopic()
+	mockLister.AssertExpectations(t)
+	mockDeleter.AssertExpectations(t)
+	groups.AssertExpectations(t)
+}
+
+func TestDelete_Force_DeletesTopicsWithActiveConsumerGroupsButNotInternalTopics(t *testing.T) {
+	mockLister := &client.MockLister{}
+	mockDeleter := &client.MockDeleter{}
+	mockUserInput := &MockUserInput{}
+	groups := &client.MockSubscriptionLister{}
+
+	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, topicWhitelist: ".*", size: -1, force: true, userInput: mockUserInput,
+		groups: groups, safety: noSafetyPolicy(), renderer: tableRenderer()}
+	mockLister.On("List").Return(topicDetails("__consumer_offsets", "test-1", "test-2"), nil)
+	mockDeleter.On("Delete", []string{"test-1", "test-2"}).Return(nil)
+	mockUserInput.On("AskForConfirmation", mock.Anything).Return(true)
+
+	d.deleteTopic()
+	mockDeleter.AssertExpectations(t)
+	groups.AssertNotCalled(t, "ListSubscribedTopics")
+}
+
+func TestDelete_ExitsWhenConsumerGroupsCannotBeFetched(t *testing.T) {
+	mockLister := &client.MockLister{}
+	mockDeleter := &client.MockDeleter{}
+	groups := &client.MockSubscriptionLister{}
+	mockLister.On("List").Return(topicDetails("test-1"), nil)
+	groups.On("ListSubscribedTopics").Return(map[string][]string{}, errors.New("error"))
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, topicWhitelist: ".*", size: -1, groups: groups, safety: noSafetyPolicy(), renderer: tableRenderer()}
+
+	assert.PanicsWithValue(t, "os.Exit called", d.deleteTopic, "os.Exit was not called")
+	mockDeleter.AssertNotCalled(t, "Delete", mock.Anything)
+}
+
+func TestDelete_ExitsWhenRefusedBySafetyPolicy(t *testing.T) {
+	mockLister := &client.MockLister{}
+	mockDeleter := &client.MockDeleter{}
+	mockUserInput := &MockUserInput{}
+	safety := &base.MockSafetyGuard{}
+	mockLister.On("List").Return(topicDetails("test-1", "test-2"), nil)
+	safety.On("Check", model.OperationDelete, []string{"test-1", "test-2"}).Return(errors.New("refused"))
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, topicWhitelist: "test", size: -1, userInput: mockUserInput,
+		groups: noSubscriptions(), safety: safety, renderer: tableRenderer()}
+
+	assert.PanicsWithValue(t, "os.Exit called", d.deleteTopic, "os.Exit was not called")
+	safety.AssertExpectations(t)
+	mockUserInput.AssertNotCalled(t, "AskForConfirmation", mock.Anything)
+	mockDeleter.AssertNotCalled(t, "Delete", mock.Anything)
+}
+
+func TestDelete_ExitsWhenSomeTopicsAreNotDeleted(t *testing.T) {
+	mockLister := &client.MockLister{}
+	mockDeleter := &client.MockDeleter{}
+	mockUserInput := &MockUserInput{}
+	topics := []string{"test-1", "test-2"}
+	mockLister.On("List").Return(topicDetails("test-1", "test-2"), nil)
+	mockUserInput.On("AskForConfirmation", mock.Anything).Return(true)
+	mockDeleter.On("Delete", topics).Return(&client.DeleteTopicsError{Total: 2, Errors: map[string]error{"test-2": errors.New("not authorized")}})
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	out := &bytes.Buffer{}
+	renderer, _ := ui.NewRenderer(ui.OutputJSON, out)
+	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, topicWhitelist: "test", size: -1, userInput: mockUserInput,
+		groups: noSubscriptions(), safety: noSafetyPolicy(), renderer: renderer}
+
+	assert.PanicsWithValue(t, "os.Exit called", d.deleteTopic, "os.Exit was not called")
+	mockDeleter.AssertExpectations(t)
+	decoder := json.NewDecoder(out)
+	var preview, results json.RawMessage
+	assert.NoError(t, decoder.Decode(&preview))
+	assert.NoError(t, decoder.Decode(&results))
+	assert.JSONEq(t, `[{"topic": "test-1", "partitions": 1, "replicationFactor": 1}, {"topic": "test-2", "partitions": 1, "replicationFactor": 1}]`,
+		string(preview))
+	assert.JSONEq(t, `[{"topic": "test-1", "result": "Deleted"}, {"topic": "test-2", "result": "Failed", "error": "not authorized"}]`,
+		string(results))
+}
+
+func TestDelete_BacksUpTopicsBeforeDeleting(t *testing.T) {
+	mockLister := &client.MockLister{}
+	mockDeleter := &client.MockDeleter{}
+	mockUserInput := &MockUserInput{}
+	backup := &mockTopicBackup{}
+	topics := []string{"test-1", "test-2"}
+	mockLister.On("List").Return(topicDetails("test-1", "test-2"), nil)
+	mockUserInput.On("AskForConfirmation", mock.Anything).Return(true)
+	backup.On("Backup", topics, "/tmp/backup", true).Return([]*model.TopicBackup{}, nil)
+	mockDeleter.On("Delete", topics).Return(nil)
+	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, topicWhitelist: "test", size: -1, userInput: mockUserInput,
+		groups: noSubscriptions(), safety: noSafetyPolicy(), backup: backup, backupDir: "/tmp/backup", backupData: true, renderer: tableRenderer()}
+
+	d.deleteTopic()
+	backup.AssertExpectations(t)
+	mockDeleter.AssertExpectations(t)
+}
+
+func TestDelete_DoesNotDeleteWhenBackupFails(t *testing.T) {
+	mockLister := &client.MockLister{}
+	mockDeleter := &client.MockDeleter{}
+	mockUserInput := &MockUserInput{}
+	backup := &mockTopicBackup{}
+	mockLister.On("List").Return(topicDetails("test-1"), nil)
+	mockUserInput.On("AskForConfirmation", mock.Anything).Return(true)
+	backup.On("Backup", []string{"test-1"}, "/tmp/backup", false).Return([]*model.TopicBackup{}, errors.New("disk full"))
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	d := deleteTopic{Lister: mockLister, Deleter: mockDeleter, topicWhitelist: "test", size: -1, userInput: mockUserInput,
+		groups: noSubscriptions(), safety: noSafetyPolicy(), backup: backup, backupDir: "/tmp/backup", renderer: tableRenderer()}
+
+	assert.PanicsWithValue(t, "os.Exit called", d.deleteTopic, "os.Exit was not called")
+	backup.AssertExpectations(t)
+	mockDeleter.AssertNotCalled(t, "Delete", mock.Anything)
+}
+
+func TestDelete_ExitsWhenBackupDataIsPassedWithoutBackupDir(t *testing.T) {
+	mockLister := &client.MockLister{}
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	d := deleteTopic{Lister: mockLister, topicWhitelist: "test", backupData: true, safety: noSafetyPolicy(), renderer: tableRenderer()}
+
+	assert.PanicsWithValue(t, "os.Exit called", d.deleteTopic, "os.Exit was not called")
+	mockLister.AssertNotCalled(t, "List")
+}
+
+func topicDetails(topics ...string) map[string]client.TopicDetail {
+	details := make(map[string]client.TopicDetail, len(topics))
+	for _, topic := range topics {
+		details[topic] = client.TopicDetail{NumPartitions: 1, ReplicationFactor: 1}
+	}
+	return details
+}
+
+func noSubscriptions() *client.MockSubscriptionLister {
+	groups := &client.MockSubscriptionLister{}
+	groups.On("ListSubscribedTopics").Return(map[string][]string{}, nil)
+	return groups
+}
+
+type mockFile struct {
+	mock.Mock
+}
+
+func (m *mockFile) Read(fileName string) ([]byte, error) {
+	args := m.Called(fileName)
+	return args.Get(0).([]byte), args.Error(1)
+}
+
 type MockUserInput struct {
 	mock.Mock
 }
@@ -221,3 +465,18 @@ func (m *MockUserInput) AskForConfirmation(question string) bool {
 	args := m.Called(question)
 	return args.Bool(0)
 }
+
+func noSafetyPolicy() *base.MockSafetyGuard {
+	safety := &base.MockSafetyGuard{}
+	safety.On("Check", mock.Anything, mock.Anything).Return(nil)
+	return safety
+}
+
+type mockTopicBackup struct {
+	mock.Mock
+}
+
+func (m *mockTopicBackup) Backup(ctx context.Context, topics []string, dir string, withData bool) ([]*model.TopicBackup, error) {
+	args := m.Called(topics, dir, withData)
+	return args.Get(0).([]*model.TopicBackup), args.Error(1)
+}
@@ -0,0 +1,127 @@
+package delete
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"syscall"
+
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/pkg/io"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+type topicRestorer interface {
+	Restore(ctx context.Context, backup *model.TopicBackup, dir string, opts model.RestoreOptions) (int, error)
+}
+
+type restoreTopic struct {
+	restorer  topicRestorer
+	safety    base.SafetyChecker
+	backupDir string
+	topics    []string
+	opts      model.RestoreOptions
+}
+
+var RestoreTopicCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Recreate topics from the backups written by topic delete --backup-dir",
+	Run: func(command *cobra.Command, args []string) {
+		cobraUtil := base.NewCobraUtil(command)
+		addr := strings.Split(cobraUtil.GetStringArg("broker-list"), ",")
+		writer, err := client.NewSaramaProducer(addr, client.ProducerConfig{Partitioner: client.PartitionerManual, Acks: client.AcksAll})
+		if err != nil {
+			logger.Fatalf("Error while creating the producer - %v\n", err)
+		}
+		defer func() { _ = writer.Close() }()
+
+		baseCmd := base.Init(cobraUtil)
+		r := restoreTopic{
+			restorer:  model.NewTopicRestorer(baseCmd.GetTopic(), baseCmd.GetClient(), writer),
+			safety:    cobraUtil.GetSafetyGuard(),
+			backupDir: cobraUtil.GetStringArg("backup-dir"),
+			topics:    cobraUtil.GetStringSliceArg("topics"),
+			opts: model.RestoreOptions{
+				IgnoreAssignment: cobraUtil.GetBoolArg("ignore-assignment"),
+				SkipData:         cobraUtil.GetBoolArg("skip-data"),
+			},
+		}
+		r.restoreTopic()
+	},
+}
+
+func init() {
+	RestoreTopicCmd.PersistentFlags().String("backup-dir", "", "Directory with the backups written by topic delete --backup-dir")
+	RestoreTopicCmd.PersistentFlags().StringSlice("topics", []string{}, "Comma separated list of topics to restore, defaults to all the backups in the directory")
+	RestoreTopicCmd.PersistentFlags().Bool("ignore-assignment", false,
+		"Let the cluster place the replicas, instead of using the replica assignment of the backup")
+	RestoreTopicCmd.PersistentFlags().Bool("skip-data", false, "Do not produce the messages exported in the backup")
+	if err := RestoreTopicCmd.MarkPersistentFlagRequired("backup-dir"); err != nil {
+		logger.Fatal(err)
+	}
+}
+
+func (r *restoreTopic) restoreTopic() {
+	backups := r.loadBackups()
+	topics := make([]string, 0, len(backups))
+	for _, backup := range backups {
+		topics = append(topics, backup.Topic)
+	}
+	if err := r.safety.Check(model.OperationCreate, topics); err != nil {
+		logger.Fatal(err)
+	}
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	sigTermHandler := io.SignalHandler{}
+	sigTermHandler.SetListener(ctx, cancelFunc, syscall.SIGTERM)
+	sigTermHandler.SetListener(ctx, cancelFunc, syscall.SIGINT)
+	defer sigTermHandler.Close()
+	defer cancelFunc()
+
+	for _, backup := range backups {
+		restored, err := r.restorer.Restore(ctx, backup, r.backupDir, r.opts)
+		if err != nil {
+			logger.Fatalf("Error while restoring topic %v - %v\n", backup.Topic, err)
+		}
+		logger.Infof("Restored topic %v with %d acls and %d messages\n", backup.Topic, len(backup.ACLs), restored)
+		if len(backup.ConsumerGroupOffsets) > 0 {
+			logger.Warnf("The offsets of consumer groups %v on topic %v are not restored, the offsets of the recreated topic start from 0. "+
+				"The committed offsets are kept in %v\n", groupIDs(backup), backup.Topic, model.TopicBackupFile(r.backupDir, backup.Topic))
+		}
+	}
+}
+
+// loadBackups reads the backups of all the topics before restoring any of them
+func (r *restoreTopic) loadBackups() []*model.TopicBackup {
+	topics := r.topics
+	if len(topics) == 0 {
+		var err error
+		if topics, err = model.ListTopicBackups(r.backupDir); err != nil {
+			logger.Fatalf("Error while listing the backups - %v\n", err)
+		}
+		if len(topics) == 0 {
+			logger.Fatalf("No topic backups found in %v\n", r.backupDir)
+		}
+	}
+	backups := make([]*model.TopicBackup, 0, len(topics))
+	for _, topic := range topics {
+		backup, err := model.LoadTopicBackup(r.backupDir, topic)
+		if err != nil {
+			logger.Fatalf("Error while reading the backup of topic %v - %v\n", topic, err)
+		}
+		backups = append(backups, backup)
+	}
+	return backups
+}
+
+func groupIDs(backup *model.TopicBackup) []string {
+	groups := make([]string, 0, len(backup.ConsumerGroupOffsets))
+	for group := range backup.ConsumerGroupOffsets {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+	return groups
+}
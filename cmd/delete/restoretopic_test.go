@@ -0,0 +1,112 @@
+package delete
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTopicRestorer struct {
+	mock.Mock
+}
+
+func (m *mockTopicRestorer) Restore(ctx context.Context, backup *model.TopicBackup, dir string, opts model.RestoreOptions) (int, error) {
+	args := m.Called(backup.Topic, dir, opts)
+	return args.Int(0), args.Error(1)
+}
+
+func writeBackups(t *testing.T, topics ...string) string {
+	dir, err := ioutil.TempDir("", "backup")
+	require.NoError(t, err)
+	for _, topic := range topics {
+		data := `{"formatVersion": 1, "topic": "` + topic + `", "numPartitions": 1, "replicationFactor": 1}`
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, topic+".json"), []byte(data), 0644))
+	}
+	return dir
+}
+
+func TestRestore_RestoresAllTheBackupsOfTheDirectory(t *testing.T) {
+	dir := writeBackups(t, "orders", "payments")
+	defer os.RemoveAll(dir)
+	restorer := &mockTopicRestorer{}
+	opts := model.RestoreOptions{IgnoreAssignment: true}
+	restorer.On("Restore", "orders", dir, opts).Return(10, nil)
+	restorer.On("Restore", "payments", dir, opts).Return(0, nil)
+	safety := &base.MockSafetyGuard{}
+	safety.On("Check", model.OperationCreate, []string{"orders", "payments"}).Return(nil)
+	r := restoreTopic{restorer: restorer, safety: safety, backupDir: dir, opts: opts}
+
+	r.restoreTopic()
+	restorer.AssertExpectations(t)
+	safety.AssertExpectations(t)
+}
+
+func TestRestore_RestoresOnlyThePassedTopics(t *testing.T) {
+	dir := writeBackups(t, "orders", "payments")
+	defer os.RemoveAll(dir)
+	restorer := &mockTopicRestorer{}
+	restorer.On("Restore", "payments", dir, model.RestoreOptions{}).Return(0, nil)
+	r := restoreTopic{restorer: restorer, safety: noSafetyPolicy(), backupDir: dir, topics: []string{"payments"}}
+
+	r.restoreTopic()
+	restorer.AssertExpectations(t)
+	restorer.AssertNumberOfCalls(t, "Restore", 1)
+}
+
+func TestRestore_ExitsWhenABackupIsMissing(t *testing.T) {
+	dir := writeBackups(t, "orders")
+	defer os.RemoveAll(dir)
+	restorer := &mockTopicRestorer{}
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	r := restoreTopic{restorer: restorer, safety: noSafetyPolicy(), backupDir: dir, topics: []string{"orders", "payments"}}
+
+	assert.PanicsWithValue(t, "os.Exit called", r.restoreTopic, "os.Exit was not called")
+	restorer.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRestore_ExitsWhenRefusedBySafetyPolicy(t *testing.T) {
+	dir := writeBackups(t, "orders")
+	defer os.RemoveAll(dir)
+	restorer := &mockTopicRestorer{}
+	safety := &base.MockSafetyGuard{}
+	safety.On("Check", model.OperationCreate, []string{"orders"}).Return(errors.New("refused"))
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	r := restoreTopic{restorer: restorer, safety: safety, backupDir: dir}
+
+	assert.PanicsWithValue(t, "os.Exit called", r.restoreTopic, "os.Exit was not called")
+	restorer.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRestore_ExitsWhenRestoreFails(t *testing.T) {
+	dir := writeBackups(t, "orders")
+	defer os.RemoveAll(dir)
+	restorer := &mockTopicRestorer{}
+	restorer.On("Restore", "orders", dir, model.RestoreOptions{}).Return(0, errors.New("topic exists"))
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	r := restoreTopic{restorer: restorer, safety: noSafetyPolicy(), backupDir: dir}
+
+	assert.PanicsWithValue(t, "os.Exit called", r.restoreTopic, "os.Exit was not called")
+	restorer.AssertExpectations(t)
+}
@@ -1,9 +1,8 @@
 package describe
 
 import (
-	"fmt"
-
 	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/ui"
 
 	"github.com/gojek/kat/cmd/base"
 
@@ -13,7 +12,8 @@ import (
 
 type describeTopic struct {
 	client.Describer
-	topics []string
+	renderer *ui.Renderer
+	topics   []string
 }
 
 var DescribeTopicCmd = &cobra.Command{
@@ -21,7 +21,7 @@ var DescribeTopicCmd = &cobra.Command{
 	Short: "Describes the given topic",
 	Run: func(command *cobra.Command, args []string) {
 		cobraUtil := base.NewCobraUtil(command)
-		d := describeTopic{Describer: base.Init(cobraUtil).GetTopic(), topics: cobraUtil.GetTopicNames()}
+		d := describeTopic{Describer: base.Init(cobraUtil).GetTopic(), renderer: cobraUtil.GetRenderer(), topics: cobraUtil.GetTopicNames()}
 		d.describeTopic()
 	},
 }
@@ -38,19 +38,18 @@ func (d *describeTopic) describeTopic() {
 	if err != nil {
 		logger.Fatalf("Error while retrieving topic metadata - %v\n", err)
 	}
-	printConfigs(metadata)
+	if err = d.renderer.Render(partitionRows(metadata)); err != nil {
+		logger.Fatalf("Error while writing the topic metadata - %v\n", err)
+	}
 }
 
-func printConfigs(metadata []*client.TopicMetadata) {
+func partitionRows(metadata []*client.TopicMetadata) []ui.Row {
+	var rows []ui.Row
 	for _, topicMetadata := range metadata {
-		fmt.Printf("topic Name: %v,\nIsInternal: %v,\nPartitions:\n", topicMetadata.Name, topicMetadata.IsInternal)
-
-		partitions := topicMetadata.Partitions
-		for _, partitionMetadata := range partitions {
-			fmt.Printf("Id: %v, Leader: %v, Replicas: %v, ISR: %v, OfflineReplicas: %v\n",
-				partitionMetadata.ID, partitionMetadata.Leader, partitionMetadata.Replicas,
-				partitionMetadata.Isr, partitionMetadata.OfflineReplicas)
+		for _, p := range topicMetadata.Partitions {
+			rows = append(rows, ui.PartitionMetadata(topicMetadata.Name, topicMetadata.IsInternal, p.ID, p.Leader, p.Replicas, p.Isr,
+				p.OfflineReplicas))
 		}
-		fmt.Println()
 	}
+	return rows
 }
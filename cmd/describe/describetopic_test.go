@@ -1,6 +1,7 @@
 package describe
 
 import (
+	"bytes"
 	"errors"
 	"os"
 	"testing"
@@ -10,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/ui"
 )
 
 func init() {
@@ -20,11 +22,30 @@ func TestDescribe_Success(t *testing.T) {
 	mockDescriber := &client.MockDescriber{}
 	topics := []string{"topic1"}
 	mockDescriber.On("Describe", topics).Return([]*client.TopicMetadata{}, nil).Times(1)
-	d := describeTopic{Describer: mockDescriber, topics: topics}
+	renderer, _ := ui.NewRenderer(ui.OutputTable, &bytes.Buffer{})
+	d := describeTopic{Describer: mockDescriber, renderer: renderer, topics: topics}
 	d.describeTopic()
 	mockDescriber.AssertExpectations(t)
 }
 
+func TestDescribe_RendersAPartitionPerRow(t *testing.T) {
+	mockDescriber := &client.MockDescriber{}
+	topics := []string{"topic1"}
+	mockDescriber.On("Describe", topics).Return([]*client.TopicMetadata{{Name: "topic1", Partitions: []*client.PartitionMetadata{
+		{ID: 0, Leader: 1, Replicas: []int32{1, 2}, Isr: []int32{1}, OfflineReplicas: []int32{2}},
+		{ID: 1, Leader: 2, Replicas: []int32{2, 1}, Isr: []int32{2, 1}},
+	}}}, nil)
+	var out bytes.Buffer
+	renderer, _ := ui.NewRenderer(ui.OutputCSV, &out)
+	d := describeTopic{Describer: mockDescriber, renderer: renderer, topics: topics}
+
+	d.describeTopic()
+
+	assert.Equal(t, "Topic,Partition,Leader,Replicas,ISR,Offline Replicas,Internal\n"+
+		"topic1,0,1,[1 2],[1],[2],false\n"+
+		"topic1,1,2,[2 1],[2 1],[],false\n", out.String())
+}
+
 func TestDescribe_Failure(t *testing.T) {
 	mockDescriber := &client.MockDescriber{}
 	topics := []string{"topic1"}
@@ -7,6 +7,7 @@ import (
 	"github.com/gojek/kat/cmd/base"
 	"github.com/gojek/kat/logger"
 	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/ui"
 	"github.com/spf13/cobra"
 )
 
@@ -25,38 +26,42 @@ var ListConsumerGroupsCmd = &cobra.Command{
 		cgl := consumerGroupAdmin{
 			saramaClient: client.NewSaramaClient(addr),
 		}
-		err := cgl.ListGroups(cobraUtil.GetStringArg("topic"))
+		groups, err := cgl.ListGroups(cobraUtil.GetStringArg("topic"))
 		if err != nil {
 			logger.Fatalf("Error while listing consumer groups for %v topic", err)
 		}
+		rows := make([]ui.Row, 0, len(groups))
+		for _, group := range groups {
+			rows = append(rows, ui.ConsumerGroup(group))
+		}
+		if err = cobraUtil.GetRenderer().Render(rows); err != nil {
+			logger.Fatalf("Error while writing the consumer groups - %v\n", err)
+		}
 	},
 }
 
-func (c *consumerGroupAdmin) ListGroups(topic string) error {
+// ListGroups returns the consumer groups with members assigned to the partitions of the topic, sorted by name
+func (c *consumerGroupAdmin) ListGroups(topic string) ([]string, error) {
 	consumerGroupsMap, err := c.saramaClient.ListConsumerGroups()
 	if err != nil {
-		return err
-	}
-
-	consumerGroupList := make([]string, len(consumerGroupsMap))
-	for group := range consumerGroupsMap {
-		consumerGroupList = append(consumerGroupList, group)
+		return nil, err
 	}
 
-	sort.Slice(consumerGroupList, func(i int, j int) bool {
-		return consumerGroupList[i] < consumerGroupList[j]
-	})
-
-	var consumerGroups []string
-
+	consumerGroups := make([]string, 0, len(consumerGroupsMap))
 	for consumerGroupID := range consumerGroupsMap {
 		consumerGroups = append(consumerGroups, consumerGroupID)
 	}
+	sort.Strings(consumerGroups)
 
-	_, err = c.saramaClient.GetConsumerGroupsForTopic(consumerGroups, topic)
+	groupsChannel, err := c.saramaClient.GetConsumerGroupsForTopic(consumerGroups, topic)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	subscribedGroups := make([]string, 0)
+	for group := range groupsChannel {
+		subscribedGroups = append(subscribedGroups, group)
+	}
+	sort.Strings(subscribedGroups)
+	return subscribedGroups, nil
 }
@@ -32,10 +32,15 @@ func TestListGroupsReturnsSuccess(t *testing.T) {
 		return (token[0] == "consumer1" && token[1] == "consumer2") || (token[0] == "consumer2" && token[1] == "consumer1")
 	}
 	mockConsumer.On("GetConsumerGroupsForTopic", mock.MatchedBy(validateParams), "").Return(mockChannel, nil)
+	go func() {
+		mockChannel <- "consumer2"
+		close(mockChannel)
+	}()
 
-	err := admin.ListGroups("")
+	groups, err := admin.ListGroups("")
 
 	require.NoError(t, err)
+	assert.Equal(t, []string{"consumer2"}, groups)
 	mockConsumer.AssertExpectations(t)
 }
 
@@ -45,7 +50,7 @@ func TestListGroupsReturnsFailureIfListConsumerGroupsFails(t *testing.T) {
 	multipleConsumers := map[string]string{"consumer1": "", "consumer2": ""}
 	mockConsumer.On("ListConsumerGroups").Return(multipleConsumers, errors.New("list consumer groups failed"))
 
-	err := admin.ListGroups("")
+	_, err := admin.ListGroups("")
 
 	require.Error(t, err)
 	assert.Equal(t, "list consumer groups failed", err.Error())
@@ -62,7 +67,7 @@ func TestListGroupsReturnsFailureIfGetConsumerGroupsFails(t *testing.T) {
 
 	mockConsumer.On("GetConsumerGroupsForTopic", []string{"consumer1", "consumer2"}, "").Return(mockChannel, errors.New("get consumer groups failed"))
 
-	err := admin.ListGroups("")
+	_, err := admin.ListGroups("")
 	require.Error(t, err)
 	assert.Equal(t, "get consumer groups failed", err.Error())
 	mockConsumer.AssertExpectations(t)
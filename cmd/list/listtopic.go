@@ -2,7 +2,7 @@ package list
 
 import (
 	"context"
-	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/gojek/kat/pkg/client"
@@ -10,12 +10,15 @@ import (
 	"github.com/gojek/kat/cmd/base"
 
 	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/ui"
 	"github.com/kevinburke/ssh_config"
 	"github.com/spf13/cobra"
 )
 
 type listTopic struct {
 	client.Lister
+	renderer          *ui.Renderer
+	topicDetails      map[string]client.TopicDetail
 	replicationFactor int
 	lastWrite         int64
 	dataDir           string
@@ -33,11 +36,12 @@ var ListTopicCmd = &cobra.Command{
 		if lastWrite == 0 {
 			baseCmd = base.Init(cobraUtil)
 		} else {
-			baseCmd = base.Init(cobraUtil, base.WithSSH())
+			baseCmd = base.Init(cobraUtil, base.WithLastWriteSource(cobraUtil.GetStringArg("last-write-source")))
 		}
 
 		l := listTopic{
 			Lister:            baseCmd.GetTopic(),
+			renderer:          cobraUtil.GetRenderer(),
 			replicationFactor: cobraUtil.GetIntArg("replication-factor"),
 			lastWrite:         lastWrite,
 			dataDir:           cobraUtil.GetStringArg("data-dir"),
@@ -52,6 +56,8 @@ func init() {
 	ListTopicCmd.PersistentFlags().Int64P("last-write", "l", 0, "Last write time for topics in epoch format")
 	// tododata directory can be fetched with describeLogDirs request making the parameter redundant
 	ListTopicCmd.PersistentFlags().StringP("data-dir", "d", "/var/log/kafka", "Data directory for kafka logs")
+	ListTopicCmd.PersistentFlags().String("last-write-source", base.LastWriteSourceSSH,
+		"Source of the last write time, one of ssh|api. api uses the timestamps of the last messages and the consumer group assignments and committed offsets")
 	ListTopicCmd.PersistentFlags().StringP("ssh-port", "p", ssh_config.Default("Port"), "Ssh port on the kafka brokers")
 	ListTopicCmd.PersistentFlags().StringP("ssh-key-file-path", "k", "~/.ssh/id_rsa", "Path to ssh key file")
 	ListTopicCmd.PersistentFlags().Int64P("size", "s", -1,
@@ -65,9 +71,16 @@ func (l *listTopic) listTopic() {
 	}
 	if len(topics) == 0 {
 		logger.Info("No topics found")
-		return
 	}
-	printTopics(topics)
+	sort.Strings(topics)
+	rows := make([]ui.Row, 0, len(topics))
+	for _, topic := range topics {
+		detail := l.topicDetails[topic]
+		rows = append(rows, ui.Topic(topic, detail.NumPartitions, detail.ReplicationFactor))
+	}
+	if err = l.renderer.Render(rows); err != nil {
+		logger.Fatalf("Error while writing the topics - %v\n", err)
+	}
 }
 
 func (l *listTopic) getTopicsFilteredByFlags() ([]string, error) {
@@ -101,6 +114,8 @@ func getListFromChannel(channel chan string) []string {
 func (l *listTopic) listAllTopics(ctx context.Context, cancelFunc context.CancelFunc, topicsChannel chan string, errorChannel chan error, wg *sync.WaitGroup) {
 	defer close(topicsChannel)
 	topicDetails, err := l.List()
+	// the details are read once all the listings are done
+	l.topicDetails = topicDetails
 	wg.Done()
 	select {
 	case <-ctx.Done():
@@ -199,11 +214,3 @@ func findCommonElement(ctx context.Context, topicsChannel, inputChannel chan str
 		}
 	}
 }
-
-func printTopics(topics []string) {
-	fmt.Println("------------------------------------------------------------")
-	for _, topic := range topics {
-		fmt.Println(topic)
-	}
-	fmt.Println("------------------------------------------------------------")
-}
@@ -1,11 +1,13 @@
 package list
 
 import (
+	"bytes"
 	"errors"
 	"os"
 	"testing"
 
 	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/ui"
 	"go.uber.org/goleak"
 
 	"bou.ke/monkey"
@@ -22,14 +24,47 @@ func TestListNoFlags(t *testing.T) {
 	defer goleak.VerifyNone(t)
 	mockLister := &client.MockLister{}
 	mockLister.On("List").Return(map[string]client.TopicDetail{"topic-1": {}}, nil).Times(2)
-	l := listTopic{Lister: mockLister, size: -1}
+	var out bytes.Buffer
+	renderer, _ := ui.NewRenderer(ui.OutputTable, &out)
+	l := listTopic{Lister: mockLister, renderer: renderer, size: -1}
 	topics, err := l.getTopicsFilteredByFlags()
 	assert.ElementsMatch(t, topics, []string{"topic-1"})
 	assert.Nil(t, err)
 	l.listTopic()
+	assert.Contains(t, out.String(), "topic-1")
 	mockLister.AssertExpectations(t)
 }
 
+func TestList_RendersTheTopicsSortedWithTheirDetails(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	mockLister := &client.MockLister{}
+	mockLister.On("List").Return(map[string]client.TopicDetail{
+		"topic-2": {NumPartitions: 3, ReplicationFactor: 2},
+		"topic-1": {NumPartitions: 1, ReplicationFactor: 1},
+	}, nil)
+	var out bytes.Buffer
+	renderer, _ := ui.NewRenderer(ui.OutputJSON, &out)
+	l := listTopic{Lister: mockLister, renderer: renderer, size: -1}
+
+	l.listTopic()
+
+	assert.JSONEq(t, `[{"topic": "topic-1", "partitions": 1, "replicationFactor": 1},
+		{"topic": "topic-2", "partitions": 3, "replicationFactor": 2}]`, out.String())
+}
+
+func TestList_RendersAnEmptyListWhenNoTopicsAreFound(t *testing.T) {
+	defer goleak.VerifyNone(t)
+	mockLister := &client.MockLister{}
+	mockLister.On("List").Return(map[string]client.TopicDetail{}, nil)
+	var out bytes.Buffer
+	renderer, _ := ui.NewRenderer(ui.OutputJSON, &out)
+	l := listTopic{Lister: mockLister, renderer: renderer, size: -1}
+
+	l.listTopic()
+
+	assert.JSONEq(t, `[]`, out.String())
+}
+
 func TestList_Success(t *testing.T) {
 	defer goleak.VerifyNone(t)
 	mockLister := &client.MockLister{}
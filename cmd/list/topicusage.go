@@ -0,0 +1,88 @@
+package list
+
+import (
+	"context"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	kio "github.com/gojek/kat/pkg/io"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/gojek/kat/ui"
+	"github.com/spf13/cobra"
+)
+
+type usageReport interface {
+	Usage(ctx context.Context, topicRegex string, since time.Time) ([]model.TopicUsage, error)
+}
+
+type topicUsage struct {
+	report     usageReport
+	renderer   *ui.Renderer
+	topicRegex string
+	since      time.Duration
+	usages     []string
+}
+
+var TopicUsageCmd = &cobra.Command{
+	Use:   "usage",
+	Short: "Classify the topics as active, write-only, read-only or unused",
+	Run: func(command *cobra.Command, args []string) {
+		cobraUtil := base.NewCobraUtil(command)
+		saramaClient := client.NewSaramaClient(strings.Split(cobraUtil.GetStringArg("broker-list"), ","))
+		u := topicUsage{
+			report:     model.NewUsageReport(base.Init(cobraUtil).GetTopic(), saramaClient, saramaClient, cobraUtil.GetIntArg("concurrency")),
+			renderer:   cobraUtil.GetRenderer(),
+			topicRegex: cobraUtil.GetStringArg("topic-regex"),
+			since:      cobraUtil.GetDurationArg("since"),
+			usages:     cobraUtil.GetStringSliceArg("usage"),
+		}
+		u.topicUsage()
+	},
+}
+
+func init() {
+	TopicUsageCmd.PersistentFlags().StringP("topic-regex", "t", ".*", "Regex of the topics to classify")
+	TopicUsageCmd.PersistentFlags().Duration("since", 7*24*time.Hour, "Topics with a message written within this duration are written to")
+	TopicUsageCmd.PersistentFlags().StringSlice("usage", []string{},
+		"Comma separated list of the usages to report, of active|write-only|read-only|unused, defaults to all")
+	TopicUsageCmd.PersistentFlags().Int("concurrency", 8, "Number of partitions read in parallel")
+}
+
+func (u *topicUsage) topicUsage() {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	sigTermHandler := kio.SignalHandler{}
+	sigTermHandler.SetListener(ctx, cancelFunc, syscall.SIGTERM)
+	sigTermHandler.SetListener(ctx, cancelFunc, syscall.SIGINT)
+	defer sigTermHandler.Close()
+	defer cancelFunc()
+
+	usages, err := u.report.Usage(ctx, u.topicRegex, time.Now().Add(-u.since))
+	if err != nil {
+		logger.Fatalf("Error while finding the usage of the topics - %v\n", err)
+	}
+	var rows []ui.Row
+	for _, usage := range filterUsages(usages, u.usages) {
+		rows = append(rows, ui.TopicUsage(usage.Topic, usage.Usage, usage.Partitions, usage.ReplicationFactor, usage.SizeBytes,
+			usage.Messages, usage.LastWrite, usage.ConsumerGroups))
+	}
+	if err = u.renderer.Render(rows); err != nil {
+		logger.Fatalf("Error while printing the usage of the topics - %v\n", err)
+	}
+}
+
+func filterUsages(usages []model.TopicUsage, include []string) []model.TopicUsage {
+	if len(include) == 0 {
+		return usages
+	}
+	filtered := make([]model.TopicUsage, 0, len(usages))
+	for _, usage := range usages {
+		if (model.ListUtil{List: include}).Contains(usage.Usage) {
+			filtered = append(filtered, usage)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,76 @@
+package list
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"bou.ke/monkey"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/gojek/kat/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockUsageReport struct{ mock.Mock }
+
+func (m *mockUsageReport) Usage(ctx context.Context, topicRegex string, since time.Time) ([]model.TopicUsage, error) {
+	args := m.Called(ctx, topicRegex, since)
+	return args.Get(0).([]model.TopicUsage), args.Error(1)
+}
+
+func topicUsages() []model.TopicUsage {
+	lastWrite := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	return []model.TopicUsage{
+		{Topic: "orders", Partitions: 3, ReplicationFactor: 2, SizeBytes: 100, Messages: 10, LastWrite: &lastWrite,
+			ConsumerGroups: []string{"group-1", "group-2"}, Usage: model.UsageActive},
+		{Topic: "payments", Partitions: 1, ReplicationFactor: 1, ConsumerGroups: []string{}, Usage: model.UsageUnused},
+	}
+}
+
+func TestTopicUsage_WritesCSV(t *testing.T) {
+	report := &mockUsageReport{}
+	report.On("Usage", mock.Anything, ".*", mock.AnythingOfType("time.Time")).Return(topicUsages(), nil)
+	out := &bytes.Buffer{}
+	renderer, _ := ui.NewRenderer(ui.OutputCSV, out)
+	u := topicUsage{report: report, renderer: renderer, topicRegex: ".*", since: time.Hour}
+
+	u.topicUsage()
+
+	assert.Equal(t, "Topic,Usage,Partitions,Replication Factor,Size Bytes,Messages,Last Write,Consumer Groups\n"+
+		"orders,active,3,2,100,10,2021-03-04T05:06:07Z,\"group-1,group-2\"\n"+
+		"payments,unused,1,1,0,0,,\n", out.String())
+	report.AssertExpectations(t)
+}
+
+func TestTopicUsage_WritesJSONOfTheGivenUsages(t *testing.T) {
+	report := &mockUsageReport{}
+	report.On("Usage", mock.Anything, ".*", mock.AnythingOfType("time.Time")).Return(topicUsages(), nil)
+	out := &bytes.Buffer{}
+	renderer, _ := ui.NewRenderer(ui.OutputJSON, out)
+	u := topicUsage{report: report, renderer: renderer, topicRegex: ".*", since: time.Hour, usages: []string{model.UsageUnused}}
+
+	u.topicUsage()
+
+	assert.JSONEq(t, `[{"topic": "payments", "partitions": 1, "replicationFactor": 1, "sizeBytes": 0, "messages": 0,
+		"lastWrite": null, "consumerGroups": [], "usage": "unused"}]`, out.String())
+	report.AssertExpectations(t)
+}
+
+func TestTopicUsage_Failure(t *testing.T) {
+	report := &mockUsageReport{}
+	report.On("Usage", mock.Anything, ".*", mock.AnythingOfType("time.Time")).Return([]model.TopicUsage{}, errors.New("error"))
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	renderer, _ := ui.NewRenderer(ui.OutputTable, &bytes.Buffer{})
+	u := topicUsage{report: report, renderer: renderer, topicRegex: ".*", since: time.Hour}
+
+	assert.PanicsWithValue(t, "os.Exit called", u.topicUsage, "os.Exit was not called")
+	report.AssertExpectations(t)
+}
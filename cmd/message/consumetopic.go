@@ -0,0 +1,83 @@
+package message
+
+import (
+	"context"
+	"strings"
+	"syscall"
+
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/pkg/io"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+type topicConsumer interface {
+	Consume(ctx context.Context, opts model.ConsumeOptions, handler func(*client.Message) error) (int, error)
+}
+
+type messagePrinter interface {
+	Print(msg *client.Message) error
+}
+
+type consumeTopic struct {
+	consumer topicConsumer
+	printer  messagePrinter
+	opts     model.ConsumeOptions
+}
+
+var ConsumeTopicCmd = &cobra.Command{
+	Use:   "consume",
+	Short: "Print the messages of a topic",
+	Run: func(command *cobra.Command, args []string) {
+		cobraUtil := base.NewCobraUtil(command)
+		from, err := model.ParseOffsetSpec(cobraUtil.GetStringArg("from"))
+		if err != nil {
+			logger.Fatalf("Error while parsing --from - %v\n", err)
+		}
+		reader := client.NewSaramaClient(strings.Split(cobraUtil.GetStringArg("broker-list"), ","))
+		c := consumeTopic{
+			consumer: model.NewTopicConsumer(reader),
+			printer:  newMessagePrinter(cobraUtil, newSchemaDecoder(cobraUtil)),
+			opts: model.ConsumeOptions{
+				Topic:       cobraUtil.GetStringArg("topic"),
+				Partitions:  cobraUtil.GetInt32SliceArg("partitions"),
+				From:        from,
+				MaxMessages: cobraUtil.GetIntArg("max-messages"),
+				Follow:      cobraUtil.GetBoolArg("follow"),
+			},
+		}
+		c.consumeTopic()
+	},
+}
+
+func init() {
+	ConsumeTopicCmd.PersistentFlags().StringP("topic", "t", "", "Topic to print the messages of")
+	ConsumeTopicCmd.PersistentFlags().IntSlice("partitions", []int{}, "Comma separated list of partitions to read, defaults to all the partitions")
+	ConsumeTopicCmd.PersistentFlags().String("from", "earliest",
+		"Offset or time to read the messages from, one of earliest|latest, an offset, a RFC3339 time or a duration like -1h")
+	ConsumeTopicCmd.PersistentFlags().IntP("max-messages", "n", 0, "Number of messages to print before exiting, 0 for no limit")
+	ConsumeTopicCmd.PersistentFlags().BoolP("follow", "f", false, "Keep printing the new messages, instead of exiting at the end of the partitions")
+	addPrintFlags(ConsumeTopicCmd, false)
+	if err := ConsumeTopicCmd.MarkPersistentFlagRequired("topic"); err != nil {
+		logger.Fatal(err)
+	}
+}
+
+// consumeTopic prints the messages until the end of the partitions or the max messages is reached, or the process is interrupted
+func (c *consumeTopic) consumeTopic() {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	sigTermHandler := io.SignalHandler{}
+	sigTermHandler.SetListener(ctx, cancelFunc, syscall.SIGTERM)
+	sigTermHandler.SetListener(ctx, cancelFunc, syscall.SIGINT)
+	defer sigTermHandler.Close()
+	defer cancelFunc()
+
+	count, err := c.consumer.Consume(ctx, c.opts, c.printer.Print)
+	if err != nil {
+		logger.Fatalf("Error while consuming topic %v - %v\n", c.opts.Topic, err)
+	}
+	// the summary is not printed at the info level, to keep the output usable in pipes
+	logger.Debugf("Consumed %d messages\n", count)
+}
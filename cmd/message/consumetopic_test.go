@@ -0,0 +1,69 @@
+package message
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockTopicConsumer struct {
+	mock.Mock
+}
+
+func (m *mockTopicConsumer) Consume(ctx context.Context, opts model.ConsumeOptions, handler func(*client.Message) error) (int, error) {
+	args := m.Called(opts)
+	for _, msg := range args.Get(0).([]*client.Message) {
+		if err := handler(msg); err != nil {
+			return 0, err
+		}
+	}
+	return len(args.Get(0).([]*client.Message)), args.Error(1)
+}
+
+type mockMessagePrinter struct {
+	mock.Mock
+}
+
+func (m *mockMessagePrinter) Print(msg *client.Message) error {
+	args := m.Called(msg)
+	return args.Error(0)
+}
+
+func TestConsumeTopic_PrintsMessages(t *testing.T) {
+	consumer := &mockTopicConsumer{}
+	printer := &mockMessagePrinter{}
+	opts := model.ConsumeOptions{Topic: "topic", From: model.OffsetSpecOldest, MaxMessages: 2}
+	messages := []*client.Message{{Offset: 1}, {Offset: 2}}
+	consumer.On("Consume", opts).Return(messages, nil)
+	printer.On("Print", messages[0]).Return(nil)
+	printer.On("Print", messages[1]).Return(nil)
+	c := consumeTopic{consumer: consumer, printer: printer, opts: opts}
+
+	c.consumeTopic()
+
+	consumer.AssertExpectations(t)
+	printer.AssertExpectations(t)
+}
+
+func TestConsumeTopic_Failure(t *testing.T) {
+	consumer := &mockTopicConsumer{}
+	opts := model.ConsumeOptions{Topic: "topic", From: model.OffsetSpecOldest}
+	consumer.On("Consume", opts).Return([]*client.Message{}, errors.New("error"))
+	c := consumeTopic{consumer: consumer, printer: &mockMessagePrinter{}, opts: opts}
+
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+
+	assert.PanicsWithValue(t, "os.Exit called", c.consumeTopic, "os.Exit was not called")
+	consumer.AssertExpectations(t)
+}
@@ -0,0 +1,123 @@
+package message
+
+import (
+	"context"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/pkg/io"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+// partitionerKeep writes the messages to the same partition as in the source topic
+const partitionerKeep = "keep"
+
+type topicCopier interface {
+	Copy(ctx context.Context, opts model.CopyOptions) (int64, error)
+}
+
+type copyTopic struct {
+	copier topicCopier
+	opts   model.CopyOptions
+}
+
+var CopyTopicCmd = &cobra.Command{
+	Use:   "copy",
+	Short: "Copy the messages of a topic to another topic or cluster",
+	Run: func(command *cobra.Command, args []string) {
+		cobraUtil := base.NewCobraUtil(command)
+		opts, destinationAddr, partitioner := copyOptions(cobraUtil)
+
+		reader := client.NewSaramaClient(strings.Split(cobraUtil.GetStringArg("broker-list"), ","))
+		producerConfig := client.ProducerConfig{Partitioner: partitioner}
+		if partitioner == partitionerKeep {
+			producerConfig.Partitioner = client.PartitionerManual
+		}
+		writer, err := client.NewSaramaProducer(strings.Split(destinationAddr, ","), producerConfig)
+		if err != nil {
+			logger.Fatalf("Error while creating the producer - %v\n", err)
+		}
+		defer func() { _ = writer.Close() }()
+
+		c := copyTopic{copier: model.NewTopicCopy(reader, writer), opts: opts}
+		c.copyTopic()
+	},
+}
+
+func init() {
+	CopyTopicCmd.PersistentFlags().StringP("topic", "t", "", "Topic to copy the messages from")
+	CopyTopicCmd.PersistentFlags().String("destination-broker-list", "",
+		"Comma separated list of broker ips to copy the messages to, defaults to the source cluster")
+	CopyTopicCmd.PersistentFlags().String("destination-topic", "", "Topic to copy the messages to, defaults to the source topic")
+	CopyTopicCmd.PersistentFlags().String("from", "earliest",
+		"Offset or time to copy the messages from, one of earliest|latest, an offset, a RFC3339 time or a duration like -1h")
+	CopyTopicCmd.PersistentFlags().String("to", "latest", "Offset or time to copy the messages until, excluding the message at it")
+	CopyTopicCmd.PersistentFlags().String("partitioner", partitionerKeep,
+		"Partitioner of the destination topic, one of keep|hash|random|roundrobin. keep writes to the same partition as in the source topic")
+	CopyTopicCmd.PersistentFlags().Int("rate", 0, "Maximum number of messages copied per second, 0 for no limit")
+	CopyTopicCmd.PersistentFlags().String("checkpoint-file", "", "File to save the progress in, the copy is resumed from it when present")
+	CopyTopicCmd.PersistentFlags().Duration("progress-interval", 10*time.Second, "Interval between the progress logs, 0 to disable")
+	if err := CopyTopicCmd.MarkPersistentFlagRequired("topic"); err != nil {
+		logger.Fatal(err)
+	}
+}
+
+// copyOptions validates the flags, and returns the copy options with the destination broker list and the partitioner
+func copyOptions(cobraUtil *base.CobraUtil) (model.CopyOptions, string, string) {
+	opts := model.CopyOptions{
+		SourceTopic:      cobraUtil.GetStringArg("topic"),
+		DestinationTopic: cobraUtil.GetStringArg("destination-topic"),
+		Rate:             cobraUtil.GetIntArg("rate"),
+		CheckpointFile:   cobraUtil.GetStringArg("checkpoint-file"),
+		ProgressInterval: cobraUtil.GetDurationArg("progress-interval"),
+	}
+	if opts.DestinationTopic == "" {
+		opts.DestinationTopic = opts.SourceTopic
+	}
+	destinationAddr := cobraUtil.GetStringArg("destination-broker-list")
+	if destinationAddr == "" {
+		destinationAddr = cobraUtil.GetStringArg("broker-list")
+	}
+	if destinationAddr == cobraUtil.GetStringArg("broker-list") && opts.DestinationTopic == opts.SourceTopic {
+		logger.Fatalf("Destination topic should be different from the source topic when copying within a cluster\n")
+	}
+
+	var err error
+	if opts.From, err = model.ParseOffsetSpec(cobraUtil.GetStringArg("from")); err != nil {
+		logger.Fatalf("Error while parsing --from - %v\n", err)
+	}
+	if opts.To, err = model.ParseOffsetSpec(cobraUtil.GetStringArg("to")); err != nil {
+		logger.Fatalf("Error while parsing --to - %v\n", err)
+	}
+	partitioner := cobraUtil.GetStringArg("partitioner")
+	opts.KeepPartitions = partitioner == partitionerKeep
+	return opts, destinationAddr, partitioner
+}
+
+// copyTopic copies the messages until the end offsets are reached, or the process is interrupted
+func (c *copyTopic) copyTopic() {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	sigTermHandler := io.SignalHandler{}
+	sigTermHandler.SetListener(ctx, cancelFunc, syscall.SIGTERM)
+	sigTermHandler.SetListener(ctx, cancelFunc, syscall.SIGINT)
+	defer sigTermHandler.Close()
+	defer cancelFunc()
+
+	copied, err := c.copier.Copy(ctx, c.opts)
+	if err != nil {
+		if ctx.Err() != nil {
+			logger.Infof("Copy was interrupted after copying %d messages\n", copied)
+			if c.opts.CheckpointFile != "" {
+				logger.Infof("Run the command again with the checkpoint file %v to resume the copy\n", c.opts.CheckpointFile)
+			}
+			return
+		}
+		logger.Fatalf("Error while copying topic %v after copying %d messages - %v\n", c.opts.SourceTopic, copied, err)
+	}
+	logger.Infof("Copied %d messages from topic %v to topic %v\n", copied, c.opts.SourceTopic, c.opts.DestinationTopic)
+}
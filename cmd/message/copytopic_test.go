@@ -0,0 +1,54 @@
+package message
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func init() {
+	logger.SetDummyLogger()
+}
+
+type mockTopicCopier struct {
+	mock.Mock
+}
+
+func (m *mockTopicCopier) Copy(ctx context.Context, opts model.CopyOptions) (int64, error) {
+	args := m.Called(opts)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func TestCopyTopic_Success(t *testing.T) {
+	copier := &mockTopicCopier{}
+	opts := model.CopyOptions{SourceTopic: "source", DestinationTopic: "destination", From: model.OffsetSpecOldest, To: model.OffsetSpecNewest}
+	copier.On("Copy", opts).Return(int64(10), nil)
+	c := copyTopic{copier: copier, opts: opts}
+
+	c.copyTopic()
+
+	copier.AssertExpectations(t)
+}
+
+func TestCopyTopic_Failure(t *testing.T) {
+	copier := &mockTopicCopier{}
+	opts := model.CopyOptions{SourceTopic: "source", DestinationTopic: "destination"}
+	copier.On("Copy", opts).Return(int64(2), errors.New("error"))
+	c := copyTopic{copier: copier, opts: opts}
+
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+
+	assert.PanicsWithValue(t, "os.Exit called", c.copyTopic, "os.Exit was not called")
+	copier.AssertExpectations(t)
+}
@@ -0,0 +1,94 @@
+package message
+
+import (
+	"strings"
+
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/gojek/kat/ui"
+	"github.com/spf13/cobra"
+)
+
+// grepTopic prints the messages matching the search criteria, the empty criteria are ignored
+type grepTopic struct {
+	consumeTopic
+	key         string
+	header      string
+	valueRegex  string
+	jsonPath    string
+	decodeValue func([]byte) ([]byte, error)
+}
+
+var GrepTopicCmd = &cobra.Command{
+	Use:   "grep",
+	Short: "Search the messages of a topic by key, header, value regex or json path",
+	Run: func(command *cobra.Command, args []string) {
+		cobraUtil := base.NewCobraUtil(command)
+		from, err := model.ParseOffsetSpec(cobraUtil.GetStringArg("from"))
+		if err != nil {
+			logger.Fatalf("Error while parsing --from - %v\n", err)
+		}
+		to, err := model.ParseOffsetSpec(cobraUtil.GetStringArg("to"))
+		if err != nil {
+			logger.Fatalf("Error while parsing --to - %v\n", err)
+		}
+
+		schemaDecoder := newSchemaDecoder(cobraUtil)
+		reader := client.NewSaramaClient(strings.Split(cobraUtil.GetStringArg("broker-list"), ","))
+		g := grepTopic{
+			consumeTopic: consumeTopic{
+				consumer: model.NewTopicConsumer(reader),
+				printer:  newMessagePrinter(cobraUtil, schemaDecoder),
+				opts: model.ConsumeOptions{
+					Topic:       cobraUtil.GetStringArg("topic"),
+					Partitions:  cobraUtil.GetInt32SliceArg("partitions"),
+					From:        from,
+					To:          &to,
+					MaxMessages: cobraUtil.GetIntArg("max-matches"),
+				},
+			},
+			key:        cobraUtil.GetStringArg("key"),
+			header:     cobraUtil.GetStringArg("header"),
+			valueRegex: cobraUtil.GetStringArg("value-regex"),
+			jsonPath:   cobraUtil.GetStringArg("jsonpath"),
+		}
+		if cobraUtil.GetStringArg("value-decoder") == ui.DecoderSchemaRegistry {
+			g.decodeValue = schemaDecoder
+		}
+		g.grepTopic()
+	},
+}
+
+func init() {
+	GrepTopicCmd.PersistentFlags().StringP("topic", "t", "", "Topic to search the messages of")
+	GrepTopicCmd.PersistentFlags().IntSlice("partitions", []int{}, "Comma separated list of partitions to search, defaults to all the partitions")
+	GrepTopicCmd.PersistentFlags().String("from", "earliest",
+		"Offset or time to search the messages from, one of earliest|latest, an offset, a RFC3339 time or a duration like -1h")
+	GrepTopicCmd.PersistentFlags().String("to", "latest", "Offset or time to search the messages until, excluding the message at it")
+	GrepTopicCmd.PersistentFlags().String("key", "", "Exact key of the messages")
+	GrepTopicCmd.PersistentFlags().String("header", "", "Header of the messages as name=value")
+	GrepTopicCmd.PersistentFlags().String("value-regex", "", "Regex matching the message values, decoded to json with the schema-registry value decoder")
+	GrepTopicCmd.PersistentFlags().String("jsonpath", "",
+		"JSON path the message values should have, optionally with the expected value, eg: '$.order.id' or '$.items[*].sku==\"A1\"'. "+
+			"The values are decoded to json with the schema-registry value decoder")
+	GrepTopicCmd.PersistentFlags().IntP("max-matches", "n", 0, "Number of matching messages to print before exiting, 0 for no limit")
+	addPrintFlags(GrepTopicCmd, true)
+	if err := GrepTopicCmd.MarkPersistentFlagRequired("topic"); err != nil {
+		logger.Fatal(err)
+	}
+}
+
+func (g *grepTopic) grepTopic() {
+	filter, err := model.NewMessageFilter(g.key, g.header, g.valueRegex, g.jsonPath)
+	if err != nil {
+		logger.Fatalf("Error while parsing the search criteria - %v\n", err)
+	}
+	if filter.Key == nil && filter.Header == nil && filter.Value == nil && filter.JSONPath == nil {
+		logger.Fatalf("At least one of --key, --header, --value-regex or --jsonpath should be passed\n")
+	}
+	filter.DecodeValue = g.decodeValue
+	g.opts.Filter = filter.Match
+	g.consumeTopic.consumeTopic()
+}
@@ -0,0 +1,102 @@
+package message
+
+import (
+	"os"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func grepMessages() []*client.Message {
+	return []*client.Message{
+		{Topic: "topic", Offset: 0, Key: []byte("order-1"), Value: []byte(`{"status": "created"}`)},
+		{Topic: "topic", Offset: 1, Key: []byte("order-2"), Value: []byte(`{"status": "paid"}`)},
+		{Topic: "topic", Offset: 2, Key: []byte("order-3"), Value: []byte(`{"status": "paid"}`)},
+	}
+}
+
+func grepReader() *client.MockMessageReader {
+	reader := &client.MockMessageReader{}
+	reader.On("Partitions", "topic").Return([]int32{0}, nil)
+	reader.On("GetOffset", "topic", int32(0), int64(client.OffsetOldest)).Return(int64(0), nil)
+	reader.On("GetOffset", "topic", int32(0), int64(client.OffsetNewest)).Return(int64(3), nil)
+	reader.On("ReadMessages", "topic", int32(0), int64(0), int64(3)).Return(grepMessages(), nil)
+	return reader
+}
+
+func newGrepTopic(reader client.MessageReader, printer messagePrinter, maxMatches int) grepTopic {
+	to := model.OffsetSpecNewest
+	return grepTopic{consumeTopic: consumeTopic{
+		consumer: model.NewTopicConsumer(reader),
+		printer:  printer,
+		opts:     model.ConsumeOptions{Topic: "topic", From: model.OffsetSpecOldest, To: &to, MaxMessages: maxMatches},
+	}}
+}
+
+func TestGrepTopic_PrintsMatchingMessages(t *testing.T) {
+	printer := &mockMessagePrinter{}
+	messages := grepMessages()
+	printer.On("Print", messages[1]).Return(nil)
+	printer.On("Print", messages[2]).Return(nil)
+	g := newGrepTopic(grepReader(), printer, 0)
+	g.valueRegex = `"status": "paid"`
+
+	g.grepTopic()
+
+	printer.AssertExpectations(t)
+	printer.AssertNotCalled(t, "Print", messages[0])
+}
+
+func TestGrepTopic_PrintsNothingWithoutMatches(t *testing.T) {
+	printer := &mockMessagePrinter{}
+	g := newGrepTopic(grepReader(), printer, 0)
+	g.key = "order-4"
+
+	g.grepTopic()
+
+	printer.AssertNotCalled(t, "Print", mock.Anything)
+}
+
+func TestGrepTopic_StopsAtMaxMatches(t *testing.T) {
+	printer := &mockMessagePrinter{}
+	messages := grepMessages()
+	printer.On("Print", messages[1]).Return(nil)
+	g := newGrepTopic(grepReader(), printer, 1)
+	g.jsonPath = `$.status=="paid"`
+
+	g.grepTopic()
+
+	printer.AssertExpectations(t)
+	printer.AssertNotCalled(t, "Print", messages[2])
+}
+
+func TestGrepTopic_ExitsForInvalidRegex(t *testing.T) {
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	reader := &client.MockMessageReader{}
+	g := newGrepTopic(reader, &mockMessagePrinter{}, 0)
+	g.valueRegex = "status[("
+
+	assert.PanicsWithValue(t, "os.Exit called", g.grepTopic, "os.Exit was not called")
+	reader.AssertNotCalled(t, "Partitions", mock.Anything)
+}
+
+func TestGrepTopic_ExitsWithoutCriteria(t *testing.T) {
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	reader := &client.MockMessageReader{}
+	g := newGrepTopic(reader, &mockMessagePrinter{}, 0)
+
+	assert.PanicsWithValue(t, "os.Exit called", g.grepTopic, "os.Exit was not called")
+	reader.AssertNotCalled(t, "Partitions", mock.Anything)
+}
@@ -0,0 +1,50 @@
+package message
+
+import (
+	"os"
+
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/gojek/kat/ui"
+	"github.com/spf13/cobra"
+)
+
+// addPrintFlags adds the flags of the message printer, printDetails is the default of printing the key, headers and metadata
+func addPrintFlags(command *cobra.Command, printDetails bool) {
+	command.PersistentFlags().String("key-decoder", ui.DecoderString, "Decoder of the message keys, one of string|hex|base64|json|schema-registry")
+	command.PersistentFlags().String("value-decoder", ui.DecoderString, "Decoder of the message values, one of string|hex|base64|json|schema-registry")
+	command.PersistentFlags().String("format", ui.MessageFormatText, "Output format, one of text|jsonl")
+	command.PersistentFlags().String("template", "",
+		"Go template to print each message with, eg: '{{.Partition}}:{{.Offset}} {{.Key}}={{.Value}}'. Overrides the format")
+	command.PersistentFlags().BoolP("print-key", "k", printDetails, "Print the message keys in the text format")
+	command.PersistentFlags().Bool("print-headers", printDetails, "Print the message headers in the text format")
+	command.PersistentFlags().Bool("print-metadata", printDetails, "Print the partition, offset and timestamp of the messages in the text format")
+	base.AddSchemaRegistryFlags(command)
+}
+
+// newSchemaDecoder returns the decoder of the values in the schema registry wire format, or nil when the registry url is not passed
+func newSchemaDecoder(cobraUtil *base.CobraUtil) func([]byte) ([]byte, error) {
+	registry := cobraUtil.GetSchemaRegistry()
+	if registry == nil {
+		return nil
+	}
+	return model.NewSchemaDecoder(registry).Decode
+}
+
+func newMessagePrinter(cobraUtil *base.CobraUtil, schemaDecoder func([]byte) ([]byte, error)) *ui.MessagePrinter {
+	printer, err := ui.NewMessagePrinter(os.Stdout, ui.PrintOptions{
+		KeyDecoder:    cobraUtil.GetStringArg("key-decoder"),
+		ValueDecoder:  cobraUtil.GetStringArg("value-decoder"),
+		Format:        cobraUtil.GetStringArg("format"),
+		Template:      cobraUtil.GetStringArg("template"),
+		PrintKey:      cobraUtil.GetBoolArg("print-key"),
+		PrintHeaders:  cobraUtil.GetBoolArg("print-headers"),
+		PrintMetadata: cobraUtil.GetBoolArg("print-metadata"),
+		SchemaDecoder: schemaDecoder,
+	})
+	if err != nil {
+		logger.Fatalf("Error while creating the message printer - %v\n", err)
+	}
+	return printer
+}
@@ -0,0 +1,122 @@
+package message
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/pkg/io"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+type topicProducer interface {
+	Produce(ctx context.Context, source model.MessageSource, opts model.ProduceOptions) (model.ProduceSummary, error)
+}
+
+type produceTopic struct {
+	producer topicProducer
+	input    model.MessageSource
+	opts     model.ProduceOptions
+}
+
+var ProduceTopicCmd = &cobra.Command{
+	Use:   "produce",
+	Short: "Produce messages to a topic from a file or stdin",
+	Run: func(command *cobra.Command, args []string) {
+		cobraUtil := base.NewCobraUtil(command)
+		headers, err := parseHeaders(cobraUtil.GetStringSliceArg("header"))
+		if err != nil {
+			logger.Fatalf("Error while parsing the headers - %v\n", err)
+		}
+		in := os.Stdin
+		if file := cobraUtil.GetStringArg("file"); file != "" && file != "-" {
+			if in, err = os.Open(file); err != nil {
+				logger.Fatalf("Error while opening the input file - %v\n", err)
+			}
+			defer func() { _ = in.Close() }()
+		}
+		input, err := model.NewMessageInput(in, cobraUtil.GetStringArg("format"), cobraUtil.GetStringArg("delimiter"))
+		if err != nil {
+			logger.Fatalf("Error while reading the input - %v\n", err)
+		}
+
+		partition := int32(cobraUtil.GetIntArg("partition"))
+		producerConfig := client.ProducerConfig{
+			Partitioner: cobraUtil.GetStringArg("partitioner"),
+			Compression: cobraUtil.GetStringArg("compression"),
+			Acks:        cobraUtil.GetStringArg("acks"),
+			Idempotent:  cobraUtil.GetBoolArg("idempotent"),
+		}
+		if partition >= 0 {
+			producerConfig.Partitioner = client.PartitionerManual
+		}
+		writer, err := client.NewSaramaProducer(strings.Split(cobraUtil.GetStringArg("broker-list"), ","), producerConfig)
+		if err != nil {
+			logger.Fatalf("Error while creating the producer - %v\n", err)
+		}
+		defer func() { _ = writer.Close() }()
+
+		p := produceTopic{
+			producer: model.NewTopicProducer(writer),
+			input:    input,
+			opts:     model.ProduceOptions{Topic: cobraUtil.GetStringArg("topic"), Partition: partition, Headers: headers},
+		}
+		p.produceTopic()
+	},
+}
+
+func init() {
+	ProduceTopicCmd.PersistentFlags().StringP("topic", "t", "", "Topic to produce the messages to")
+	ProduceTopicCmd.PersistentFlags().String("file", "-", "File to read the messages from, - for stdin")
+	ProduceTopicCmd.PersistentFlags().String("format", model.InputFormatJSONLines, "Format of the input, one of jsonl|csv|delimited")
+	ProduceTopicCmd.PersistentFlags().String("delimiter", "\t", "Delimiter between the key and the value in the delimited format")
+	ProduceTopicCmd.PersistentFlags().Int("partition", -1, "Partition to produce all the messages to, instead of the partition chosen by the partitioner")
+	ProduceTopicCmd.PersistentFlags().String("partitioner", client.PartitionerHash,
+		"Partitioner of the messages, one of hash|random|roundrobin|manual. manual uses the partition of the messages in the input")
+	ProduceTopicCmd.PersistentFlags().StringSlice("header", []string{}, "Header added to all the messages as key=value, can be repeated")
+	ProduceTopicCmd.PersistentFlags().String("compression", client.CompressionNone, "Compression codec, one of none|gzip|snappy|lz4|zstd")
+	ProduceTopicCmd.PersistentFlags().String("acks", client.AcksAll, "Acknowledgements to wait for, one of all|leader|none")
+	ProduceTopicCmd.PersistentFlags().Bool("idempotent", false, "Use an idempotent producer, so that retries do not write duplicates")
+	if err := ProduceTopicCmd.MarkPersistentFlagRequired("topic"); err != nil {
+		logger.Fatal(err)
+	}
+}
+
+func parseHeaders(headers []string) ([]client.MessageHeader, error) {
+	result := make([]client.MessageHeader, 0, len(headers))
+	for _, header := range headers {
+		keyValue := strings.SplitN(header, "=", 2)
+		if len(keyValue) != 2 || keyValue[0] == "" {
+			return nil, fmt.Errorf("invalid header %v, expected key=value", header)
+		}
+		result = append(result, client.MessageHeader{Key: []byte(keyValue[0]), Value: []byte(keyValue[1])})
+	}
+	return result, nil
+}
+
+// produceTopic produces the messages until the end of the input, or the process is interrupted, and prints a summary
+func (p *produceTopic) produceTopic() {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	sigTermHandler := io.SignalHandler{}
+	sigTermHandler.SetListener(ctx, cancelFunc, syscall.SIGTERM)
+	sigTermHandler.SetListener(ctx, cancelFunc, syscall.SIGINT)
+	defer sigTermHandler.Close()
+	defer cancelFunc()
+
+	summary, err := p.producer.Produce(ctx, p.input, p.opts)
+	logger.Infof("Produced %d messages (%d bytes) to topic %v in %v, %.0f messages/s, %d failed\n", summary.Produced, summary.Bytes,
+		p.opts.Topic, summary.Duration.Round(time.Millisecond), summary.MessagesPerSecond(), summary.Failed)
+	if err != nil {
+		logger.Fatalf("Error while reading the input - %v\n", err)
+	}
+	if summary.Failed > 0 {
+		logger.Fatalf("Error while producing - %d messages were not produced\n", summary.Failed)
+	}
+}
@@ -0,0 +1,70 @@
+package message
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockTopicProducer struct {
+	mock.Mock
+}
+
+func (m *mockTopicProducer) Produce(ctx context.Context, source model.MessageSource, opts model.ProduceOptions) (model.ProduceSummary, error) {
+	args := m.Called(source, opts)
+	return args.Get(0).(model.ProduceSummary), args.Error(1)
+}
+
+func TestParseHeaders(t *testing.T) {
+	headers, err := parseHeaders([]string{"source=test", "query=a=b"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []client.MessageHeader{
+		{Key: []byte("source"), Value: []byte("test")},
+		{Key: []byte("query"), Value: []byte("a=b")},
+	}, headers)
+
+	_, err = parseHeaders([]string{"source"})
+	assert.EqualError(t, err, "invalid header source, expected key=value")
+}
+
+func TestProduceTopic_Success(t *testing.T) {
+	producer := &mockTopicProducer{}
+	opts := model.ProduceOptions{Topic: "topic", Partition: -1}
+	producer.On("Produce", nil, opts).Return(model.ProduceSummary{Produced: 10}, nil)
+	p := produceTopic{producer: producer, opts: opts}
+
+	p.produceTopic()
+
+	producer.AssertExpectations(t)
+}
+
+func TestProduceTopic_ExitsOnFailures(t *testing.T) {
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+
+	for _, result := range []struct {
+		summary model.ProduceSummary
+		err     error
+	}{
+		{model.ProduceSummary{Produced: 10, Failed: 1}, nil},
+		{model.ProduceSummary{Produced: 10}, errors.New("error")},
+	} {
+		producer := &mockTopicProducer{}
+		opts := model.ProduceOptions{Topic: "topic", Partition: -1}
+		producer.On("Produce", nil, opts).Return(result.summary, result.err)
+		p := produceTopic{producer: producer, opts: opts}
+
+		assert.PanicsWithValue(t, "os.Exit called", p.produceTopic, "os.Exit was not called")
+	}
+}
@@ -0,0 +1,83 @@
+package message
+
+import (
+	"context"
+	"syscall"
+
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/pkg/io"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/gojek/kat/ui"
+	"github.com/spf13/cobra"
+)
+
+type offsetsReader interface {
+	Offsets(ctx context.Context, topics []string) ([]model.TopicOffsets, error)
+}
+
+type topicOffsets struct {
+	client.Lister
+	reader     offsetsReader
+	renderer   *ui.Renderer
+	topicRegex string
+}
+
+var TopicOffsetsCmd = &cobra.Command{
+	Use:   "offsets",
+	Short: "Show the offset range, message count and timestamp range of the partitions of topics",
+	Run: func(command *cobra.Command, args []string) {
+		cobraUtil := base.NewCobraUtil(command)
+		baseCmd := base.Init(cobraUtil)
+		o := topicOffsets{
+			Lister:     baseCmd.GetTopic(),
+			reader:     model.NewOffsetsReader(baseCmd.GetClient(), cobraUtil.GetIntArg("concurrency")),
+			renderer:   cobraUtil.GetRenderer(),
+			topicRegex: cobraUtil.GetStringArg("topic-regex"),
+		}
+		o.topicOffsets()
+	},
+}
+
+func init() {
+	TopicOffsetsCmd.PersistentFlags().StringP("topic-regex", "t", "", "Regex of the topics to show the offsets of")
+	TopicOffsetsCmd.PersistentFlags().Int("concurrency", 8, "Number of partitions read in parallel")
+	if err := TopicOffsetsCmd.MarkPersistentFlagRequired("topic-regex"); err != nil {
+		logger.Fatal(err)
+	}
+}
+
+// topicOffsets prints the offsets of each partition, followed by the totals of the topic
+func (o *topicOffsets) topicOffsets() {
+	topics, err := o.ListOnly(o.topicRegex, true)
+	if err != nil {
+		logger.Fatalf("Error while fetching topic list - %v\n", err)
+	}
+	if len(topics) == 0 {
+		logger.Info("No topics found")
+		return
+	}
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	sigTermHandler := io.SignalHandler{}
+	sigTermHandler.SetListener(ctx, cancelFunc, syscall.SIGTERM)
+	sigTermHandler.SetListener(ctx, cancelFunc, syscall.SIGINT)
+	defer sigTermHandler.Close()
+	defer cancelFunc()
+
+	offsets, err := o.reader.Offsets(ctx, topics)
+	if err != nil {
+		logger.Fatalf("Error while reading the offsets - %v\n", err)
+	}
+	var rows []ui.Row
+	for _, topic := range offsets {
+		for _, p := range topic.Partitions {
+			rows = append(rows, ui.PartitionOffsets(p.Topic, p.Partition, p.OldestOffset, p.NewestOffset, p.Messages(), p.FirstTimestamp, p.LastTimestamp))
+		}
+		rows = append(rows, ui.TopicOffsetsTotal(topic.Topic, topic.Messages(), topic.FirstTimestamp(), topic.LastTimestamp()))
+	}
+	if err = o.renderer.Render(rows); err != nil {
+		logger.Fatalf("Error while writing the offsets - %v\n", err)
+	}
+}
@@ -0,0 +1,73 @@
+package message
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/gojek/kat/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockOffsetsReader struct {
+	mock.Mock
+}
+
+func (m *mockOffsetsReader) Offsets(ctx context.Context, topics []string) ([]model.TopicOffsets, error) {
+	args := m.Called(topics)
+	return args.Get(0).([]model.TopicOffsets), args.Error(1)
+}
+
+func TestTopicOffsets_Success(t *testing.T) {
+	lister := &client.MockLister{}
+	reader := &mockOffsetsReader{}
+	lister.On("ListOnly", "orders.*", true).Return([]string{"orders"}, nil)
+	reader.On("Offsets", []string{"orders"}).Return([]model.TopicOffsets{
+		{Topic: "orders", Partitions: []model.PartitionOffsets{{Topic: "orders", Partition: 0, OldestOffset: 1, NewestOffset: 5}}},
+	}, nil)
+	var out bytes.Buffer
+	renderer, _ := ui.NewRenderer(ui.OutputJSON, &out)
+	o := topicOffsets{Lister: lister, reader: reader, renderer: renderer, topicRegex: "orders.*"}
+
+	o.topicOffsets()
+
+	assert.JSONEq(t, `[
+		{"topic": "orders", "partition": 0, "earliestOffset": 1, "latestOffset": 5, "messages": 4},
+		{"topic": "orders", "messages": 4}
+	]`, out.String())
+	lister.AssertExpectations(t)
+	reader.AssertExpectations(t)
+}
+
+func TestTopicOffsets_NoTopics(t *testing.T) {
+	lister := &client.MockLister{}
+	reader := &mockOffsetsReader{}
+	lister.On("ListOnly", "orders.*", true).Return([]string{}, nil)
+	o := topicOffsets{Lister: lister, reader: reader, topicRegex: "orders.*"}
+
+	o.topicOffsets()
+
+	reader.AssertNotCalled(t, "Offsets", mock.Anything)
+}
+
+func TestTopicOffsets_Failure(t *testing.T) {
+	lister := &client.MockLister{}
+	reader := &mockOffsetsReader{}
+	lister.On("ListOnly", "orders.*", true).Return([]string{"orders"}, nil)
+	reader.On("Offsets", []string{"orders"}).Return([]model.TopicOffsets{}, errors.New("error"))
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	o := topicOffsets{Lister: lister, reader: reader, topicRegex: "orders.*"}
+
+	assert.PanicsWithValue(t, "os.Exit called", o.topicOffsets, "os.Exit was not called")
+	reader.AssertExpectations(t)
+}
@@ -0,0 +1,99 @@
+package message
+
+import (
+	"fmt"
+
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/gojek/kat/ui"
+	"github.com/spf13/cobra"
+)
+
+type topicTruncation interface {
+	Plan(topic string, partitions []int32, before model.OffsetSpec) ([]model.PartitionTruncation, error)
+	Truncate(topic string, truncations []model.PartitionTruncation) error
+}
+
+type userInput interface {
+	AskForConfirmation(string) bool
+}
+
+type truncateTopic struct {
+	truncation topicTruncation
+	userInput  userInput
+	safety     base.SafetyChecker
+	renderer   *ui.Renderer
+	topic      string
+	partitions []int32
+	before     model.OffsetSpec
+}
+
+var TruncateTopicCmd = &cobra.Command{
+	Use:   "truncate",
+	Short: "Delete the messages of a topic before an offset or time",
+	Run: func(command *cobra.Command, args []string) {
+		cobraUtil := base.NewCobraUtil(command)
+		before, err := model.ParseOffsetSpec(cobraUtil.GetStringArg("before"))
+		if err != nil {
+			logger.Fatalf("Error while parsing --before - %v\n", err)
+		}
+		kafkaClient := base.Init(cobraUtil).GetClient()
+		t := truncateTopic{
+			truncation: model.NewTopicTruncation(kafkaClient, kafkaClient),
+			userInput:  &ui.UserInput{},
+			safety:     cobraUtil.GetSafetyGuard(),
+			renderer:   cobraUtil.GetRenderer(),
+			topic:      cobraUtil.GetStringArg("topic"),
+			partitions: cobraUtil.GetInt32SliceArg("partitions"),
+			before:     before,
+		}
+		t.truncateTopic()
+	},
+}
+
+func init() {
+	TruncateTopicCmd.PersistentFlags().StringP("topic", "t", "", "Topic to delete the messages of")
+	TruncateTopicCmd.PersistentFlags().IntSlice("partitions", []int{}, "Comma separated list of partitions to truncate, defaults to all the partitions")
+	TruncateTopicCmd.PersistentFlags().String("before", "",
+		"Offset or time to delete the messages before, one of latest, an offset, a RFC3339 time or a duration like -1h. latest deletes all the messages")
+	for _, flag := range []string{"topic", "before"} {
+		if err := TruncateTopicCmd.MarkPersistentFlagRequired(flag); err != nil {
+			logger.Fatal(err)
+		}
+	}
+}
+
+// truncateTopic previews the number of messages to be deleted per partition, and deletes them on confirmation
+func (t *truncateTopic) truncateTopic() {
+	truncations, err := t.truncation.Plan(t.topic, t.partitions, t.before)
+	if err != nil {
+		logger.Fatalf("Error while finding the messages to delete - %v\n", err)
+	}
+
+	var total int64
+	rows := make([]ui.Row, 0, len(truncations))
+	for _, truncation := range truncations {
+		total += truncation.Messages()
+		rows = append(rows, ui.PartitionTruncation(truncation.Partition, truncation.OldestOffset, truncation.Offset, truncation.Messages()))
+	}
+	if total == 0 {
+		logger.Infof("No messages of topic %v are before %v\n", t.topic, t.before)
+		return
+	}
+	if err = t.renderer.Render(rows); err != nil {
+		logger.Fatalf("Error while printing the messages to delete - %v\n", err)
+	}
+
+	if err = t.safety.Check(model.OperationDelete, []string{t.topic}); err != nil {
+		logger.Fatal(err)
+	}
+	question := fmt.Sprintf("Do you really want to delete %d messages of topic %v?", total, t.topic)
+	if !t.userInput.AskForConfirmation(question) {
+		return
+	}
+	if err = t.truncation.Truncate(t.topic, truncations); err != nil {
+		logger.Fatalf("Error while deleting the messages of topic %v - %v\n", t.topic, err)
+	}
+	logger.Infof("Deleted %d messages of topic %v\n", total, t.topic)
+}
@@ -0,0 +1,117 @@
+package message
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/gojek/kat/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockTopicTruncation struct {
+	mock.Mock
+}
+
+func (m *mockTopicTruncation) Plan(topic string, partitions []int32, before model.OffsetSpec) ([]model.PartitionTruncation, error) {
+	args := m.Called(topic, partitions, before)
+	return args.Get(0).([]model.PartitionTruncation), args.Error(1)
+}
+
+func (m *mockTopicTruncation) Truncate(topic string, truncations []model.PartitionTruncation) error {
+	args := m.Called(topic, truncations)
+	return args.Error(0)
+}
+
+type mockUserInput struct {
+	mock.Mock
+}
+
+func (m *mockUserInput) AskForConfirmation(question string) bool {
+	args := m.Called(question)
+	return args.Bool(0)
+}
+
+func TestTruncateTopic_DeletesOnConfirmation(t *testing.T) {
+	truncation := &mockTopicTruncation{}
+	input := &mockUserInput{}
+	truncations := []model.PartitionTruncation{{Partition: 0, OldestOffset: 10, Offset: 50}, {Partition: 1, OldestOffset: 5, Offset: 7}}
+	truncation.On("Plan", "topic", []int32{0, 1}, model.OffsetSpecNewest).Return(truncations, nil)
+	truncation.On("Truncate", "topic", truncations).Return(nil)
+	input.On("AskForConfirmation", "Do you really want to delete 42 messages of topic topic?").Return(true)
+	out := &bytes.Buffer{}
+	renderer, _ := ui.NewRenderer(ui.OutputCSV, out)
+	tr := truncateTopic{truncation: truncation, userInput: input, topic: "topic", partitions: []int32{0, 1}, before: model.OffsetSpecNewest,
+		safety: noSafetyPolicy(), renderer: renderer}
+
+	tr.truncateTopic()
+
+	truncation.AssertExpectations(t)
+	input.AssertExpectations(t)
+	assert.Equal(t, "Partition,Oldest Offset,Delete Before Offset,Messages To Delete\n0,10,50,40\n1,5,7,2\n", out.String())
+}
+
+func TestTruncateTopic_DoesNotDeleteOnNo(t *testing.T) {
+	truncation := &mockTopicTruncation{}
+	input := &mockUserInput{}
+	truncations := []model.PartitionTruncation{{Partition: 0, OldestOffset: 10, Offset: 50}}
+	truncation.On("Plan", "topic", []int32(nil), model.OffsetSpecNewest).Return(truncations, nil)
+	input.On("AskForConfirmation", mock.Anything).Return(false)
+	tr := truncateTopic{truncation: truncation, userInput: input, topic: "topic", before: model.OffsetSpecNewest,
+		safety: noSafetyPolicy(), renderer: tableRenderer()}
+
+	tr.truncateTopic()
+
+	truncation.AssertNotCalled(t, "Truncate", mock.Anything, mock.Anything)
+	input.AssertExpectations(t)
+}
+
+func TestTruncateTopic_DoesNotAskWithoutMessagesToDelete(t *testing.T) {
+	truncation := &mockTopicTruncation{}
+	input := &mockUserInput{}
+	truncations := []model.PartitionTruncation{{Partition: 0, OldestOffset: 50, Offset: 50}}
+	truncation.On("Plan", "topic", []int32(nil), model.OffsetSpecOldest).Return(truncations, nil)
+	tr := truncateTopic{truncation: truncation, userInput: input, topic: "topic", before: model.OffsetSpecOldest, safety: noSafetyPolicy(),
+		renderer: tableRenderer()}
+
+	tr.truncateTopic()
+
+	input.AssertNotCalled(t, "AskForConfirmation", mock.Anything)
+	truncation.AssertNotCalled(t, "Truncate", mock.Anything, mock.Anything)
+}
+
+func TestTruncateTopic_Failure(t *testing.T) {
+	truncation := &mockTopicTruncation{}
+	input := &mockUserInput{}
+	truncations := []model.PartitionTruncation{{Partition: 0, OldestOffset: 10, Offset: 50}}
+	truncation.On("Plan", "topic", []int32(nil), model.OffsetSpecNewest).Return(truncations, nil)
+	truncation.On("Truncate", "topic", truncations).Return(errors.New("error"))
+	input.On("AskForConfirmation", mock.Anything).Return(true)
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	tr := truncateTopic{truncation: truncation, userInput: input, topic: "topic", before: model.OffsetSpecNewest,
+		safety: noSafetyPolicy(), renderer: tableRenderer()}
+
+	assert.PanicsWithValue(t, "os.Exit called", tr.truncateTopic, "os.Exit was not called")
+	truncation.AssertExpectations(t)
+}
+
+func tableRenderer() *ui.Renderer {
+	renderer, _ := ui.NewRenderer(ui.OutputTable, ioutil.Discard)
+	return renderer
+}
+
+func noSafetyPolicy() *base.MockSafetyGuard {
+	safety := &base.MockSafetyGuard{}
+	safety.On("Check", mock.Anything, mock.Anything).Return(nil)
+	return safety
+}
@@ -0,0 +1,58 @@
+package mirror
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// mirrorMetrics counts the changes applied by the mirror. The counters are exposed in the prometheus text format.
+type mirrorMetrics struct {
+	mu             sync.Mutex
+	runs           int64
+	topicsCreated  int64
+	configsUpdated int64
+	failures       int64
+}
+
+func (m *mirrorMetrics) ran() {
+	m.add(&m.runs, 1)
+}
+
+func (m *mirrorMetrics) topicCreated() {
+	m.add(&m.topicsCreated, 1)
+}
+
+func (m *mirrorMetrics) configUpdated(count int) {
+	m.add(&m.configsUpdated, int64(count))
+}
+
+func (m *mirrorMetrics) failed() {
+	m.add(&m.failures, 1)
+}
+
+func (m *mirrorMetrics) add(counter *int64, delta int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	*counter += delta
+}
+
+func (m *mirrorMetrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	m.mu.Lock()
+	counters := []struct {
+		name  string
+		help  string
+		value int64
+	}{
+		{"kat_mirror_runs_total", "Number of mirror runs", m.runs},
+		{"kat_mirror_topics_created_total", "Number of topics created in the destination cluster", m.topicsCreated},
+		{"kat_mirror_configs_updated_total", "Number of configs updated in the destination cluster", m.configsUpdated},
+		{"kat_mirror_failures_total", "Number of failures while mirroring", m.failures},
+	}
+	m.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	for _, counter := range counters {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n%s %d\n", counter.name, counter.help, counter.name, counter.name, counter.value)
+	}
+}
@@ -0,0 +1,25 @@
+package mirror
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMirrorMetrics_ServeHTTP(t *testing.T) {
+	metrics := &mirrorMetrics{}
+	metrics.ran()
+	metrics.topicCreated()
+	metrics.configUpdated(3)
+	metrics.failed()
+	recorder := httptest.NewRecorder()
+
+	metrics.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+	body := recorder.Body.String()
+	assert.Contains(t, body, "# TYPE kat_mirror_runs_total counter\nkat_mirror_runs_total 1\n")
+	assert.Contains(t, body, "kat_mirror_topics_created_total 1\n")
+	assert.Contains(t, body, "kat_mirror_configs_updated_total 3\n")
+	assert.Contains(t, body, "kat_mirror_failures_total 1\n")
+}
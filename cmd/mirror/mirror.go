@@ -1,11 +1,16 @@
 package mirror
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"net/http"
 	"reflect"
+	"sort"
+	"syscall"
+	"time"
 
 	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/pkg/io"
 	"github.com/gojek/kat/pkg/model"
 
 	"github.com/gojek/kat/cmd/base"
@@ -24,12 +29,23 @@ type createOrUpdate interface {
 }
 
 type mirror struct {
-	sourceCli          createOrUpdate
-	destinationCli     createOrUpdate
-	createTopics       bool
-	increasePartitions bool
-	dryRun             bool
-	excludeConfigs     []string
+	sourceCli           createOrUpdate
+	destinationCli      createOrUpdate
+	createTopics        bool
+	increasePartitions  bool
+	dryRun              bool
+	excludeConfigs      []string
+	rules               *model.MirrorRules
+	topicsWithOverrides bool
+	includeTopics       string
+	excludeTopics       string
+	replicationFactor   int16
+	ignoreAssignment    bool
+	rackAware           bool
+	destinationBrokers  []client.Broker
+	metrics             mirrorMetrics
+	safety              base.SafetyChecker
+	renderer            *ui.Renderer
 }
 
 var MirrorCmd = &cobra.Command{
@@ -37,17 +53,41 @@ var MirrorCmd = &cobra.Command{
 	Short: "Mirror topic configurations from source to destination cluster",
 	Run: func(command *cobra.Command, args []string) {
 		cobraUtil := base.NewCobraUtil(command)
+		var rules *model.MirrorRules
+		if rulesFile := cobraUtil.GetStringArg("rules"); rulesFile != "" {
+			var err error
+			rules, err = model.LoadMirrorRules(rulesFile)
+			if err != nil {
+				logger.Fatalf("Error while loading mirror rules - %v\n", err)
+			}
+		}
+
+		if cobraUtil.GetBoolArg("ignore-assignment") && cobraUtil.GetBoolArg("rack-aware") {
+			logger.Fatalf("Only one of --ignore-assignment and --rack-aware can be passed\n")
+		}
 
 		sourceCli := base.Init(cobraUtil, base.WithAddr("source-broker-ips")).GetTopic()
 		destinationCli := base.Init(cobraUtil, base.WithAddr("destination-broker-ips")).GetTopic()
 		m := mirror{sourceCli: sourceCli,
-			destinationCli:     destinationCli,
-			createTopics:       cobraUtil.GetBoolArg("create-topics"),
-			increasePartitions: cobraUtil.GetBoolArg("increase-partitions"),
-			dryRun:             cobraUtil.GetBoolArg("dry-run"),
-			excludeConfigs:     cobraUtil.GetStringSliceArg("exclude-configs"),
+			destinationCli:      destinationCli,
+			createTopics:        cobraUtil.GetBoolArg("create-topics"),
+			increasePartitions:  cobraUtil.GetBoolArg("increase-partitions"),
+			dryRun:              cobraUtil.GetBoolArg("dry-run"),
+			excludeConfigs:      cobraUtil.GetStringSliceArg("exclude-configs"),
+			rules:               rules,
+			topicsWithOverrides: cobraUtil.GetBoolArg("topics-with-overrides"),
+			includeTopics:       cobraUtil.GetStringArg("topics"),
+			excludeTopics:       cobraUtil.GetStringArg("exclude-topics"),
+			replicationFactor:   int16(cobraUtil.GetIntArg("replication-factor")),
+			ignoreAssignment:    cobraUtil.GetBoolArg("ignore-assignment"),
+			rackAware:           cobraUtil.GetBoolArg("rack-aware"),
+			safety:              cobraUtil.GetSafetyGuard(),
+			renderer:            cobraUtil.GetRenderer(),
+		}
+		if cobraUtil.GetBoolArg("watch") {
+			m.watchTopicConfigs(cobraUtil.GetDurationArg("interval"), cobraUtil.GetStringArg("metrics-addr"))
+			return
 		}
-		//TODO: Read configs to be mirrored from a json config file. Currently, everything is mirrored
 		m.mirrorTopicConfigs()
 	},
 }
@@ -55,10 +95,17 @@ var MirrorCmd = &cobra.Command{
 func init() {
 	MirrorCmd.PersistentFlags().StringP("source-broker-ips", "b", "", "Comma separated list of source broker ips")
 	MirrorCmd.PersistentFlags().StringP("destination-broker-ips", "d", "", "Comma separated list of broker ips to mirror the configs to")
-	//TODO: Mirror only the topics that have overridden configs.
-	MirrorCmd.PersistentFlags().String("topics-with-overrides", "true", "Mirror only the topics that have overridden configs")
+	MirrorCmd.PersistentFlags().Bool("topics-with-overrides", false, "Mirror only the topics that have overridden configs")
+	MirrorCmd.PersistentFlags().String("topics", "", "Regex pattern to include topics from the source cluster")
+	MirrorCmd.PersistentFlags().String("exclude-topics", "", "Regex pattern to exclude topics from the source cluster")
 	MirrorCmd.PersistentFlags().Bool("create-topics", false, "Create the topics on destination cluster if not present and mirror the configs")
 	MirrorCmd.PersistentFlags().Bool("increase-partitions", false, "Increase the partition count of topics on destination cluster")
+	MirrorCmd.PersistentFlags().Int16("replication-factor", 0,
+		"Replication factor of the topics created on destination cluster, defaults to the replication factor on source cluster")
+	MirrorCmd.PersistentFlags().Bool("ignore-assignment", false,
+		"Create the topics on destination cluster without the replica assignment of source cluster, and let the brokers place the replicas")
+	MirrorCmd.PersistentFlags().Bool("rack-aware", false,
+		"Create the topics on destination cluster with a replica assignment spread across the racks of the destination brokers")
 	if err := MirrorCmd.MarkPersistentFlagRequired("source-broker-ips"); err != nil {
 		logger.Fatal(err)
 	}
@@ -67,47 +114,156 @@ func init() {
 	}
 	MirrorCmd.PersistentFlags().Bool("dry-run", false, "shows only the configs which gets updated")
 	MirrorCmd.PersistentFlags().StringSlice("exclude-configs", []string{}, "Comma separated list of topics configs need to be excluded")
+	MirrorCmd.PersistentFlags().String("rules", "", "Path to the rules file with the configs to be mirrored, value transforms and topic name mappings")
+	MirrorCmd.PersistentFlags().Bool("watch", false, "Keep running and mirror the configs at every interval")
+	MirrorCmd.PersistentFlags().Duration("interval", 5*time.Minute, "Interval between the mirror runs in watch mode")
+	MirrorCmd.PersistentFlags().String("metrics-addr", ":9102", "Address to serve the mirror metrics on in watch mode, empty to disable")
 }
 
 func (m *mirror) mirrorTopicConfigs() {
-	sourceTopics, sourceTopicConfigs, err := getTopicDetailsAndConfigs(m.sourceCli)
+	if err := m.mirrorOnce(); err != nil {
+		logger.Fatalf("Error while mirroring configs - %v\n", err)
+	}
+}
+
+// watchTopicConfigs mirrors the configs at every interval, until the process is interrupted
+func (m *mirror) watchTopicConfigs(interval time.Duration, metricsAddr string) {
+	if interval <= 0 {
+		logger.Fatalf("Interval should be greater than 0 in watch mode\n")
+	}
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	sigTermHandler := io.SignalHandler{}
+	sigTermHandler.SetListener(ctx, cancelFunc, syscall.SIGTERM)
+	sigTermHandler.SetListener(ctx, cancelFunc, syscall.SIGINT)
+	defer sigTermHandler.Close()
+	defer cancelFunc()
+
+	if metricsAddr != "" {
+		server := m.serveMetrics(metricsAddr)
+		defer server.Close()
+	}
+	m.watch(ctx, interval)
+}
+
+func (m *mirror) watch(ctx context.Context, interval time.Duration) {
+	for {
+		if err := m.mirrorOnce(); err != nil {
+			logger.Errorf("Error while mirroring configs - %v\n", err)
+			m.metrics.failed()
+		}
+		m.metrics.ran()
+
+		select {
+		case <-ctx.Done():
+			logger.Info("Stopping the mirror")
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+func (m *mirror) serveMetrics(addr string) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", &m.metrics)
+	server := &http.Server{Addr: addr, Handler: mux}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Errorf("Error while serving metrics on %v - %v\n", addr, err)
+		}
+	}()
+	logger.Infof("Serving mirror metrics on %v/metrics\n", addr)
+	return server
+}
+
+func (m *mirror) mirrorOnce() error {
+	m.destinationBrokers = nil
+	sourceTopics, sourceTopicConfigs, err := getTopicDetailsAndConfigs(m.sourceCli, m.selectSourceTopics)
 	if err != nil {
-		logger.Fatalf("Source cluster - %v\n", err)
+		return fmt.Errorf("source cluster - %v", err)
+	}
+	if m.topicsWithOverrides {
+		filterTopicsWithOverrides(sourceTopics, sourceTopicConfigs)
 	}
 
-	destinationTopics, destinationTopicConfigs, err := getTopicDetailsAndConfigs(m.destinationCli)
+	destinationTopics, destinationTopicConfigs, err := getTopicDetailsAndConfigs(m.destinationCli, func(topics map[string]client.TopicDetail) ([]string, error) {
+		return m.selectDestinationTopics(sourceTopics, topics), nil
+	})
 	if err != nil {
-		logger.Fatalf("Destination cluster - %v\n", err)
+		return fmt.Errorf("destination cluster - %v", err)
 	}
 
-	tw := &ui.TableWriter{}
-	for topic, detail := range sourceTopics {
-		var err error
-		if destinationTopics[topic].NumPartitions == 0 {
-			if !m.createTopics {
-				logger.Infof("topic - %v does not exist in destination cluster. Pass --create-topics flag\n", topic)
-				continue
-			}
-			err = m.createTopic(topic, detail)
-			tw.AddRow(ui.MirrorStatus(topic, jsonString(detail.Config), detail.NumPartitions, detail.NumPartitions, true, m.dryRun, err))
-		} else {
-			sourceNumOfPartitions := sourceTopics[topic].NumPartitions
-			destNumOfPartitions := destinationTopics[topic].NumPartitions
-			sourceCM := getConfigMap(sourceTopicConfigs[topic], m.excludeConfigs)
-			destinationCM := getConfigMap(destinationTopicConfigs[topic], m.excludeConfigs)
-			equalConfigs := reflect.DeepEqual(destinationCM, sourceCM)
-
-			if equalConfigs && (!m.increasePartitions || (sourceNumOfPartitions <= destNumOfPartitions)) {
-				logger.Debugf("Configs are equal for topic - %v\n", topic)
-				continue
-			} else {
-				changelogs, err := m.applyDiff(topic, sourceCM, destinationCM, sourceNumOfPartitions, destNumOfPartitions)
-				tw.AddRow(ui.MirrorStatus(topic, fmt.Sprint(changelogs), destNumOfPartitions, sourceNumOfPartitions, false, m.dryRun, err))
+	topics := make([]string, 0, len(sourceTopics))
+	for topic := range sourceTopics {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	var rows []ui.Row
+	for _, topic := range topics {
+		if row := m.mirrorTopic(topic, sourceTopics[topic], sourceTopicConfigs[topic], destinationTopics, destinationTopicConfigs); row != nil {
+			rows = append(rows, row)
+		}
+	}
+	return m.renderer.Render(rows)
+}
+
+// mirrorTopic applies the changes for the topic on the destination cluster, and returns nil if there are none
+func (m *mirror) mirrorTopic(topic string, detail client.TopicDetail, sourceConfigs []client.ConfigEntry,
+	destinationTopics map[string]client.TopicDetail, destinationTopicConfigs map[string][]client.ConfigEntry) ui.Row {
+	destinationTopic := m.rules.DestinationTopic(topic)
+	sourceCM, err := m.mirroredConfigs(topic, getConfigMap(sourceConfigs, m.excludeConfigs), true)
+	if err != nil {
+		logger.Errorf("Err while reading configs to be mirrored for topic %v - %v\n", topic, err)
+		m.metrics.failed()
+		return ui.MirrorStatus(displayName(topic, destinationTopic), nil, 0, 0, false, false, err)
+	}
+	if destinationTopics[destinationTopic].NumPartitions == 0 {
+		if !m.createTopics {
+			logger.Infof("topic - %v does not exist in destination cluster. Pass --create-topics flag\n", destinationTopic)
+			return nil
+		}
+		return m.createTopicInDestinationCluster(topic, destinationTopic, detail)
+	}
+
+	sourceNumOfPartitions := detail.NumPartitions
+	destNumOfPartitions := destinationTopics[destinationTopic].NumPartitions
+	destinationCM, _ := m.mirroredConfigs(topic, getConfigMap(destinationTopicConfigs[destinationTopic], m.excludeConfigs), false)
+	equalConfigs := reflect.DeepEqual(destinationCM, sourceCM)
+
+	if equalConfigs && (!m.increasePartitions || (sourceNumOfPartitions <= destNumOfPartitions)) {
+		logger.Debugf("Configs are equal for topic - %v\n", topic)
+		return nil
+	}
+	changelogs, err := m.applyDiff(destinationTopic, sourceCM, destinationCM, sourceNumOfPartitions, destNumOfPartitions)
+	return ui.MirrorStatus(displayName(topic, destinationTopic), configChanges(changelogs), destNumOfPartitions, sourceNumOfPartitions,
+		false, m.dryRun, err)
+}
+
+// mirroredConfigs filters the configs to the ones that are mirrored for the source topic,
+// and applies the value transforms of the rules when transform is set
+func (m *mirror) mirroredConfigs(topic string, configMap map[string]string, transform bool) (map[string]string, error) {
+	result := make(map[string]string)
+	for name, value := range configMap {
+		if !m.rules.ShouldMirror(topic, name) {
+			continue
+		}
+		if transform {
+			transformed, err := m.rules.Transform(topic, name, value)
+			if err != nil {
+				return nil, err
 			}
+			value = transformed
 		}
+		result[name] = value
 	}
+	return result, nil
+}
 
-	tw.Render()
+func displayName(topic, destinationTopic string) string {
+	if topic == destinationTopic {
+		return topic
+	}
+	return fmt.Sprintf("%v -> %v", topic, destinationTopic)
 }
 
 func (m *mirror) applyDiff(topic string, sourceCM, destinationCM map[string]string, sourceNumOfPartitions, destNumOfPartitions int32) (diff.Changelog, error) {
@@ -117,12 +273,105 @@ func (m *mirror) applyDiff(topic string, sourceCM, destinationCM map[string]stri
 	} else if !m.dryRun {
 		err = m.updateTopicInDestinationCluster(topic, sourceNumOfPartitions, destNumOfPartitions, changelogs)
 	}
+	if err != nil {
+		m.metrics.failed()
+	}
 
 	return changelogs, err
 }
 
+func (m *mirror) createTopicInDestinationCluster(topic, destinationTopic string, detail client.TopicDetail) ui.MirrorStatusRow {
+	detail, err := m.destinationDetail(topic, detail)
+	if err == nil {
+		detail, err = m.placeReplicas(detail)
+	}
+	if err == nil {
+		err = m.createTopic(destinationTopic, detail)
+	}
+	if err != nil {
+		m.metrics.failed()
+	} else if !m.dryRun {
+		m.metrics.topicCreated()
+	}
+	return ui.MirrorStatus(displayName(topic, destinationTopic), createdConfigs(detail.Config), detail.NumPartitions, detail.NumPartitions,
+		true, m.dryRun, err)
+}
+
+// destinationDetail returns the topic detail with the configs to be mirrored, when creating the topic on the destination cluster
+func (m *mirror) destinationDetail(topic string, detail client.TopicDetail) (client.TopicDetail, error) {
+	configMap := make(map[string]string)
+	for name, value := range detail.Config {
+		if value != nil && !(model.ListUtil{List: m.excludeConfigs}).Contains(name) {
+			configMap[name] = *value
+		}
+	}
+	configMap, err := m.mirroredConfigs(topic, configMap, true)
+	if err != nil {
+		return detail, err
+	}
+	if len(configMap) == 0 {
+		detail.Config = nil
+		return detail, nil
+	}
+	detail.Config = make(map[string]*string, len(configMap))
+	for name := range configMap {
+		value := configMap[name]
+		detail.Config[name] = &value
+	}
+	return detail, nil
+}
+
+// placeReplicas sets the replication factor and the replica assignment of the topic to be created on the destination cluster.
+// The assignment of the source cluster is kept only when the replication factor is not overridden,
+// and it is validated against the destination brokers.
+func (m *mirror) placeReplicas(detail client.TopicDetail) (client.TopicDetail, error) {
+	if m.replicationFactor > 0 {
+		detail.ReplicationFactor = m.replicationFactor
+	}
+
+	switch {
+	case m.rackAware:
+		brokers, err := m.brokers()
+		if err != nil {
+			return detail, err
+		}
+		assignment, err := model.AssignReplicas(brokers, detail.NumPartitions, detail.ReplicationFactor)
+		if err != nil {
+			return detail, err
+		}
+		detail.ReplicaAssignment = assignment
+	case m.ignoreAssignment || m.replicationFactor > 0:
+		detail.ReplicaAssignment = nil
+	case len(detail.ReplicaAssignment) > 0:
+		brokers, err := m.brokers()
+		if err != nil {
+			return detail, err
+		}
+		if validationErr := model.ValidateAssignment(detail.ReplicaAssignment, brokers); validationErr != nil {
+			return detail, fmt.Errorf("invalid replica assignment for destination cluster, pass --ignore-assignment or --rack-aware - %v", validationErr)
+		}
+	}
+	return detail, nil
+}
+
+// brokers returns the brokers of the destination cluster, described once per run
+func (m *mirror) brokers() ([]client.Broker, error) {
+	if m.destinationBrokers == nil {
+		brokers, err := m.destinationCli.DescribeBrokers()
+		if err != nil {
+			return nil, fmt.Errorf("err while describing the brokers of destination cluster - %v", err)
+		}
+		m.destinationBrokers = brokers
+	}
+	return m.destinationBrokers, nil
+}
+
 func (m *mirror) createTopic(topic string, detail client.TopicDetail) error {
 	if !m.dryRun {
+		if err := m.safety.Check(model.OperationCreate, []string{topic}); err != nil {
+			logger.Errorf("Err while creating topic %v in destination cluster - %v\n", topic, err)
+			return err
+		}
 		err := m.destinationCli.Create(topic, detail, false)
 		if err != nil {
 			logger.Errorf("Err while creating topic %v in destination cluster - %v\n", topic, err)
@@ -143,33 +392,101 @@ func (m *mirror) updateTopicInDestinationCluster(topic string, sourceNumOfPartit
 		return nil
 	}
 
+	if err = m.safety.Check(model.OperationAlterConfig, []string{topic}); err != nil {
+		logger.Errorf("Err while updating config for topic %v - %v\n", topic, err)
+		return err
+	}
 	err = m.destinationCli.UpdateConfig([]string{topic}, configToUpdate(changelogs), false)
 	if err != nil {
 		logger.Errorf("Err while updating config for topic %v - %v\n", topic, err)
+		return err
 	}
-	return err
+	m.metrics.configUpdated(len(changelogs))
+	return nil
 }
 
-func getTopicDetailsAndConfigs(cli createOrUpdate) (topics map[string]client.TopicDetail, topicConfigs map[string][]client.ConfigEntry, err error) {
-	topics, err = cli.List()
+// getTopicDetailsAndConfigs returns the details and configs of the topics chosen by selectTopics.
+// The configs are fetched in batches, instead of a request per topic.
+func getTopicDetailsAndConfigs(cli createOrUpdate, selectTopics func(map[string]client.TopicDetail) ([]string, error)) (
+	topics map[string]client.TopicDetail, topicConfigs map[string][]client.ConfigEntry, err error) {
+	allTopics, err := cli.List()
 	if err != nil {
 		return nil, nil, fmt.Errorf("err while fetching topics - %v", err)
 	}
-	if len(topics) == 0 {
+	if len(allTopics) == 0 {
 		logger.Info("No topics found in cluster")
 		return nil, nil, nil
 	}
 
-	topicConfigs = make(map[string][]client.ConfigEntry)
+	selectedTopics, err := selectTopics(allTopics)
+	if err != nil {
+		return nil, nil, fmt.Errorf("err while filtering topics - %v", err)
+	}
+	topics = make(map[string]client.TopicDetail, len(selectedTopics))
+	for _, topic := range selectedTopics {
+		topics[topic] = allTopics[topic]
+	}
+	if len(selectedTopics) == 0 {
+		return topics, map[string][]client.ConfigEntry{}, nil
+	}
+
+	sort.Strings(selectedTopics)
+	topicConfigs, err = cli.GetConfigs(selectedTopics)
+	if err != nil {
+		return nil, nil, fmt.Errorf("err while reading configs - %v", err)
+	}
+	return topics, topicConfigs, nil
+}
+
+func (m *mirror) selectSourceTopics(topics map[string]client.TopicDetail) ([]string, error) {
+	names := make([]string, 0, len(topics))
 	for topic := range topics {
-		entries, err := cli.GetConfig(topic)
+		names = append(names, topic)
+	}
+	var err error
+	if m.includeTopics != "" {
+		names, err = model.ListUtil{List: names}.Filter(m.includeTopics, true)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if m.excludeTopics != "" {
+		names, err = model.ListUtil{List: names}.Filter(m.excludeTopics, false)
 		if err != nil {
-			return nil, nil, fmt.Errorf("err while reading config for topic %v - %v", topic, err)
+			return nil, err
 		}
-		topicConfigs[topic] = entries
 	}
+	return names, nil
+}
 
-	return topics, topicConfigs, nil
+// selectDestinationTopics returns the destination names of the source topics that are present in the destination cluster
+func (m *mirror) selectDestinationTopics(sourceTopics, destinationTopics map[string]client.TopicDetail) []string {
+	var names []string
+	for topic := range sourceTopics {
+		destinationTopic := m.rules.DestinationTopic(topic)
+		if _, ok := destinationTopics[destinationTopic]; ok {
+			names = append(names, destinationTopic)
+		}
+	}
+	return names
+}
+
+func filterTopicsWithOverrides(topics map[string]client.TopicDetail, topicConfigs map[string][]client.ConfigEntry) {
+	for topic := range topics {
+		if !hasOverrides(topicConfigs[topic]) {
+			logger.Debugf("Skipping topic %v as it does not have overridden configs\n", topic)
+			delete(topics, topic)
+		}
+	}
+}
+
+func hasOverrides(configs []client.ConfigEntry) bool {
+	for _, config := range configs {
+		if config.IsOverridden() {
+			return true
+		}
+	}
+	return false
 }
 
 func (m *mirror) increasePartitionsIfEnabled(topic string, sourceNumOfPartitions, destNumOfPartitions int32) error {
@@ -177,6 +494,9 @@ func (m *mirror) increasePartitionsIfEnabled(topic string, sourceNumOfPartitions
 		if !m.increasePartitions {
 			logger.Infof("Partitions are not the same for topic %v. Pass --increase-partitions flag", topic)
 		} else {
+			if err := m.safety.Check(model.OperationCreate, []string{topic}); err != nil {
+				return err
+			}
 			err := m.destinationCli.CreatePartitions(topic, sourceNumOfPartitions, [][]int32{}, false)
 			if err != nil {
 				return fmt.Errorf("err while increasing partitions for topic %v - %v", topic, err)
@@ -206,12 +526,26 @@ func configToUpdate(changelogs diff.Changelog) map[string]*string {
 	return result
 }
 
-func jsonString(configs map[string]*string) string {
-	configJSON := make(map[string]string)
-	for k, v := range configs {
-		configJSON[k] = *v
+// configChanges returns the configs of the changelog sorted by name, with the values they change from and to
+func configChanges(changelogs diff.Changelog) []ui.ConfigChange {
+	changes := make([]ui.ConfigChange, 0, len(changelogs))
+	for _, log := range changelogs {
+		change := ui.ConfigChange{Config: log.Path[0], To: fmt.Sprint(log.To)}
+		if log.From != nil {
+			change.From = fmt.Sprint(log.From)
+		}
+		changes = append(changes, change)
 	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Config < changes[j].Config })
+	return changes
+}
 
-	outputJSON, _ := json.MarshalIndent(configJSON, "", "    ")
-	return string(outputJSON)
+// createdConfigs returns the configs of a created topic sorted by name
+func createdConfigs(configs map[string]*string) []ui.ConfigChange {
+	changes := make([]ui.ConfigChange, 0, len(configs))
+	for name, value := range configs {
+		changes = append(changes, ui.ConfigChange{Config: name, To: *value})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Config < changes[j].Config })
+	return changes
 }
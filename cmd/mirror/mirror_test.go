@@ -1,14 +1,22 @@
 package mirror
 
 import (
+	"context"
 	"errors"
+	"io/ioutil"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/gojek/kat/cmd/base"
 	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/gojek/kat/ui"
+	"github.com/stretchr/testify/require"
 
 	"bou.ke/monkey"
 	"github.com/gojek/kat/logger"
+	"github.com/r3labs/diff"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -46,6 +54,8 @@ func TestMirrorConfig_SourceClusterListError(t *testing.T) {
 		increasePartitions: false,
 		dryRun:             false,
 		excludeConfigs:     nil,
+		safety:             noSafetyPolicy(),
+		renderer:           tableRenderer(),
 	}
 	assert.PanicsWithValue(t, "os.Exit called", m.mirrorTopicConfigs, "os.Exit was not called")
 
@@ -60,7 +70,7 @@ func TestMirrorConfig_SourceClusterGetConfigError(t *testing.T) {
 	}
 	topicName := "topic1"
 	sourceCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1Detail}, nil)
-	sourceCli.MockConfigurer.On("GetConfig", topicName).Return([]client.ConfigEntry{}, errors.New("error"))
+	sourceCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{}, errors.New("error"))
 	fakeExit := func(int) {
 		panic("os.Exit called")
 	}
@@ -73,6 +83,8 @@ func TestMirrorConfig_SourceClusterGetConfigError(t *testing.T) {
 		increasePartitions: false,
 		dryRun:             false,
 		excludeConfigs:     nil,
+		safety:             noSafetyPolicy(),
+		renderer:           tableRenderer(),
 	}
 	assert.PanicsWithValue(t, "os.Exit called", m.mirrorTopicConfigs, "os.Exit was not called")
 
@@ -95,7 +107,7 @@ func TestMirrorConfig_DestinationClusterListError(t *testing.T) {
 	}}
 	topicName := "topic1"
 	sourceCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1Detail}, nil)
-	sourceCli.MockConfigurer.On("GetConfig", topicName).Return(topic1SrcConfigEntry, nil)
+	sourceCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{topicName: topic1SrcConfigEntry}, nil)
 	destinationCli.MockLister.On("List").Return(map[string]client.TopicDetail{}, errors.New("error"))
 	fakeExit := func(int) {
 		panic("os.Exit called")
@@ -109,6 +121,8 @@ func TestMirrorConfig_DestinationClusterListError(t *testing.T) {
 		increasePartitions: false,
 		dryRun:             false,
 		excludeConfigs:     nil,
+		safety:             noSafetyPolicy(),
+		renderer:           tableRenderer(),
 	}
 	assert.PanicsWithValue(t, "os.Exit called", m.mirrorTopicConfigs, "os.Exit was not called")
 
@@ -132,9 +146,9 @@ func TestMirrorConfig_DestinationClusterGetConfigError(t *testing.T) {
 	}}
 	topicName := "topic1"
 	sourceCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1Detail}, nil)
-	sourceCli.MockConfigurer.On("GetConfig", topicName).Return(topic1SrcConfigEntry, nil)
+	sourceCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{topicName: topic1SrcConfigEntry}, nil)
 	destinationCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1Detail}, nil)
-	destinationCli.MockConfigurer.On("GetConfig", topicName).Return([]client.ConfigEntry{}, errors.New("error"))
+	destinationCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{}, errors.New("error"))
 	fakeExit := func(int) {
 		panic("os.Exit called")
 	}
@@ -147,6 +161,8 @@ func TestMirrorConfig_DestinationClusterGetConfigError(t *testing.T) {
 		increasePartitions: false,
 		dryRun:             false,
 		excludeConfigs:     nil,
+		safety:             noSafetyPolicy(),
+		renderer:           tableRenderer(),
 	}
 	assert.PanicsWithValue(t, "os.Exit called", m.mirrorTopicConfigs, "os.Exit was not called")
 
@@ -177,9 +193,9 @@ func TestMirrorConfig_WhenTopicIsPresentAndConfigIsDifferent_Success(t *testing.
 	}}
 	topicName := "topic1"
 	sourceCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1Detail}, nil)
-	sourceCli.MockConfigurer.On("GetConfig", topicName).Return(topic1SrcConfigEntry, nil)
+	sourceCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{topicName: topic1SrcConfigEntry}, nil)
 	destinationCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1Detail}, nil)
-	destinationCli.MockConfigurer.On("GetConfig", topicName).Return(topic1DestConfigEntry, nil)
+	destinationCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{topicName: topic1DestConfigEntry}, nil)
 	m := &mirror{
 		sourceCli:          sourceCli,
 		destinationCli:     destinationCli,
@@ -187,6 +203,8 @@ func TestMirrorConfig_WhenTopicIsPresentAndConfigIsDifferent_Success(t *testing.
 		increasePartitions: false,
 		dryRun:             false,
 		excludeConfigs:     nil,
+		safety:             noSafetyPolicy(),
+		renderer:           tableRenderer(),
 	}
 	val2 := "val2"
 	destinationCli.MockConfigurer.On("UpdateConfig", []string{topicName}, map[string]*string{"key2": &val2}, false).Return(nil)
@@ -213,7 +231,7 @@ func TestMirrorConfig_WhenTopicIsNotPresentAndCreateTopicIsDisabled_Noop(t *test
 	}}
 	topicName := "topic1"
 	sourceCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1Detail}, nil)
-	sourceCli.MockConfigurer.On("GetConfig", topicName).Return(topic1SrcConfigEntry, nil)
+	sourceCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{topicName: topic1SrcConfigEntry}, nil)
 	destinationCli.MockLister.On("List").Return(map[string]client.TopicDetail{}, nil)
 	m := &mirror{
 		sourceCli:          sourceCli,
@@ -222,6 +240,8 @@ func TestMirrorConfig_WhenTopicIsNotPresentAndCreateTopicIsDisabled_Noop(t *test
 		increasePartitions: false,
 		dryRun:             false,
 		excludeConfigs:     nil,
+		safety:             noSafetyPolicy(),
+		renderer:           tableRenderer(),
 	}
 
 	m.mirrorTopicConfigs()
@@ -248,7 +268,7 @@ func TestMirrorConfig_WhenTopicIsNotPresentAndCreateTopicIsEnabled_Success(t *te
 	}}
 	topicName := "topic1"
 	sourceCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1Detail}, nil)
-	sourceCli.MockConfigurer.On("GetConfig", topicName).Return(topic1SrcConfigEntry, nil)
+	sourceCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{topicName: topic1SrcConfigEntry}, nil)
 	destinationCli.MockLister.On("List").Return(map[string]client.TopicDetail{}, nil)
 	destinationCli.MockCreator.On("Create", topicName, topic1Detail, false).Return(nil)
 	m := &mirror{
@@ -258,6 +278,8 @@ func TestMirrorConfig_WhenTopicIsNotPresentAndCreateTopicIsEnabled_Success(t *te
 		increasePartitions: false,
 		dryRun:             false,
 		excludeConfigs:     nil,
+		safety:             noSafetyPolicy(),
+		renderer:           tableRenderer(),
 	}
 
 	m.mirrorTopicConfigs()
@@ -266,6 +288,24 @@ func TestMirrorConfig_WhenTopicIsNotPresentAndCreateTopicIsEnabled_Success(t *te
 	destinationCli.assertExpectations(t)
 }
 
+func TestMirrorConfig_WhenTopicCreateIsRefusedBySafetyPolicy_DoesNotCreate(t *testing.T) {
+	sourceCli := &mockCreateOrUpdate{}
+	destinationCli := &mockCreateOrUpdate{}
+	topicName := "topic1"
+	sourceCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: {NumPartitions: 1, ReplicationFactor: 1}}, nil)
+	sourceCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{topicName: {}}, nil)
+	destinationCli.MockLister.On("List").Return(map[string]client.TopicDetail{}, nil)
+	safety := &base.MockSafetyGuard{}
+	safety.On("Check", model.OperationCreate, []string{topicName}).Return(errors.New("refused"))
+	m := &mirror{sourceCli: sourceCli, destinationCli: destinationCli, createTopics: true, safety: safety, renderer: tableRenderer()}
+
+	m.mirrorTopicConfigs()
+
+	safety.AssertExpectations(t)
+	destinationCli.MockCreator.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+	assert.Equal(t, int64(1), m.metrics.failures)
+}
+
 func TestMirrorConfig_WhenTopicIsNotPresentAndCreateTopicIsEnabled_DryRun(t *testing.T) {
 	sourceCli := &mockCreateOrUpdate{}
 	destinationCli := &mockCreateOrUpdate{}
@@ -282,7 +322,7 @@ func TestMirrorConfig_WhenTopicIsNotPresentAndCreateTopicIsEnabled_DryRun(t *tes
 	}}
 	topicName := "topic1"
 	sourceCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1Detail}, nil)
-	sourceCli.MockConfigurer.On("GetConfig", topicName).Return(topic1SrcConfigEntry, nil)
+	sourceCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{topicName: topic1SrcConfigEntry}, nil)
 	destinationCli.MockLister.On("List").Return(map[string]client.TopicDetail{}, nil)
 	m := &mirror{
 		sourceCli:          sourceCli,
@@ -291,6 +331,8 @@ func TestMirrorConfig_WhenTopicIsNotPresentAndCreateTopicIsEnabled_DryRun(t *tes
 		increasePartitions: false,
 		dryRun:             true,
 		excludeConfigs:     nil,
+		safety:             noSafetyPolicy(),
+		renderer:           tableRenderer(),
 	}
 
 	m.mirrorTopicConfigs()
@@ -327,9 +369,9 @@ func TestMirrorConfig_WhenPartitionCountIsDifferentAndFlagDisabled_Noop(t *testi
 	}}
 	topicName := "topic1"
 	sourceCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1SrcDetail}, nil)
-	sourceCli.MockConfigurer.On("GetConfig", topicName).Return(topic1SrcConfigEntry, nil)
+	sourceCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{topicName: topic1SrcConfigEntry}, nil)
 	destinationCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1DestDetail}, nil)
-	destinationCli.MockConfigurer.On("GetConfig", topicName).Return(topic1DestConfigEntry, nil)
+	destinationCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{topicName: topic1DestConfigEntry}, nil)
 	m := &mirror{
 		sourceCli:          sourceCli,
 		destinationCli:     destinationCli,
@@ -337,6 +379,8 @@ func TestMirrorConfig_WhenPartitionCountIsDifferentAndFlagDisabled_Noop(t *testi
 		increasePartitions: false,
 		dryRun:             false,
 		excludeConfigs:     nil,
+		safety:             noSafetyPolicy(),
+		renderer:           tableRenderer(),
 	}
 
 	m.mirrorTopicConfigs()
@@ -373,9 +417,9 @@ func TestMirrorConfig_WhenPartitionCountIsDifferentAndFlagEnabled_Success(t *tes
 	}}
 	topicName := "topic1"
 	sourceCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1SrcDetail}, nil)
-	sourceCli.MockConfigurer.On("GetConfig", topicName).Return(topic1SrcConfigEntry, nil)
+	sourceCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{topicName: topic1SrcConfigEntry}, nil)
 	destinationCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1DestDetail}, nil)
-	destinationCli.MockConfigurer.On("GetConfig", topicName).Return(topic1DestConfigEntry, nil)
+	destinationCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{topicName: topic1DestConfigEntry}, nil)
 	destinationCli.MockCreator.On("CreatePartitions", topicName, topic1SrcDetail.NumPartitions, [][]int32{}, false).Return(nil)
 	m := &mirror{
 		sourceCli:          sourceCli,
@@ -384,6 +428,8 @@ func TestMirrorConfig_WhenPartitionCountIsDifferentAndFlagEnabled_Success(t *tes
 		increasePartitions: true,
 		dryRun:             false,
 		excludeConfigs:     nil,
+		safety:             noSafetyPolicy(),
+		renderer:           tableRenderer(),
 	}
 
 	m.mirrorTopicConfigs()
@@ -419,9 +465,9 @@ func TestMirrorConfig_WhenSourcePartitionIsLessThanDestinationPartition_Noop(t *
 	}}
 	topicName := "topic1"
 	sourceCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1SrcDetail}, nil)
-	sourceCli.MockConfigurer.On("GetConfig", topicName).Return(topic1SrcConfigEntry, nil)
+	sourceCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{topicName: topic1SrcConfigEntry}, nil)
 	destinationCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1DestDetail}, nil)
-	destinationCli.MockConfigurer.On("GetConfig", topicName).Return(topic1DestConfigEntry, nil)
+	destinationCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{topicName: topic1DestConfigEntry}, nil)
 	m := &mirror{
 		sourceCli:          sourceCli,
 		destinationCli:     destinationCli,
@@ -429,6 +475,8 @@ func TestMirrorConfig_WhenSourcePartitionIsLessThanDestinationPartition_Noop(t *
 		increasePartitions: true,
 		dryRun:             false,
 		excludeConfigs:     nil,
+		safety:             noSafetyPolicy(),
+		renderer:           tableRenderer(),
 	}
 
 	m.mirrorTopicConfigs()
@@ -465,9 +513,9 @@ func TestMirrorConfig_WhenPartitionCountIsDifferentAndFlagEnabled_DryRun(t *test
 	}}
 	topicName := "topic1"
 	sourceCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1SrcDetail}, nil)
-	sourceCli.MockConfigurer.On("GetConfig", topicName).Return(topic1SrcConfigEntry, nil)
+	sourceCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{topicName: topic1SrcConfigEntry}, nil)
 	destinationCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1DestDetail}, nil)
-	destinationCli.MockConfigurer.On("GetConfig", topicName).Return(topic1DestConfigEntry, nil)
+	destinationCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{topicName: topic1DestConfigEntry}, nil)
 	m := &mirror{
 		sourceCli:          sourceCli,
 		destinationCli:     destinationCli,
@@ -475,6 +523,8 @@ func TestMirrorConfig_WhenPartitionCountIsDifferentAndFlagEnabled_DryRun(t *test
 		increasePartitions: true,
 		dryRun:             true,
 		excludeConfigs:     nil,
+		safety:             noSafetyPolicy(),
+		renderer:           tableRenderer(),
 	}
 
 	m.mirrorTopicConfigs()
@@ -511,9 +561,9 @@ func TestMirrorConfig_WhenPartitionCountAndConfigAreDifferent_Success(t *testing
 	}}
 	topicName := "topic1"
 	sourceCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1SrcDetail}, nil)
-	sourceCli.MockConfigurer.On("GetConfig", topicName).Return(topic1SrcConfigEntry, nil)
+	sourceCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{topicName: topic1SrcConfigEntry}, nil)
 	destinationCli.MockLister.On("List").Return(map[string]client.TopicDetail{topicName: topic1DestDetail}, nil)
-	destinationCli.MockConfigurer.On("GetConfig", topicName).Return(topic1DestConfigEntry, nil)
+	destinationCli.MockConfigurer.On("GetConfigs", []string{topicName}).Return(map[string][]client.ConfigEntry{topicName: topic1DestConfigEntry}, nil)
 	destinationCli.MockCreator.On("CreatePartitions", topicName, topic1SrcDetail.NumPartitions, [][]int32{}, false).Return(nil)
 	val2 := "val2"
 	destinationCli.MockConfigurer.On("UpdateConfig", []string{topicName}, map[string]*string{"key2": &val2}, false).Return(nil)
@@ -524,6 +574,260 @@ func TestMirrorConfig_WhenPartitionCountAndConfigAreDifferent_Success(t *testing
 		increasePartitions: true,
 		dryRun:             false,
 		excludeConfigs:     nil,
+		safety:             noSafetyPolicy(),
+		renderer:           tableRenderer(),
+	}
+
+	m.mirrorTopicConfigs()
+
+	sourceCli.assertExpectations(t)
+	destinationCli.assertExpectations(t)
+}
+
+func TestMirrorConfig_WithRules_MapsTopicAndTransformsConfigs(t *testing.T) {
+	sourceCli := &mockCreateOrUpdate{}
+	destinationCli := &mockCreateOrUpdate{}
+	topic1Detail := client.TopicDetail{
+		NumPartitions:     1,
+		ReplicationFactor: 1,
+	}
+	topic1SrcConfigEntry := []client.ConfigEntry{{
+		Name:  "retention.ms",
+		Value: "1000",
+	}, {
+		Name:  "segment.bytes",
+		Value: "100",
+	}}
+	topic1DestConfigEntry := []client.ConfigEntry{{
+		Name:  "retention.ms",
+		Value: "1000",
+	}, {
+		Name:  "segment.bytes",
+		Value: "200",
+	}}
+	rulesFile, err := ioutil.TempFile("", "rules-*.yaml")
+	require.NoError(t, err)
+	defer os.Remove(rulesFile.Name())
+	_, err = rulesFile.WriteString("topics:\n  - match: \".*\"\n    prefix: \"dr-\"\n" +
+		"configs:\n  - topics: \".*\"\n    include: [retention.ms]\n    transforms:\n      retention.ms:\n        scale: 2\n")
+	require.NoError(t, err)
+	rules, err := model.LoadMirrorRules(rulesFile.Name())
+	require.NoError(t, err)
+
+	sourceCli.MockLister.On("List").Return(map[string]client.TopicDetail{"topic1": topic1Detail}, nil)
+	sourceCli.MockConfigurer.On("GetConfigs", []string{"topic1"}).Return(map[string][]client.ConfigEntry{"topic1": topic1SrcConfigEntry}, nil)
+	destinationCli.MockLister.On("List").Return(map[string]client.TopicDetail{"dr-topic1": topic1Detail}, nil)
+	destinationCli.MockConfigurer.On("GetConfigs", []string{"dr-topic1"}).Return(map[string][]client.ConfigEntry{"dr-topic1": topic1DestConfigEntry}, nil)
+	m := &mirror{
+		sourceCli:      sourceCli,
+		destinationCli: destinationCli,
+		rules:          rules,
+		safety:         noSafetyPolicy(),
+		renderer:       tableRenderer(),
+	}
+	retention := "2000"
+	destinationCli.MockConfigurer.On("UpdateConfig", []string{"dr-topic1"}, map[string]*string{"retention.ms": &retention}, false).Return(nil)
+
+	m.mirrorTopicConfigs()
+
+	sourceCli.assertExpectations(t)
+	destinationCli.assertExpectations(t)
+}
+
+func TestMirrorConfig_WithTopicFilters_MirrorsOnlySelectedTopics(t *testing.T) {
+	sourceCli := &mockCreateOrUpdate{}
+	destinationCli := &mockCreateOrUpdate{}
+	topicDetail := client.TopicDetail{
+		NumPartitions:     1,
+		ReplicationFactor: 1,
+	}
+	srcConfigEntry := []client.ConfigEntry{{
+		Name:  "retention.ms",
+		Value: "1000",
+	}}
+	destConfigEntry := []client.ConfigEntry{{
+		Name:  "retention.ms",
+		Value: "2000",
+	}}
+
+	sourceCli.MockLister.On("List").Return(map[string]client.TopicDetail{"orders-1": topicDetail, "orders-2": topicDetail,
+		"orders-internal": topicDetail, "payments": topicDetail}, nil)
+	sourceCli.MockConfigurer.On("GetConfigs", []string{"orders-1", "orders-2"}).Return(map[string][]client.ConfigEntry{
+		"orders-1": srcConfigEntry, "orders-2": srcConfigEntry}, nil)
+	destinationCli.MockLister.On("List").Return(map[string]client.TopicDetail{"orders-1": topicDetail, "payments": topicDetail}, nil)
+	destinationCli.MockConfigurer.On("GetConfigs", []string{"orders-1"}).Return(map[string][]client.ConfigEntry{
+		"orders-1": destConfigEntry}, nil)
+	m := &mirror{
+		sourceCli:      sourceCli,
+		destinationCli: destinationCli,
+		includeTopics:  "orders-.*",
+		excludeTopics:  ".*-internal",
+		safety:         noSafetyPolicy(),
+		renderer:       tableRenderer(),
+	}
+	retention := "1000"
+	destinationCli.MockConfigurer.On("UpdateConfig", []string{"orders-1"}, map[string]*string{"retention.ms": &retention}, false).Return(nil)
+
+	m.mirrorTopicConfigs()
+
+	sourceCli.assertExpectations(t)
+	destinationCli.assertExpectations(t)
+}
+
+func TestMirrorConfig_WithTopicsWithOverrides_SkipsTopicsWithoutOverrides(t *testing.T) {
+	sourceCli := &mockCreateOrUpdate{}
+	destinationCli := &mockCreateOrUpdate{}
+	topicDetail := client.TopicDetail{
+		NumPartitions:     1,
+		ReplicationFactor: 1,
+	}
+	overriddenConfigEntry := []client.ConfigEntry{{
+		Name:   "retention.ms",
+		Value:  "1000",
+		Source: client.ConfigSourceTopic,
+	}}
+	defaultConfigEntry := []client.ConfigEntry{{
+		Name:   "retention.ms",
+		Value:  "2000",
+		Source: client.ConfigSourceDefault,
+	}}
+
+	sourceCli.MockLister.On("List").Return(map[string]client.TopicDetail{"topic1": topicDetail, "topic2": topicDetail}, nil)
+	sourceCli.MockConfigurer.On("GetConfigs", []string{"topic1", "topic2"}).Return(map[string][]client.ConfigEntry{
+		"topic1": overriddenConfigEntry, "topic2": defaultConfigEntry}, nil)
+	destinationCli.MockLister.On("List").Return(map[string]client.TopicDetail{"topic1": topicDetail, "topic2": topicDetail}, nil)
+	destinationCli.MockConfigurer.On("GetConfigs", []string{"topic1"}).Return(map[string][]client.ConfigEntry{
+		"topic1": defaultConfigEntry}, nil)
+	m := &mirror{
+		sourceCli:           sourceCli,
+		destinationCli:      destinationCli,
+		topicsWithOverrides: true,
+		safety:              noSafetyPolicy(),
+		renderer:            tableRenderer(),
+	}
+	retention := "1000"
+	destinationCli.MockConfigurer.On("UpdateConfig", []string{"topic1"}, map[string]*string{"retention.ms": &retention}, false).Return(nil)
+
+	m.mirrorTopicConfigs()
+
+	sourceCli.assertExpectations(t)
+	destinationCli.assertExpectations(t)
+}
+
+func TestMirrorConfig_Watch_MirrorsUntilCancelledAndCountsChanges(t *testing.T) {
+	sourceCli := &mockCreateOrUpdate{}
+	destinationCli := &mockCreateOrUpdate{}
+	topicDetail := client.TopicDetail{
+		NumPartitions:     1,
+		ReplicationFactor: 1,
+	}
+	srcConfigEntry := []client.ConfigEntry{{
+		Name:  "retention.ms",
+		Value: "1000",
+	}, {
+		Name:  "segment.bytes",
+		Value: "100",
+	}}
+	destConfigEntry := []client.ConfigEntry{{
+		Name:  "retention.ms",
+		Value: "2000",
+	}, {
+		Name:  "segment.bytes",
+		Value: "200",
+	}}
+	sourceCli.MockLister.On("List").Return(map[string]client.TopicDetail{"topic1": topicDetail, "topic2": topicDetail}, nil)
+	sourceCli.MockConfigurer.On("GetConfigs", []string{"topic1", "topic2"}).Return(map[string][]client.ConfigEntry{
+		"topic1": srcConfigEntry, "topic2": srcConfigEntry}, nil)
+	destinationCli.MockLister.On("List").Return(map[string]client.TopicDetail{"topic1": topicDetail}, nil)
+	destinationCli.MockConfigurer.On("GetConfigs", []string{"topic1"}).Return(map[string][]client.ConfigEntry{
+		"topic1": destConfigEntry}, nil)
+	destinationCli.MockConfigurer.On("UpdateConfig", []string{"topic1"}, mock.Anything, false).Return(nil)
+	destinationCli.MockCreator.On("Create", "topic2", topicDetail, false).Return(nil)
+	m := &mirror{
+		sourceCli:      sourceCli,
+		destinationCli: destinationCli,
+		createTopics:   true,
+		safety:         noSafetyPolicy(),
+		renderer:       tableRenderer(),
+	}
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	cancelFunc()
+
+	m.watch(ctx, time.Hour)
+
+	assert.Equal(t, int64(1), m.metrics.runs)
+	assert.Equal(t, int64(1), m.metrics.topicsCreated)
+	assert.Equal(t, int64(2), m.metrics.configsUpdated)
+	assert.Equal(t, int64(0), m.metrics.failures)
+	sourceCli.assertExpectations(t)
+	destinationCli.assertExpectations(t)
+}
+
+func TestMirrorConfig_Watch_CountsFailuresWithoutExiting(t *testing.T) {
+	sourceCli := &mockCreateOrUpdate{}
+	sourceCli.MockLister.On("List").Return(map[string]client.TopicDetail{}, errors.New("error"))
+	m := &mirror{
+		sourceCli: sourceCli,
+		safety:    noSafetyPolicy(),
+		renderer:  tableRenderer(),
+	}
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	cancelFunc()
+
+	m.watch(ctx, time.Hour)
+
+	assert.Equal(t, int64(1), m.metrics.runs)
+	assert.Equal(t, int64(1), m.metrics.failures)
+	sourceCli.assertExpectations(t)
+}
+
+func TestMirrorConfig_CreateTopic_WithSourceAssignmentOnUnknownBrokers_Fails(t *testing.T) {
+	sourceCli := &mockCreateOrUpdate{}
+	destinationCli := &mockCreateOrUpdate{}
+	topicDetail := client.TopicDetail{
+		NumPartitions:     2,
+		ReplicationFactor: 2,
+		ReplicaAssignment: map[int32][]int32{0: {1, 2}, 1: {2, 3}},
+	}
+	sourceCli.MockLister.On("List").Return(map[string]client.TopicDetail{"topic1": topicDetail}, nil)
+	sourceCli.MockConfigurer.On("GetConfigs", []string{"topic1"}).Return(map[string][]client.ConfigEntry{}, nil)
+	destinationCli.MockLister.On("List").Return(map[string]client.TopicDetail{}, nil)
+	destinationCli.MockDescriber.On("DescribeBrokers").Return([]client.Broker{{ID: 1}, {ID: 2}}, nil)
+	m := &mirror{
+		sourceCli:      sourceCli,
+		destinationCli: destinationCli,
+		createTopics:   true,
+		safety:         noSafetyPolicy(),
+		renderer:       tableRenderer(),
+	}
+
+	m.mirrorTopicConfigs()
+
+	assert.Equal(t, int64(1), m.metrics.failures)
+	destinationCli.MockCreator.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+	sourceCli.assertExpectations(t)
+	destinationCli.assertExpectations(t)
+}
+
+func TestMirrorConfig_CreateTopic_WithValidSourceAssignment(t *testing.T) {
+	sourceCli := &mockCreateOrUpdate{}
+	destinationCli := &mockCreateOrUpdate{}
+	topicDetail := client.TopicDetail{
+		NumPartitions:     2,
+		ReplicationFactor: 2,
+		ReplicaAssignment: map[int32][]int32{0: {1, 2}, 1: {2, 1}},
+	}
+	sourceCli.MockLister.On("List").Return(map[string]client.TopicDetail{"topic1": topicDetail}, nil)
+	sourceCli.MockConfigurer.On("GetConfigs", []string{"topic1"}).Return(map[string][]client.ConfigEntry{}, nil)
+	destinationCli.MockLister.On("List").Return(map[string]client.TopicDetail{}, nil)
+	destinationCli.MockDescriber.On("DescribeBrokers").Return([]client.Broker{{ID: 1}, {ID: 2}}, nil)
+	destinationCli.MockCreator.On("Create", "topic1", topicDetail, false).Return(nil)
+	m := &mirror{
+		sourceCli:      sourceCli,
+		destinationCli: destinationCli,
+		createTopics:   true,
+		safety:         noSafetyPolicy(),
+		renderer:       tableRenderer(),
 	}
 
 	m.mirrorTopicConfigs()
@@ -531,3 +835,91 @@ func TestMirrorConfig_WhenPartitionCountAndConfigAreDifferent_Success(t *testing
 	sourceCli.assertExpectations(t)
 	destinationCli.assertExpectations(t)
 }
+
+func TestMirrorConfig_CreateTopic_WithReplicationFactorOverride_DropsSourceAssignment(t *testing.T) {
+	sourceCli := &mockCreateOrUpdate{}
+	destinationCli := &mockCreateOrUpdate{}
+	topicDetail := client.TopicDetail{
+		NumPartitions:     2,
+		ReplicationFactor: 2,
+		ReplicaAssignment: map[int32][]int32{0: {1, 2}, 1: {2, 3}},
+	}
+	sourceCli.MockLister.On("List").Return(map[string]client.TopicDetail{"topic1": topicDetail}, nil)
+	sourceCli.MockConfigurer.On("GetConfigs", []string{"topic1"}).Return(map[string][]client.ConfigEntry{}, nil)
+	destinationCli.MockLister.On("List").Return(map[string]client.TopicDetail{}, nil)
+	destinationCli.MockCreator.On("Create", "topic1", client.TopicDetail{NumPartitions: 2, ReplicationFactor: 3}, false).Return(nil)
+	m := &mirror{
+		sourceCli:         sourceCli,
+		destinationCli:    destinationCli,
+		createTopics:      true,
+		replicationFactor: 3,
+		safety:            noSafetyPolicy(),
+		renderer:          tableRenderer(),
+	}
+
+	m.mirrorTopicConfigs()
+
+	sourceCli.assertExpectations(t)
+	destinationCli.assertExpectations(t)
+}
+
+func TestMirrorConfig_CreateTopic_RackAware(t *testing.T) {
+	sourceCli := &mockCreateOrUpdate{}
+	destinationCli := &mockCreateOrUpdate{}
+	topicDetail := client.TopicDetail{
+		NumPartitions:     2,
+		ReplicationFactor: 2,
+		ReplicaAssignment: map[int32][]int32{0: {7, 8}, 1: {8, 7}},
+	}
+	sourceCli.MockLister.On("List").Return(map[string]client.TopicDetail{"topic1": topicDetail}, nil)
+	sourceCli.MockConfigurer.On("GetConfigs", []string{"topic1"}).Return(map[string][]client.ConfigEntry{}, nil)
+	destinationCli.MockLister.On("List").Return(map[string]client.TopicDetail{}, nil)
+	destinationCli.MockDescriber.On("DescribeBrokers").Return([]client.Broker{
+		{ID: 1, Rack: "a"}, {ID: 2, Rack: "a"}, {ID: 3, Rack: "b"}, {ID: 4, Rack: "b"}}, nil)
+	destinationCli.MockCreator.On("Create", "topic1", client.TopicDetail{
+		NumPartitions:     2,
+		ReplicationFactor: 2,
+		ReplicaAssignment: map[int32][]int32{0: {1, 3}, 1: {3, 2}},
+	}, false).Return(nil)
+	m := &mirror{
+		sourceCli:      sourceCli,
+		destinationCli: destinationCli,
+		createTopics:   true,
+		rackAware:      true,
+		safety:         noSafetyPolicy(),
+		renderer:       tableRenderer(),
+	}
+
+	m.mirrorTopicConfigs()
+
+	sourceCli.assertExpectations(t)
+	destinationCli.assertExpectations(t)
+}
+
+func TestConfigChanges_SortsTheChangedConfigs(t *testing.T) {
+	changelogs := diff.Changelog{
+		{Type: diff.UPDATE, Path: []string{"segment.bytes"}, From: "100", To: "200"},
+		{Type: diff.CREATE, Path: []string{"retention.ms"}, To: "1000"},
+	}
+
+	assert.Equal(t, []ui.ConfigChange{{Config: "retention.ms", To: "1000"}, {Config: "segment.bytes", From: "100", To: "200"}},
+		configChanges(changelogs))
+}
+
+func TestCreatedConfigs_SortsTheConfigs(t *testing.T) {
+	retention, segment := "1000", "100"
+
+	assert.Equal(t, []ui.ConfigChange{{Config: "retention.ms", To: "1000"}, {Config: "segment.bytes", To: "100"}},
+		createdConfigs(map[string]*string{"segment.bytes": &segment, "retention.ms": &retention}))
+}
+
+func tableRenderer() *ui.Renderer {
+	renderer, _ := ui.NewRenderer(ui.OutputTable, ioutil.Discard)
+	return renderer
+}
+
+func noSafetyPolicy() *base.MockSafetyGuard {
+	safety := &base.MockSafetyGuard{}
+	safety.On("Check", mock.Anything, mock.Anything).Return(nil)
+	return safety
+}
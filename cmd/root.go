@@ -4,7 +4,13 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/gojek/kat/cmd/acl"
+	"github.com/gojek/kat/cmd/audit"
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/cmd/cluster"
 	"github.com/gojek/kat/cmd/mirror"
+	"github.com/gojek/kat/cmd/schema"
+	"github.com/gojek/kat/cmd/snapshot"
 
 	"github.com/gojek/kat/logger"
 	"github.com/spf13/cobra"
@@ -14,13 +20,24 @@ var cliCmd = &cobra.Command{
 	Use:     "kat",
 	Short:   "Tool used for admin activities against specified kafka brokers",
 	Version: fmt.Sprintf("%s (Commit: %s)", "0.0.1", "n/a"),
+	PersistentPreRun: func(command *cobra.Command, args []string) {
+		base.NewCobraUtil(command).SetKafkaVersion()
+	},
 }
 
 func init() {
 	cobra.OnInitialize()
+	base.AddSafetyPolicyFlags(cliCmd)
+	base.AddOutputFlag(cliCmd)
+	base.AddKafkaVersionFlag(cliCmd)
 	cliCmd.AddCommand(topicCmd)
 	cliCmd.AddCommand(mirror.MirrorCmd)
 	cliCmd.AddCommand(consumerGroupCmd)
+	cliCmd.AddCommand(audit.AuditCmd)
+	cliCmd.AddCommand(cluster.ClusterCmd)
+	cliCmd.AddCommand(schema.SchemaCmd)
+	cliCmd.AddCommand(snapshot.SnapshotCmd)
+	cliCmd.AddCommand(acl.ACLCmd)
 }
 
 func Execute() {
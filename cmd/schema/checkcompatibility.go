@@ -0,0 +1,80 @@
+package schema
+
+import (
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/pkg/io"
+	"github.com/spf13/cobra"
+)
+
+type file interface {
+	Read(fileName string) ([]byte, error)
+}
+
+type checkCompatibility struct {
+	client.SchemaRegistry
+	file
+	subject    string
+	version    string
+	schemaFile string
+	schemaType string
+}
+
+var checkCompatibilityCmd = &cobra.Command{
+	Use:   "compatibility",
+	Short: "Check whether a schema is compatible with a version of the schema of a subject",
+	Run: func(command *cobra.Command, args []string) {
+		cobraUtil := base.NewCobraUtil(command)
+		c := checkCompatibility{
+			SchemaRegistry: getRegistry(cobraUtil),
+			file:           &io.File{},
+			subject:        getSubject(cobraUtil),
+			version:        cobraUtil.GetStringArg("version"),
+			schemaFile:     cobraUtil.GetStringArg("file"),
+			schemaType:     cobraUtil.GetStringArg("schema-type"),
+		}
+		c.checkCompatibility()
+	},
+}
+
+func init() {
+	addSubjectFlags(checkCompatibilityCmd)
+	checkCompatibilityCmd.PersistentFlags().StringP("file", "f", "", "File with the schema to check")
+	checkCompatibilityCmd.PersistentFlags().StringP("version", "v", client.SchemaVersionLatest, "Version of the subject to check against")
+	checkCompatibilityCmd.PersistentFlags().String("schema-type", "", "Type of the schema, one of AVRO|PROTOBUF|JSON, defaults to the type of the version")
+	if err := checkCompatibilityCmd.MarkPersistentFlagRequired("file"); err != nil {
+		logger.Fatal(err)
+	}
+}
+
+func (c *checkCompatibility) checkCompatibility() {
+	data, err := c.Read(c.schemaFile)
+	if err != nil {
+		logger.Fatalf("Error while reading the schema file - %v\n", err)
+	}
+	schemaType := c.schemaType
+	if schemaType == "" {
+		current, schemaErr := c.GetSchema(c.subject, c.version)
+		if schemaErr != nil {
+			logger.Fatalf("Error while fetching version %v of subject %v - %v\n", c.version, c.subject, schemaErr)
+		}
+		schemaType = current.SchemaType
+	}
+	level, err := c.CompatibilityLevel(c.subject)
+	if err != nil {
+		logger.Fatalf("Error while fetching the compatibility level of subject %v - %v\n", c.subject, err)
+	}
+
+	result, err := c.CheckCompatibility(c.subject, c.version, &client.Schema{SchemaType: schemaType, Schema: string(data)})
+	if err != nil {
+		logger.Fatalf("Error while checking the compatibility - %v\n", err)
+	}
+	if !result.IsCompatible {
+		for _, message := range result.Messages {
+			logger.Errorf("%v\n", message)
+		}
+		logger.Fatalf("Schema is not compatible with version %v of subject %v, with compatibility level %v\n", c.version, c.subject, level)
+	}
+	logger.Infof("Schema is compatible with version %v of subject %v, with compatibility level %v\n", c.version, c.subject, level)
+}
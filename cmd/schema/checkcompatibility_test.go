@@ -0,0 +1,52 @@
+package schema
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/pkg/io"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeSchemaFile(t *testing.T, schema string) string {
+	dir, err := ioutil.TempDir("", "schema")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	fileName := filepath.Join(dir, "schema.avsc")
+	require.NoError(t, ioutil.WriteFile(fileName, []byte(schema), 0600))
+	return fileName
+}
+
+func TestCheckCompatibility_Compatible(t *testing.T) {
+	registry := &client.MockSchemaRegistry{}
+	registry.On("GetSchema", "orders-value", client.SchemaVersionLatest).Return(&client.Schema{SchemaType: client.SchemaTypeProtobuf}, nil)
+	registry.On("CompatibilityLevel", "orders-value").Return("BACKWARD", nil)
+	registry.On("CheckCompatibility", "orders-value", client.SchemaVersionLatest,
+		&client.Schema{SchemaType: client.SchemaTypeProtobuf, Schema: "message A {}"}).Return(&client.CompatibilityResult{IsCompatible: true}, nil)
+	c := checkCompatibility{SchemaRegistry: registry, file: &io.File{}, subject: "orders-value", version: client.SchemaVersionLatest,
+		schemaFile: writeSchemaFile(t, "message A {}")}
+
+	c.checkCompatibility()
+
+	registry.AssertExpectations(t)
+}
+
+func TestCheckCompatibility_Incompatible(t *testing.T) {
+	registry := &client.MockSchemaRegistry{}
+	registry.On("CompatibilityLevel", "orders-value").Return("FULL", nil)
+	registry.On("CheckCompatibility", "orders-value", "2", &client.Schema{SchemaType: client.SchemaTypeAvro, Schema: `"int"`}).
+		Return(&client.CompatibilityResult{IsCompatible: false, Messages: []string{"type changed"}}, nil)
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	c := checkCompatibility{SchemaRegistry: registry, file: &io.File{}, subject: "orders-value", version: "2",
+		schemaFile: writeSchemaFile(t, `"int"`), schemaType: client.SchemaTypeAvro}
+
+	assert.PanicsWithValue(t, "os.Exit called", c.checkCompatibility, "os.Exit was not called")
+	registry.AssertExpectations(t)
+	registry.AssertNotCalled(t, "GetSchema", "orders-value", "2")
+}
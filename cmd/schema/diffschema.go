@@ -0,0 +1,106 @@
+package schema
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/gojek/kat/ui"
+	"github.com/spf13/cobra"
+)
+
+type diffSchema struct {
+	client.SchemaRegistry
+	renderer    *ui.Renderer
+	subject     string
+	fromVersion string
+	toVersion   string
+}
+
+var diffSchemaCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare two versions of the schema of a subject",
+	Run: func(command *cobra.Command, args []string) {
+		cobraUtil := base.NewCobraUtil(command)
+		d := diffSchema{
+			SchemaRegistry: getRegistry(cobraUtil),
+			renderer:       cobraUtil.GetRenderer(),
+			subject:        getSubject(cobraUtil),
+			fromVersion:    cobraUtil.GetStringArg("from-version"),
+			toVersion:      cobraUtil.GetStringArg("to-version"),
+		}
+		d.diffSchemas()
+	},
+}
+
+func init() {
+	addSubjectFlags(diffSchemaCmd)
+	diffSchemaCmd.PersistentFlags().String("from-version", "", "Version to compare from, defaults to the version before the to-version")
+	diffSchemaCmd.PersistentFlags().String("to-version", client.SchemaVersionLatest, "Version to compare to")
+}
+
+func (d *diffSchema) diffSchemas() {
+	toSchema, err := d.GetSchema(d.subject, d.toVersion)
+	if err != nil {
+		logger.Fatalf("Error while fetching version %v of subject %v - %v\n", d.toVersion, d.subject, err)
+	}
+	fromVersion := d.fromVersion
+	if fromVersion == "" {
+		if fromVersion, err = d.previousVersion(toSchema.Version); err != nil {
+			logger.Fatalf("Error while finding the version before %d of subject %v - %v\n", toSchema.Version, d.subject, err)
+		}
+	}
+	fromSchema, err := d.GetSchema(d.subject, fromVersion)
+	if err != nil {
+		logger.Fatalf("Error while fetching version %v of subject %v - %v\n", fromVersion, d.subject, err)
+	}
+
+	lines := model.DiffLines(model.FormatSchema(fromSchema), model.FormatSchema(toSchema))
+	if d.renderer.Format() == ui.OutputTable {
+		fmt.Printf("Subject: %v, Version %d -> %d\n", d.subject, fromSchema.Version, toSchema.Version)
+		for _, line := range lines {
+			fmt.Println(line)
+		}
+		return
+	}
+	rows := make([]ui.Row, 0, len(lines))
+	for _, line := range lines {
+		change, text := diffChange(line)
+		rows = append(rows, ui.SchemaDiff(fromSchema.Version, toSchema.Version, change, text))
+	}
+	if err = d.renderer.Render(rows); err != nil {
+		logger.Fatalf("Error while writing the differences - %v\n", err)
+	}
+}
+
+// diffChange returns the change of a line of model.DiffLines, and the line without its prefix
+func diffChange(line string) (string, string) {
+	switch line[:2] {
+	case "+ ":
+		return "Added", line[2:]
+	case "- ":
+		return "Removed", line[2:]
+	default:
+		return "Unchanged", line[2:]
+	}
+}
+
+func (d *diffSchema) previousVersion(version int) (string, error) {
+	versions, err := d.Versions(d.subject)
+	if err != nil {
+		return "", err
+	}
+	previous := -1
+	for _, v := range versions {
+		if v < version && v > previous {
+			previous = v
+		}
+	}
+	if previous < 0 {
+		return "", fmt.Errorf("version %d is the first version", version)
+	}
+	return strconv.Itoa(previous), nil
+}
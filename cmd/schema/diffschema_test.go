@@ -0,0 +1,52 @@
+package schema
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/ui"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffSchemas_ComparesWithPreviousVersion(t *testing.T) {
+	registry := &client.MockSchemaRegistry{}
+	registry.On("GetSchema", "orders-value", client.SchemaVersionLatest).Return(&client.Schema{Version: 5, Schema: `"long"`}, nil)
+	registry.On("Versions", "orders-value").Return([]int{1, 3, 5}, nil)
+	registry.On("GetSchema", "orders-value", "3").Return(&client.Schema{Version: 3, Schema: `"int"`}, nil)
+	renderer, _ := ui.NewRenderer(ui.OutputTable, &bytes.Buffer{})
+	d := diffSchema{SchemaRegistry: registry, renderer: renderer, subject: "orders-value", toVersion: client.SchemaVersionLatest}
+
+	d.diffSchemas()
+
+	registry.AssertExpectations(t)
+}
+
+func TestDiffSchemas_ComparesGivenVersions(t *testing.T) {
+	registry := &client.MockSchemaRegistry{}
+	registry.On("GetSchema", "orders-value", "3").Return(&client.Schema{Version: 3, Schema: `"long"`}, nil)
+	registry.On("GetSchema", "orders-value", "1").Return(&client.Schema{Version: 1, Schema: `"int"`}, nil)
+	var out bytes.Buffer
+	renderer, _ := ui.NewRenderer(ui.OutputCSV, &out)
+	d := diffSchema{SchemaRegistry: registry, renderer: renderer, subject: "orders-value", fromVersion: "1", toVersion: "3"}
+
+	d.diffSchemas()
+
+	assert.Equal(t, "From Version,To Version,Change,Line\n1,3,Added,\"\"\"long\"\"\"\n1,3,Removed,\"\"\"int\"\"\"\n", out.String())
+	registry.AssertExpectations(t)
+	registry.AssertNotCalled(t, "Versions", "orders-value")
+}
+
+func TestDiffSchemas_FailsForFirstVersion(t *testing.T) {
+	registry := &client.MockSchemaRegistry{}
+	registry.On("GetSchema", "orders-value", client.SchemaVersionLatest).Return(&client.Schema{Version: 1, Schema: `"int"`}, nil)
+	registry.On("Versions", "orders-value").Return([]int{1}, nil)
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	d := diffSchema{SchemaRegistry: registry, subject: "orders-value", toVersion: client.SchemaVersionLatest}
+
+	assert.PanicsWithValue(t, "os.Exit called", d.diffSchemas, "os.Exit was not called")
+	registry.AssertExpectations(t)
+}
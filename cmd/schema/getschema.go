@@ -0,0 +1,51 @@
+package schema
+
+import (
+	"fmt"
+
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/gojek/kat/ui"
+	"github.com/spf13/cobra"
+)
+
+type getSchema struct {
+	client.SchemaRegistry
+	renderer *ui.Renderer
+	subject  string
+	version  string
+}
+
+var getSchemaCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Print a version of the schema of a subject",
+	Run: func(command *cobra.Command, args []string) {
+		cobraUtil := base.NewCobraUtil(command)
+		g := getSchema{SchemaRegistry: getRegistry(cobraUtil), renderer: cobraUtil.GetRenderer(), subject: getSubject(cobraUtil),
+			version: cobraUtil.GetStringArg("version")}
+		g.getSchema()
+	},
+}
+
+func init() {
+	addSubjectFlags(getSchemaCmd)
+	getSchemaCmd.PersistentFlags().StringP("version", "v", client.SchemaVersionLatest, "Version of the schema")
+}
+
+func (g *getSchema) getSchema() {
+	schema, err := g.GetSchema(g.subject, g.version)
+	if err != nil {
+		logger.Fatalf("Error while fetching version %v of subject %v - %v\n", g.version, g.subject, err)
+	}
+	if g.renderer.Format() == ui.OutputTable {
+		fmt.Printf("Subject: %v, Version: %d, Type: %v, Schema ID: %d\n", schema.Subject, schema.Version, schema.Type(), schema.ID)
+		fmt.Println(model.FormatSchema(schema))
+		return
+	}
+	row := ui.SchemaVersion(schema.Subject, schema.Version, schema.Type(), schema.ID, schema.Schema)
+	if err = g.renderer.Render([]ui.Row{row}); err != nil {
+		logger.Fatalf("Error while writing the schema - %v\n", err)
+	}
+}
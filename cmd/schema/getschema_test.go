@@ -0,0 +1,48 @@
+package schema
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/ui"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSchema_Success(t *testing.T) {
+	registry := &client.MockSchemaRegistry{}
+	registry.On("GetSchema", "orders-value", "2").Return(&client.Schema{ID: 2, Version: 2, Schema: `"string"`}, nil)
+	renderer, _ := ui.NewRenderer(ui.OutputTable, &bytes.Buffer{})
+	g := getSchema{SchemaRegistry: registry, renderer: renderer, subject: "orders-value", version: "2"}
+
+	g.getSchema()
+
+	registry.AssertExpectations(t)
+}
+
+func TestGetSchema_RendersTheSchemaInTheOutputFormat(t *testing.T) {
+	registry := &client.MockSchemaRegistry{}
+	registry.On("GetSchema", "orders-value", "2").Return(&client.Schema{ID: 7, Subject: "orders-value", Version: 2, Schema: `"string"`}, nil)
+	var out bytes.Buffer
+	renderer, _ := ui.NewRenderer(ui.OutputJSON, &out)
+	g := getSchema{SchemaRegistry: registry, renderer: renderer, subject: "orders-value", version: "2"}
+
+	g.getSchema()
+
+	assert.JSONEq(t, `[{"subject": "orders-value", "version": 2, "schemaType": "AVRO", "schemaId": 7, "schema": "\"string\""}]`, out.String())
+	registry.AssertExpectations(t)
+}
+
+func TestGetSchema_Failure(t *testing.T) {
+	registry := &client.MockSchemaRegistry{}
+	registry.On("GetSchema", "orders-value", client.SchemaVersionLatest).Return(&client.Schema{}, errors.New("error"))
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	g := getSchema{SchemaRegistry: registry, subject: "orders-value", version: client.SchemaVersionLatest}
+
+	assert.PanicsWithValue(t, "os.Exit called", g.getSchema, "os.Exit was not called")
+	registry.AssertExpectations(t)
+}
@@ -0,0 +1,56 @@
+package schema
+
+import (
+	"sort"
+
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/gojek/kat/ui"
+	"github.com/spf13/cobra"
+)
+
+type listSchema struct {
+	client.SchemaRegistry
+	renderer *ui.Renderer
+	topic    string
+}
+
+var listSchemaCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the subjects with their latest schema versions",
+	Run: func(command *cobra.Command, args []string) {
+		cobraUtil := base.NewCobraUtil(command)
+		l := listSchema{SchemaRegistry: getRegistry(cobraUtil), renderer: cobraUtil.GetRenderer(), topic: cobraUtil.GetStringArg("topic")}
+		l.listSchemas()
+	},
+}
+
+func init() {
+	listSchemaCmd.PersistentFlags().StringP("topic", "t", "", "List only the key and value subjects of the topic")
+}
+
+func (l *listSchema) listSchemas() {
+	subjects, err := l.Subjects()
+	if err != nil {
+		logger.Fatalf("Error while listing the subjects - %v\n", err)
+	}
+	sort.Strings(subjects)
+
+	rows := make([]ui.Row, 0, len(subjects))
+	for _, subject := range subjects {
+		topic := model.SubjectTopic(subject)
+		if l.topic != "" && topic != l.topic {
+			continue
+		}
+		schema, schemaErr := l.GetSchema(subject, client.SchemaVersionLatest)
+		if schemaErr != nil {
+			logger.Fatalf("Error while fetching the latest schema of subject %v - %v\n", subject, schemaErr)
+		}
+		rows = append(rows, ui.SchemaSubject(subject, topic, schema.Version, schema.Type(), schema.ID))
+	}
+	if err = l.renderer.Render(rows); err != nil {
+		logger.Fatalf("Error while writing the subjects - %v\n", err)
+	}
+}
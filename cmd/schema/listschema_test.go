@@ -0,0 +1,49 @@
+package schema
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/ui"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	logger.SetDummyLogger()
+}
+
+func fakeExit(int) {
+	panic("os.Exit called")
+}
+
+func TestListSchemas_FiltersByTopic(t *testing.T) {
+	registry := &client.MockSchemaRegistry{}
+	registry.On("Subjects").Return([]string{"payments-value", "orders-value", "orders-key"}, nil)
+	registry.On("GetSchema", "orders-key", client.SchemaVersionLatest).Return(&client.Schema{ID: 1, Version: 1}, nil)
+	registry.On("GetSchema", "orders-value", client.SchemaVersionLatest).Return(&client.Schema{ID: 2, Version: 3}, nil)
+	var out bytes.Buffer
+	renderer, _ := ui.NewRenderer(ui.OutputCSV, &out)
+	l := listSchema{SchemaRegistry: registry, renderer: renderer, topic: "orders"}
+
+	l.listSchemas()
+
+	assert.Equal(t, "Subject,Topic,Latest Version,Type,Schema ID\norders-key,orders,1,AVRO,1\norders-value,orders,3,AVRO,2\n", out.String())
+	registry.AssertExpectations(t)
+	registry.AssertNotCalled(t, "GetSchema", "payments-value", client.SchemaVersionLatest)
+}
+
+func TestListSchemas_Failure(t *testing.T) {
+	registry := &client.MockSchemaRegistry{}
+	registry.On("Subjects").Return([]string{}, errors.New("error"))
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	l := listSchema{SchemaRegistry: registry}
+
+	assert.PanicsWithValue(t, "os.Exit called", l.listSchemas, "os.Exit was not called")
+	registry.AssertExpectations(t)
+}
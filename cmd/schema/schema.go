@@ -0,0 +1,49 @@
+package schema
+
+import (
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+var SchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Commands on the schemas in the schema registry",
+}
+
+func init() {
+	base.AddSchemaRegistryFlags(SchemaCmd)
+	if err := SchemaCmd.MarkPersistentFlagRequired("schema-registry-url"); err != nil {
+		logger.Fatal(err)
+	}
+
+	SchemaCmd.AddCommand(listSchemaCmd)
+	SchemaCmd.AddCommand(getSchemaCmd)
+	SchemaCmd.AddCommand(diffSchemaCmd)
+	SchemaCmd.AddCommand(checkCompatibilityCmd)
+}
+
+// addSubjectFlags adds the flags to pass the subject, or the topic of the subject
+func addSubjectFlags(command *cobra.Command) {
+	command.PersistentFlags().StringP("subject", "s", "", "Subject of the schema")
+	command.PersistentFlags().StringP("topic", "t", "", "Topic of the schema, instead of the subject")
+	command.PersistentFlags().Bool("key", false, "Use the key schema of the topic instead of the value schema")
+}
+
+func getSubject(cobraUtil *base.CobraUtil) string {
+	subject := cobraUtil.GetStringArg("subject")
+	topic := cobraUtil.GetStringArg("topic")
+	if (subject == "") == (topic == "") {
+		logger.Fatalf("One of --subject or --topic should be passed\n")
+	}
+	if subject != "" {
+		return subject
+	}
+	return model.TopicSubject(topic, cobraUtil.GetBoolArg("key"))
+}
+
+func getRegistry(cobraUtil *base.CobraUtil) client.SchemaRegistry {
+	return cobraUtil.GetSchemaRegistry()
+}
@@ -0,0 +1,49 @@
+package snapshot
+
+import (
+	"github.com/gojek/kat/cmd/base"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/model"
+	"github.com/gojek/kat/ui"
+	"github.com/spf13/cobra"
+)
+
+type diff struct {
+	renderer *ui.Renderer
+	before   string
+	after    string
+}
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <before snapshot> <after snapshot>",
+	Short: "Compare the topics, assignments, configs, acls and consumer group offsets of two snapshots, without connecting to a cluster",
+	Args:  cobra.ExactArgs(2),
+	Run: func(command *cobra.Command, args []string) {
+		d := diff{renderer: base.NewCobraUtil(command).GetRenderer(), before: args[0], after: args[1]}
+		d.diff()
+	},
+}
+
+func (d *diff) diff() {
+	before, err := model.LoadClusterSnapshot(d.before)
+	if err != nil {
+		logger.Fatalf("Error while reading the snapshot - %v\n", err)
+	}
+	after, err := model.LoadClusterSnapshot(d.after)
+	if err != nil {
+		logger.Fatalf("Error while reading the snapshot - %v\n", err)
+	}
+
+	diffs := model.DiffSnapshots(before, after)
+	if len(diffs) == 0 {
+		logger.Info("No differences found between the snapshots")
+	}
+	rows := make([]ui.Row, 0, len(diffs))
+	for _, difference := range diffs {
+		rows = append(rows, ui.SnapshotDifference(difference.Type, difference.Resource, difference.Field, difference.Source,
+			difference.Destination))
+	}
+	if err = d.renderer.Render(rows); err != nil {
+		logger.Fatalf("Error while writing the differences - %v\n", err)
+	}
+}
@@ -0,0 +1,64 @@
+package snapshot
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	logger.SetDummyLogger()
+}
+
+func writeSnapshots(t *testing.T, snapshots ...string) (string, []string) {
+	dir, err := ioutil.TempDir("", "snapshot")
+	require.NoError(t, err)
+	var files []string
+	for i, data := range snapshots {
+		file := filepath.Join(dir, fmt.Sprintf("snapshot-%d.json", i))
+		require.NoError(t, ioutil.WriteFile(file, []byte(data), 0644))
+		files = append(files, file)
+	}
+	return dir, files
+}
+
+func TestDiff_RendersTheDifferences(t *testing.T) {
+	dir, files := writeSnapshots(t,
+		`{"formatVersion": 1, "topics": {"orders": {"numPartitions": 1, "replicationFactor": 1}}}`,
+		`{"formatVersion": 1, "topics": {"orders": {"numPartitions": 2, "replicationFactor": 1}}}`)
+	defer os.RemoveAll(dir)
+	var out bytes.Buffer
+	renderer, err := ui.NewRenderer(ui.OutputJSON, &out)
+	require.NoError(t, err)
+	d := diff{renderer: renderer, before: files[0], after: files[1]}
+
+	d.diff()
+
+	assert.JSONEq(t, `[{"type": "Partitions", "resource": "orders", "before": "1", "after": "2"}]`, out.String())
+}
+
+func TestDiff_ExitsForUnsupportedSnapshot(t *testing.T) {
+	dir, files := writeSnapshots(t, `{"formatVersion": 1, "topics": {}}`, `{"formatVersion": 99, "topics": {}}`)
+	defer os.RemoveAll(dir)
+	fakeExit := func(int) {
+		panic("os.Exit called")
+	}
+	patch := monkey.Patch(os.Exit, fakeExit)
+	defer patch.Unpatch()
+	var out bytes.Buffer
+	renderer, err := ui.NewRenderer(ui.OutputJSON, &out)
+	require.NoError(t, err)
+	d := diff{renderer: renderer, before: files[0], after: files[1]}
+
+	assert.PanicsWithValue(t, "os.Exit called", d.diff, "os.Exit was not called")
+	assert.Empty(t, out.String())
+}
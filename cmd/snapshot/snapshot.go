@@ -0,0 +1,14 @@
+package snapshot
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var SnapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Offline commands on the snapshots written by cluster snapshot",
+}
+
+func init() {
+	SnapshotCmd.AddCommand(diffCmd)
+}
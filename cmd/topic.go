@@ -6,6 +6,7 @@ import (
 	"github.com/gojek/kat/cmd/delete"
 	"github.com/gojek/kat/cmd/describe"
 	"github.com/gojek/kat/cmd/list"
+	"github.com/gojek/kat/cmd/message"
 	"github.com/gojek/kat/logger"
 	"github.com/spf13/cobra"
 )
@@ -23,9 +24,17 @@ func init() {
 
 	topicCmd.AddCommand(list.ListTopicCmd)
 	topicCmd.AddCommand(delete.DeleteTopicCmd)
+	topicCmd.AddCommand(delete.RestoreTopicCmd)
 	topicCmd.AddCommand(describe.DescribeTopicCmd)
 	topicCmd.AddCommand(admin.IncreaseReplicationFactorCmd)
 	topicCmd.AddCommand(admin.ReassignPartitionsCmd)
 	topicCmd.AddCommand(config.ConfigCmd)
+	topicCmd.AddCommand(message.CopyTopicCmd)
+	topicCmd.AddCommand(message.ConsumeTopicCmd)
+	topicCmd.AddCommand(message.ProduceTopicCmd)
+	topicCmd.AddCommand(message.GrepTopicCmd)
+	topicCmd.AddCommand(message.TruncateTopicCmd)
+	topicCmd.AddCommand(message.TopicOffsetsCmd)
+	topicCmd.AddCommand(list.TopicUsageCmd)
 
 }
@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"io"
 	"os"
 
 	"github.com/sirupsen/logrus/hooks/test"
@@ -27,6 +28,11 @@ func SetupLogger(configuredLevel string) {
 	}
 }
 
+// SetOutput redirects the logs, eg: to stderr when the results are written to stdout in a machine readable format
+func SetOutput(out io.Writer) {
+	logger.SetOutput(out)
+}
+
 func SetDummyLogger() {
 	logger, _ = test.NewNullLogger()
 }
@@ -0,0 +1,50 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Shopify/sarama"
+)
+
+// ACL is a single access control entry bound to a resource
+type ACL struct {
+	ResourceType   string `json:"resourceType" yaml:"resourceType"`
+	ResourceName   string `json:"resourceName" yaml:"resourceName"`
+	PatternType    string `json:"patternType" yaml:"patternType"`
+	Principal      string `json:"principal" yaml:"principal"`
+	Host           string `json:"host" yaml:"host"`
+	Operation      string `json:"operation" yaml:"operation"`
+	PermissionType string `json:"permissionType" yaml:"permissionType"`
+}
+
+func (a ACL) String() string {
+	return fmt.Sprintf("%v %v %v on %v:%v (%v) from %v", a.Principal, a.PermissionType, a.Operation, a.ResourceType,
+		a.ResourceName, a.PatternType, a.Host)
+}
+
+// ACLFilter selects ACLs by the names of their fields, the empty fields match any value.
+// The pattern type match selects the literal and prefixed ACLs applying to the resource name.
+type ACLFilter struct {
+	ResourceType   string
+	ResourceName   string
+	PatternType    string
+	Principal      string
+	Host           string
+	Operation      string
+	PermissionType string
+}
+
+type ACLLister interface {
+	ListACLs() ([]ACL, error)
+}
+
+type ACLCreator interface {
+	CreateACLs(acls []ACL) error
+}
+
+// IsSecurityDisabled returns true for the error of listing the ACLs of a cluster without an authorizer,
+// which has no ACLs
+func IsSecurityDisabled(err error) bool {
+	return errors.Is(err, sarama.ErrSecurityDisabled)
+}
@@ -0,0 +1,12 @@
+package client
+
+// BrokerConfigLister lists the configs set dynamically on the brokers, which are not part of the server.properties of the brokers
+type BrokerConfigLister interface {
+	// ListDynamicBrokerConfigs returns the dynamic configs per broker id, the cluster wide defaults are under the empty id
+	ListDynamicBrokerConfigs() (map[string]map[string]string, error)
+}
+
+type BrokerConfigUpdater interface {
+	// UpdateBrokerConfigs sets the configs dynamically on the broker, or as cluster wide defaults for the empty id
+	UpdateBrokerConfigs(broker string, configs map[string]string) error
+}
@@ -1,5 +1,11 @@
 package client
 
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
 type TopicDetail struct {
 	NumPartitions     int32
 	ReplicationFactor int16
@@ -23,6 +29,12 @@ type PartitionMetadata struct {
 	OfflineReplicas []int32
 }
 
+type Broker struct {
+	ID   int32
+	Addr string
+	Rack string
+}
+
 type ConfigResource struct {
 	Type        int
 	Name        string
@@ -39,6 +51,39 @@ type ConfigEntry struct {
 	Synonyms  []*ConfigSynonym
 }
 
+// Sources of a config value, as reported by the DescribeConfigs api
+const (
+	ConfigSourceUnknown              = "Unknown"
+	ConfigSourceTopic                = "Topic"
+	ConfigSourceDynamicBroker        = "DynamicBroker"
+	ConfigSourceDynamicDefaultBroker = "DynamicDefaultBroker"
+	ConfigSourceDefault              = "Default"
+)
+
+// IsOverridden returns true if the config is set at the topic level.
+// Older brokers do not report the source, in which case the default flag is used.
+func (c ConfigEntry) IsOverridden() bool {
+	if c.Source == "" || c.Source == ConfigSourceUnknown {
+		return !c.Default
+	}
+	return c.Source == ConfigSourceTopic
+}
+
+type ConfigOperation int
+
+// The config operations map one to one to the operations supported by the IncrementalAlterConfigs api
+const (
+	ConfigOperationSet ConfigOperation = iota
+	ConfigOperationDelete
+	ConfigOperationAppend
+	ConfigOperationSubtract
+)
+
+type IncrementalConfigEntry struct {
+	Operation ConfigOperation
+	Value     *string
+}
+
 type ConfigSynonym struct {
 	ConfigName  string
 	ConfigValue string
@@ -72,15 +117,52 @@ type KafkaAPIClient interface {
 	CreateTopic(topic string, detail TopicDetail, validateOnly bool) error
 	CreatePartitions(topic string, count int32, assignment [][]int32, validateOnly bool) error
 	ListBrokers() map[int]string
+	DescribeBrokers() ([]Broker, error)
 	ListTopicDetails() (map[string]TopicDetail, error)
+	// DeleteTopic returns a *DeleteTopicsError with the error of each topic that was not deleted
 	DeleteTopic(topics []string) error
 	DescribeTopicMetadata(topics []string) ([]*TopicMetadata, error)
-	UpdateConfig(resourceType int, name string, entries map[string]*string, validateOnly bool) error
+	UpdateConfig(resourceType int, name string, entries map[string]IncrementalConfigEntry, validateOnly bool) error
 	GetTopicResourceType() int
 	GetConfig(resource ConfigResource) ([]ConfigEntry, error)
+	GetConfigs(resources []ConfigResource) (map[string][]ConfigEntry, error)
 	DescribeLogDirs(brokerIDs []int32) (map[int32][]DescribeLogDirsResponseDirMetadata, error)
 }
 
+// DeleteTopicsError is returned when some of the topics were not deleted
+type DeleteTopicsError struct {
+	Total int
+	// Errors are the errors of the topics that were not deleted
+	Errors map[string]error
+}
+
+func (e *DeleteTopicsError) Error() string {
+	topics := make([]string, 0, len(e.Errors))
+	for topic := range e.Errors {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	errs := make([]string, 0, len(topics))
+	for _, topic := range topics {
+		errs = append(errs, fmt.Sprintf("%v: %v", topic, e.Errors[topic]))
+	}
+	return fmt.Sprintf("err while deleting %d of %d topics - %v", len(e.Errors), e.Total, strings.Join(errs, ", "))
+}
+
 type KafkaSSHClient interface {
 	ListTopics(ListTopicsRequest) ([]string, error)
 }
+
+// StaleTopicLister lists the topics not written to since the last written time of the request
+type StaleTopicLister interface {
+	ListTopics(ListTopicsRequest) ([]string, error)
+}
+
+type SubscriptionLister interface {
+	// ListSubscribedTopics returns the consumer groups having members assigned to the partitions of each topic
+	ListSubscribedTopics() (map[string][]string, error)
+	// ListCommittedTopics returns the consumer groups having committed offsets for the partitions of each topic
+	ListCommittedTopics() (map[string][]string, error)
+	// ListCommittedOffsets returns the committed offsets per topic, consumer group and partition
+	ListCommittedOffsets() (map[string]map[string]map[int32]int64, error)
+}
@@ -14,11 +14,14 @@ type Lister interface {
 
 type Describer interface {
 	Describe(topics []string) ([]*TopicMetadata, error)
+	DescribeBrokers() ([]Broker, error)
 }
 
 type Configurer interface {
 	GetConfig(topic string) ([]ConfigEntry, error)
+	GetConfigs(topics []string) (map[string][]ConfigEntry, error)
 	UpdateConfig(topics []string, configMap map[string]*string, validateOnly bool) error
+	IncrementalUpdateConfig(topics []string, entries map[string]IncrementalConfigEntry, validateOnly bool) error
 }
 
 type Deleter interface {
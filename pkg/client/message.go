@@ -0,0 +1,74 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Offsets to read from the oldest or the newest message of a partition, instead of an absolute offset
+const (
+	OffsetNewest int64 = -1
+	OffsetOldest int64 = -2
+)
+
+// ErrStopReading is returned by a message handler to stop reading the partition without an error
+var ErrStopReading = errors.New("stop reading")
+
+// ErrReadIdle is returned when reading a partition stops before its end offset, as no messages were received for a while
+var ErrReadIdle = errors.New("no messages were received for a while")
+
+type Message struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+	Key       []byte
+	Value     []byte
+	Headers   []MessageHeader
+	Timestamp time.Time
+}
+
+type MessageHeader struct {
+	Key   []byte
+	Value []byte
+}
+
+type MessageReader interface {
+	Partitions(topic string) ([]int32, error)
+	// GetOffset returns the offset of the first message with a timestamp at or after the time in milliseconds,
+	// or the offset for OffsetNewest or OffsetOldest
+	GetOffset(topic string, partition int32, timeInMs int64) (int64, error)
+	// ReadMessages calls the handler for the messages of the partition from the start offset, until the end offset
+	// is reached or the context is done. An end offset of OffsetNewest reads until the context is done.
+	// The end offset is also reached when the offsets left have no messages, like the offsets of transaction markers
+	// and compacted messages. ErrReadIdle is returned when no messages are received for a while before the end offset.
+	ReadMessages(ctx context.Context, topic string, partition int32, startOffset, endOffset int64, handler func(*Message) error) error
+}
+
+type MessageWriter interface {
+	Partitions(topic string) ([]int32, error)
+	// WriteMessages writes the messages to the topic set on them, and returns once they are acknowledged
+	WriteMessages(messages []*Message) error
+	Close() error
+}
+
+type MessageDeleter interface {
+	// DeleteRecords deletes the messages of the partitions before the offsets
+	DeleteRecords(topic string, partitionOffsets map[int32]int64) error
+}
+
+// WriteError is returned by a MessageWriter when some of the messages were not written
+type WriteError struct {
+	Failed int
+	Total  int
+	Err    error
+}
+
+func (e *WriteError) Error() string {
+	return fmt.Sprintf("err while writing %d of %d messages - %v", e.Failed, e.Total, e.Err)
+}
+
+func (e *WriteError) Unwrap() error {
+	return e.Err
+}
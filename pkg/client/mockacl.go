@@ -0,0 +1,21 @@
+package client
+
+import "github.com/stretchr/testify/mock"
+
+type MockACLLister struct {
+	mock.Mock
+}
+
+func (m *MockACLLister) ListACLs() ([]ACL, error) {
+	args := m.Called()
+	return args.Get(0).([]ACL), args.Error(1)
+}
+
+type MockACLCreator struct {
+	mock.Mock
+}
+
+func (m *MockACLCreator) CreateACLs(acls []ACL) error {
+	args := m.Called(acls)
+	return args.Error(0)
+}
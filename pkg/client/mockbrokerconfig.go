@@ -0,0 +1,21 @@
+package client
+
+import "github.com/stretchr/testify/mock"
+
+type MockBrokerConfigLister struct {
+	mock.Mock
+}
+
+func (m *MockBrokerConfigLister) ListDynamicBrokerConfigs() (map[string]map[string]string, error) {
+	args := m.Called()
+	return args.Get(0).(map[string]map[string]string), args.Error(1)
+}
+
+type MockBrokerConfigUpdater struct {
+	mock.Mock
+}
+
+func (m *MockBrokerConfigUpdater) UpdateBrokerConfigs(broker string, configs map[string]string) error {
+	args := m.Called(broker, configs)
+	return args.Error(0)
+}
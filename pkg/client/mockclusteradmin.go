@@ -49,6 +49,12 @@ func (m *MockClusterAdmin) AlterConfig(resourceType sarama.ConfigResourceType, n
 	return args.Error(0)
 }
 
+func (m *MockClusterAdmin) IncrementalAlterConfig(resourceType sarama.ConfigResourceType, name string,
+	entries map[string]sarama.IncrementalAlterConfigsEntry, validateOnly bool) error {
+	args := m.Called(resourceType, name, entries, validateOnly)
+	return args.Error(0)
+}
+
 func (m *MockClusterAdmin) CreateACL(resource sarama.Resource, acl sarama.Acl) error {
 	args := m.Called(resource, acl)
 	return args.Error(0)
@@ -64,6 +70,11 @@ func (m *MockClusterAdmin) DeleteACL(filter sarama.AclFilter, validateOnly bool)
 	return args.Get(0).([]sarama.MatchingAcl), args.Error(1)
 }
 
+func (m *MockClusterAdmin) CreateACLs(resourceACLs []*sarama.ResourceAcls) error {
+	args := m.Called(resourceACLs)
+	return args.Error(0)
+}
+
 func (m *MockClusterAdmin) ListConsumerGroups() (map[string]string, error) {
 	args := m.Called()
 	return args.Get(0).(map[string]string), args.Error(1)
@@ -79,6 +90,10 @@ func (m *MockClusterAdmin) ListConsumerGroupOffsets(group string, topicPartition
 	return args.Get(0).(*sarama.OffsetFetchResponse), args.Error(1)
 }
 
+func (m *MockClusterAdmin) DeleteConsumerGroupOffset(group string, topic string, partition int32) error {
+	panic("unused")
+}
+
 func (m *MockClusterAdmin) DescribeCluster() (brokers []*sarama.Broker, controllerID int32, err error) {
 	args := m.Called()
 	return args.Get(0).([]*sarama.Broker), args.Get(1).(int32), args.Error(2)
@@ -110,3 +125,31 @@ func (m *MockClusterAdmin) ListPartitionReassignments(topics string,
 	partitions []int32) (topicStatus map[string]map[int32]*sarama.PartitionReplicaReassignmentsStatus, err error) {
 	panic("unused")
 }
+
+func (m *MockClusterAdmin) DescribeUserScramCredentials(users []string) ([]*sarama.DescribeUserScramCredentialsResult, error) {
+	panic("unused")
+}
+
+func (m *MockClusterAdmin) DeleteUserScramCredentials(delete []sarama.AlterUserScramCredentialsDelete) ([]*sarama.AlterUserScramCredentialsResult, error) {
+	panic("unused")
+}
+
+func (m *MockClusterAdmin) UpsertUserScramCredentials(upsert []sarama.AlterUserScramCredentialsUpsert) ([]*sarama.AlterUserScramCredentialsResult, error) {
+	panic("unused")
+}
+
+func (m *MockClusterAdmin) DescribeClientQuotas(components []sarama.QuotaFilterComponent, strict bool) ([]sarama.DescribeClientQuotasEntry, error) {
+	panic("unused")
+}
+
+func (m *MockClusterAdmin) AlterClientQuotas(entity []sarama.QuotaEntityComponent, op sarama.ClientQuotasOp, validateOnly bool) error {
+	panic("unused")
+}
+
+func (m *MockClusterAdmin) Controller() (*sarama.Broker, error) {
+	args := m.Called()
+	if args.Get(0) != nil {
+		return args.Get(0).(*sarama.Broker), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
@@ -36,7 +36,7 @@ func (m *MockKafkaAPIClient) DescribeTopicMetadata(topics []string) ([]*TopicMet
 	return args.Get(0).([]*TopicMetadata), args.Error(1)
 }
 
-func (m *MockKafkaAPIClient) UpdateConfig(resourceType int, name string, entries map[string]*string, validateOnly bool) error {
+func (m *MockKafkaAPIClient) UpdateConfig(resourceType int, name string, entries map[string]IncrementalConfigEntry, validateOnly bool) error {
 	args := m.Called(resourceType, name, entries, validateOnly)
 	return args.Error(0)
 }
@@ -51,6 +51,16 @@ func (m *MockKafkaAPIClient) GetConfig(resource ConfigResource) ([]ConfigEntry,
 	return args.Get(0).([]ConfigEntry), args.Error(1)
 }
 
+func (m *MockKafkaAPIClient) GetConfigs(resources []ConfigResource) (map[string][]ConfigEntry, error) {
+	args := m.Called(resources)
+	return args.Get(0).(map[string][]ConfigEntry), args.Error(1)
+}
+
+func (m *MockKafkaAPIClient) DescribeBrokers() ([]Broker, error) {
+	args := m.Called()
+	return args.Get(0).([]Broker), args.Error(1)
+}
+
 func (m *MockKafkaAPIClient) DescribeLogDirs(brokerIDs []int32) (map[int32][]DescribeLogDirsResponseDirMetadata, error) {
 	args := m.Called(brokerIDs)
 	if args.Get(0) != nil {
@@ -49,6 +49,11 @@ func (m *MockDescriber) Describe(topics []string) ([]*TopicMetadata, error) {
 	return args.Get(0).([]*TopicMetadata), args.Error(1)
 }
 
+func (m *MockDescriber) DescribeBrokers() ([]Broker, error) {
+	args := m.Called()
+	return args.Get(0).([]Broker), args.Error(1)
+}
+
 type MockConfigurer struct {
 	mock.Mock
 }
@@ -58,11 +63,21 @@ func (m *MockConfigurer) GetConfig(topic string) ([]ConfigEntry, error) {
 	return args.Get(0).([]ConfigEntry), args.Error(1)
 }
 
+func (m *MockConfigurer) GetConfigs(topics []string) (map[string][]ConfigEntry, error) {
+	args := m.Called(topics)
+	return args.Get(0).(map[string][]ConfigEntry), args.Error(1)
+}
+
 func (m *MockConfigurer) UpdateConfig(topics []string, configMap map[string]*string, validateOnly bool) error {
 	args := m.Called(topics, configMap, validateOnly)
 	return args.Error(0)
 }
 
+func (m *MockConfigurer) IncrementalUpdateConfig(topics []string, entries map[string]IncrementalConfigEntry, validateOnly bool) error {
+	args := m.Called(topics, entries, validateOnly)
+	return args.Error(0)
+}
+
 type MockDeleter struct {
 	mock.Mock
 }
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockMessageReader struct {
+	mock.Mock
+}
+
+func (m *MockMessageReader) Partitions(topic string) ([]int32, error) {
+	args := m.Called(topic)
+	return args.Get(0).([]int32), args.Error(1)
+}
+
+func (m *MockMessageReader) GetOffset(topic string, partition int32, timeInMs int64) (int64, error) {
+	args := m.Called(topic, partition, timeInMs)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// ReadMessages calls the handler with the messages the mock is set up to return, and then returns the error
+func (m *MockMessageReader) ReadMessages(ctx context.Context, topic string, partition int32, startOffset, endOffset int64,
+	handler func(*Message) error) error {
+	args := m.Called(topic, partition, startOffset, endOffset)
+	for _, msg := range args.Get(0).([]*Message) {
+		if err := handler(msg); err != nil {
+			if err == ErrStopReading {
+				return nil
+			}
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+type MockMessageWriter struct {
+	mock.Mock
+}
+
+func (m *MockMessageWriter) Partitions(topic string) ([]int32, error) {
+	args := m.Called(topic)
+	return args.Get(0).([]int32), args.Error(1)
+}
+
+func (m *MockMessageWriter) WriteMessages(messages []*Message) error {
+	args := m.Called(messages)
+	return args.Error(0)
+}
+
+func (m *MockMessageWriter) Close() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+type MockMessageDeleter struct {
+	mock.Mock
+}
+
+func (m *MockMessageDeleter) DeleteRecords(topic string, partitionOffsets map[int32]int64) error {
+	args := m.Called(topic, partitionOffsets)
+	return args.Error(0)
+}
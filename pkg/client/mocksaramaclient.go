@@ -22,6 +22,10 @@ func (m *MockSaramaClient) Brokers() []*sarama.Broker {
 	return args.Get(0).([]*sarama.Broker)
 }
 
+func (m *MockSaramaClient) Broker(brokerID int32) (*sarama.Broker, error) {
+	panic("implement me")
+}
+
 func (m *MockSaramaClient) Topics() ([]string, error) {
 	panic("implement me")
 }
@@ -0,0 +1,37 @@
+package client
+
+import "github.com/stretchr/testify/mock"
+
+type MockSchemaRegistry struct {
+	mock.Mock
+}
+
+func (m *MockSchemaRegistry) Subjects() ([]string, error) {
+	args := m.Called()
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockSchemaRegistry) Versions(subject string) ([]int, error) {
+	args := m.Called(subject)
+	return args.Get(0).([]int), args.Error(1)
+}
+
+func (m *MockSchemaRegistry) GetSchema(subject, version string) (*Schema, error) {
+	args := m.Called(subject, version)
+	return args.Get(0).(*Schema), args.Error(1)
+}
+
+func (m *MockSchemaRegistry) GetSchemaByID(id int) (*Schema, error) {
+	args := m.Called(id)
+	return args.Get(0).(*Schema), args.Error(1)
+}
+
+func (m *MockSchemaRegistry) CheckCompatibility(subject, version string, schema *Schema) (*CompatibilityResult, error) {
+	args := m.Called(subject, version, schema)
+	return args.Get(0).(*CompatibilityResult), args.Error(1)
+}
+
+func (m *MockSchemaRegistry) CompatibilityLevel(subject string) (string, error) {
+	args := m.Called(subject)
+	return args.String(0), args.Error(1)
+}
@@ -0,0 +1,22 @@
+package client
+
+import "github.com/stretchr/testify/mock"
+
+type MockSubscriptionLister struct {
+	mock.Mock
+}
+
+func (m *MockSubscriptionLister) ListSubscribedTopics() (map[string][]string, error) {
+	args := m.Called()
+	return args.Get(0).(map[string][]string), args.Error(1)
+}
+
+func (m *MockSubscriptionLister) ListCommittedTopics() (map[string][]string, error) {
+	args := m.Called()
+	return args.Get(0).(map[string][]string), args.Error(1)
+}
+
+func (m *MockSubscriptionLister) ListCommittedOffsets() (map[string]map[string]map[int32]int64, error) {
+	args := m.Called()
+	return args.Get(0).(map[string]map[string]map[int32]int64), args.Error(1)
+}
@@ -1,19 +1,59 @@
 package client
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"strconv"
 	"sync"
+	"time"
 
 	"github.com/Shopify/sarama"
 	"github.com/gojek/kat/logger"
 )
 
 type SaramaClient struct {
-	admin  sarama.ClusterAdmin
-	client sarama.Client
+	admin      sarama.ClusterAdmin
+	client     sarama.Client
+	consumer   sarama.Consumer
+	consumerMu sync.Mutex
+	// legacyAlterConfigs is set for brokers older than 2.3.0, which do not support the IncrementalAlterConfigs api
+	legacyAlterConfigs bool
 }
 
+// DefaultKafkaVersion is the version of the brokers the clients are created for by default.
+// The IncrementalAlterConfigs api used to change configs is supported from kafka 2.3.0.
+const DefaultKafkaVersion = "2.3.0"
+
+var kafkaVersion = sarama.V2_3_0_0
+
+// SetKafkaVersion sets the version of the brokers the clients are created for, the versions older than 2.0.0 are not supported
+func SetKafkaVersion(version string) error {
+	v, err := sarama.ParseKafkaVersion(version)
+	if err != nil {
+		return err
+	}
+	if !v.IsAtLeast(sarama.V2_0_0_0) {
+		return fmt.Errorf("kafka version %v is not supported, expected at least 2.0.0", version)
+	}
+	kafkaVersion = v
+	return nil
+}
+
+// readIdleTimeout is the time after which the partition is checked for messages left before the end offset, when no messages
+// are received. The end offset may never be reached by the messages, as the offsets of transaction markers are not delivered.
+var readIdleTimeout = 5 * time.Second
+
+// deleteTopicsConcurrency is the number of topics deleted at once
+const deleteTopicsConcurrency = 5
+
+// deleteTopicTimeout is the time to wait for a deleted topic to be removed from the metadata of the controller
+var (
+	deleteTopicTimeout      = 30 * time.Second
+	deleteTopicPollInterval = time.Second
+)
+
 type consumerGroups map[string]*sarama.GroupMemberDescription
 
 func (c *consumerGroups) HasSubscription(topic string) bool {
@@ -31,7 +71,7 @@ func (c *consumerGroups) HasSubscription(topic string) bool {
 
 func NewSaramaClient(addr []string) *SaramaClient {
 	cfg := sarama.NewConfig()
-	cfg.Version = sarama.V2_0_0_0
+	cfg.Version = kafkaVersion
 
 	admin, err := sarama.NewClusterAdmin(addr, cfg)
 	if err != nil {
@@ -42,7 +82,7 @@ func NewSaramaClient(addr []string) *SaramaClient {
 	if err != nil {
 		logger.Fatalf("Err on creating client for %s: %v\n", addr, err)
 	}
-	return &SaramaClient{admin, client}
+	return &SaramaClient{admin: admin, client: client, legacyAlterConfigs: !kafkaVersion.IsAtLeast(sarama.V2_3_0_0)}
 }
 
 func (s *SaramaClient) CreateTopic(topic string, detail TopicDetail, validateOnly bool) error {
@@ -52,6 +92,11 @@ func (s *SaramaClient) CreateTopic(topic string, detail TopicDetail, validateOnl
 		ReplicaAssignment: detail.ReplicaAssignment,
 		ConfigEntries:     detail.Config,
 	}
+	// the brokers reject requests with both the replica assignment and the partition count or replication factor
+	if len(detail.ReplicaAssignment) > 0 {
+		topicDetail.NumPartitions = -1
+		topicDetail.ReplicationFactor = -1
+	}
 	return s.admin.CreateTopic(topic, topicDetail, validateOnly)
 }
 
@@ -68,6 +113,24 @@ func (s *SaramaClient) ListBrokers() map[int]string {
 	return brokerMap
 }
 
+// DescribeBrokers returns the brokers of the cluster along with their racks, sorted by id
+func (s *SaramaClient) DescribeBrokers() ([]Broker, error) {
+	saramaBrokers, _, err := s.admin.DescribeCluster()
+	if err != nil {
+		logger.Errorf("Error while describing the cluster - %v\n", err)
+		return nil, err
+	}
+
+	brokers := make([]Broker, 0, len(saramaBrokers))
+	for _, broker := range saramaBrokers {
+		brokers = append(brokers, Broker{ID: broker.ID(), Addr: broker.Addr(), Rack: broker.Rack()})
+	}
+	sort.Slice(brokers, func(i, j int) bool {
+		return brokers[i].ID < brokers[j].ID
+	})
+	return brokers, nil
+}
+
 func (s *SaramaClient) ListConsumerGroups() (map[string]string, error) {
 	return s.admin.ListConsumerGroups()
 }
@@ -89,7 +152,6 @@ func (s *SaramaClient) GetConsumerGroupsForTopic(groups []string, topic string)
 
 			if c.HasSubscription(topic) {
 				consumerGroupsChannel <- groupDescription[0].GroupId
-				fmt.Println(groupDescription[0].GroupId)
 			}
 		}(i, &wg)
 	}
@@ -101,6 +163,233 @@ func (s *SaramaClient) GetConsumerGroupsForTopic(groups []string, topic string)
 	return consumerGroupsChannel, nil
 }
 
+func (s *SaramaClient) ListSubscribedTopics() (map[string][]string, error) {
+	groups, err := s.admin.ListConsumerGroups()
+	if err != nil {
+		return nil, err
+	}
+	if len(groups) == 0 {
+		return map[string][]string{}, nil
+	}
+	groupIDs := make([]string, 0, len(groups))
+	for group := range groups {
+		groupIDs = append(groupIDs, group)
+	}
+	sort.Strings(groupIDs)
+	descriptions, err := s.admin.DescribeConsumerGroups(groupIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions := make(map[string][]string)
+	for _, description := range descriptions {
+		for _, topic := range assignedTopics(description) {
+			subscriptions[topic] = append(subscriptions[topic], description.GroupId)
+		}
+	}
+	return subscriptions, nil
+}
+
+func (s *SaramaClient) ListCommittedTopics() (map[string][]string, error) {
+	offsets, err := s.ListCommittedOffsets()
+	if err != nil {
+		return nil, err
+	}
+	committed := make(map[string][]string, len(offsets))
+	for topic, groupOffsets := range offsets {
+		for group := range groupOffsets {
+			committed[topic] = append(committed[topic], group)
+		}
+		sort.Strings(committed[topic])
+	}
+	return committed, nil
+}
+
+func (s *SaramaClient) ListCommittedOffsets() (map[string]map[string]map[int32]int64, error) {
+	groups, err := s.admin.ListConsumerGroups()
+	if err != nil {
+		return nil, err
+	}
+	groupIDs := make([]string, 0, len(groups))
+	for group := range groups {
+		groupIDs = append(groupIDs, group)
+	}
+	sort.Strings(groupIDs)
+
+	committed := make(map[string]map[string]map[int32]int64)
+	for _, group := range groupIDs {
+		// the offsets of all the topics are fetched when no partitions are passed
+		offsets, offsetsErr := s.admin.ListConsumerGroupOffsets(group, nil)
+		if offsetsErr != nil {
+			return nil, fmt.Errorf("err while fetching the offsets of consumer group %v - %w", group, offsetsErr)
+		}
+		for topic, blocks := range offsets.Blocks {
+			partitionOffsets := committedOffsets(blocks)
+			if len(partitionOffsets) == 0 {
+				continue
+			}
+			if committed[topic] == nil {
+				committed[topic] = make(map[string]map[int32]int64)
+			}
+			committed[topic][group] = partitionOffsets
+		}
+	}
+	return committed, nil
+}
+
+func committedOffsets(blocks map[int32]*sarama.OffsetFetchResponseBlock) map[int32]int64 {
+	offsets := make(map[int32]int64)
+	for partition, block := range blocks {
+		if block.Err == sarama.ErrNoError && block.Offset >= 0 {
+			offsets[partition] = block.Offset
+		}
+	}
+	return offsets
+}
+
+// assignedTopics returns the topics assigned to any of the members of the group
+func assignedTopics(description *sarama.GroupDescription) []string {
+	topics := make(map[string]bool)
+	for _, member := range description.Members {
+		assignment, err := member.GetMemberAssignment()
+		if err != nil || assignment == nil {
+			continue
+		}
+		for topic := range assignment.Topics {
+			topics[topic] = true
+		}
+	}
+	result := make([]string, 0, len(topics))
+	for topic := range topics {
+		result = append(result, topic)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// ListACLs returns all the ACLs in the cluster
+func (s *SaramaClient) ListACLs() ([]ACL, error) {
+	return s.FilterACLs(ACLFilter{})
+}
+
+// FilterACLs returns the ACLs matching the filter
+func (s *SaramaClient) FilterACLs(filter ACLFilter) ([]ACL, error) {
+	aclFilter, err := toACLFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	resourceACLs, err := s.admin.ListAcls(aclFilter)
+	if err != nil {
+		if !IsSecurityDisabled(err) {
+			logger.Errorf("Error while listing acls - %v\n", err)
+		}
+		return nil, err
+	}
+
+	var acls []ACL
+	for _, resourceACL := range resourceACLs {
+		for _, acl := range resourceACL.Acls {
+			acls = append(acls, ACL{
+				ResourceType:   resourceACL.ResourceType.String(),
+				ResourceName:   resourceACL.ResourceName,
+				PatternType:    resourceACL.ResourcePatternType.String(),
+				Principal:      acl.Principal,
+				Host:           acl.Host,
+				Operation:      acl.Operation.String(),
+				PermissionType: acl.PermissionType.String(),
+			})
+		}
+	}
+	return acls, nil
+}
+
+// CreateACLs creates the ACLs, the resource type, pattern type, operation and permission type are given by their names
+func (s *SaramaClient) CreateACLs(acls []ACL) error {
+	resourceACLs := make([]*sarama.ResourceAcls, 0, len(acls))
+	for _, acl := range acls {
+		resourceACL, err := toResourceACL(acl)
+		if err != nil {
+			return err
+		}
+		resourceACLs = append(resourceACLs, resourceACL)
+	}
+	if len(resourceACLs) == 0 {
+		return nil
+	}
+	if err := s.admin.CreateACLs(resourceACLs); err != nil {
+		logger.Errorf("Error while creating acls - %v\n", err)
+		return err
+	}
+	return nil
+}
+
+// DeleteACLs deletes each of the ACLs by an exact filter, so that no other ACL is deleted
+func (s *SaramaClient) DeleteACLs(acls []ACL) error {
+	for _, acl := range acls {
+		filter, err := toACLFilter(ACLFilter(acl))
+		if err != nil {
+			return err
+		}
+		if _, err = s.admin.DeleteACL(filter, false); err != nil {
+			logger.Errorf("Error while deleting acl %v - %v\n", acl, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// toACLFilter converts the names of the filter, the empty fields match any value
+func toACLFilter(filter ACLFilter) (sarama.AclFilter, error) {
+	aclFilter := sarama.AclFilter{ResourceName: optional(filter.ResourceName), Principal: optional(filter.Principal), Host: optional(filter.Host)}
+	if err := aclFilter.ResourceType.UnmarshalText([]byte(anyIfEmpty(filter.ResourceType))); err != nil {
+		return aclFilter, fmt.Errorf("invalid resource type %v - %w", filter.ResourceType, err)
+	}
+	if err := aclFilter.ResourcePatternTypeFilter.UnmarshalText([]byte(anyIfEmpty(filter.PatternType))); err != nil {
+		return aclFilter, fmt.Errorf("invalid pattern type %v - %w", filter.PatternType, err)
+	}
+	if err := aclFilter.Operation.UnmarshalText([]byte(anyIfEmpty(filter.Operation))); err != nil {
+		return aclFilter, fmt.Errorf("invalid operation %v - %w", filter.Operation, err)
+	}
+	if err := aclFilter.PermissionType.UnmarshalText([]byte(anyIfEmpty(filter.PermissionType))); err != nil {
+		return aclFilter, fmt.Errorf("invalid permission type %v - %w", filter.PermissionType, err)
+	}
+	return aclFilter, nil
+}
+
+func optional(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func anyIfEmpty(value string) string {
+	if value == "" {
+		return "any"
+	}
+	return value
+}
+
+func toResourceACL(acl ACL) (*sarama.ResourceAcls, error) {
+	resourceACL := &sarama.ResourceAcls{
+		Resource: sarama.Resource{ResourceName: acl.ResourceName},
+		Acls:     []*sarama.Acl{{Principal: acl.Principal, Host: acl.Host}},
+	}
+	if err := resourceACL.ResourceType.UnmarshalText([]byte(acl.ResourceType)); err != nil {
+		return nil, fmt.Errorf("invalid resource type in acl %v - %w", acl, err)
+	}
+	if err := resourceACL.ResourcePatternType.UnmarshalText([]byte(acl.PatternType)); err != nil {
+		return nil, fmt.Errorf("invalid pattern type in acl %v - %w", acl, err)
+	}
+	if err := resourceACL.Acls[0].Operation.UnmarshalText([]byte(acl.Operation)); err != nil {
+		return nil, fmt.Errorf("invalid operation in acl %v - %w", acl, err)
+	}
+	if err := resourceACL.Acls[0].PermissionType.UnmarshalText([]byte(acl.PermissionType)); err != nil {
+		return nil, fmt.Errorf("invalid permission type in acl %v - %w", acl, err)
+	}
+	return resourceACL, nil
+}
+
 func (s *SaramaClient) ListTopicDetails() (map[string]TopicDetail, error) {
 	topics, err := s.admin.ListTopics()
 	if err != nil {
@@ -121,18 +410,53 @@ func (s *SaramaClient) ListTopicDetails() (map[string]TopicDetail, error) {
 	return topicDetails, err
 }
 
+// DeleteTopic deletes the topics in parallel, and waits for each topic to be removed from the metadata of the controller
 func (s *SaramaClient) DeleteTopic(topics []string) error {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	errs := make(map[string]error)
+	sem := make(chan struct{}, deleteTopicsConcurrency)
 	for _, topic := range topics {
-		err := s.admin.DeleteTopic(topic)
-		if err != nil {
-			logger.Errorf("Error while deleting topic %v- %v\n", topic, err)
-		} else {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(topic string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := s.deleteTopic(topic); err != nil {
+				logger.Errorf("Error while deleting topic %v - %v\n", topic, err)
+				mu.Lock()
+				errs[topic] = err
+				mu.Unlock()
+				return
+			}
 			logger.Infof("Deleted topic - %v\n", topic)
-		}
+		}(topic)
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return &DeleteTopicsError{Total: len(topics), Errors: errs}
 	}
 	return nil
 }
 
+func (s *SaramaClient) deleteTopic(topic string) error {
+	if err := s.admin.DeleteTopic(topic); err != nil {
+		return err
+	}
+	deadline := time.Now().Add(deleteTopicTimeout)
+	for {
+		metadata, err := s.admin.DescribeTopics([]string{topic})
+		if err == nil && (len(metadata) == 0 || metadata[0].Err == sarama.ErrUnknownTopicOrPartition) {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("topic is still present in the metadata %v after the deletion", deleteTopicTimeout)
+		}
+		time.Sleep(deleteTopicPollInterval)
+	}
+}
+
 func (s *SaramaClient) DescribeTopicMetadata(topics []string) ([]*TopicMetadata, error) {
 	metadata, err := s.admin.DescribeTopics(topics)
 	if err != nil {
@@ -164,8 +488,16 @@ func (s *SaramaClient) DescribeTopicMetadata(topics []string) ([]*TopicMetadata,
 	return topicMetadata, nil
 }
 
-func (s *SaramaClient) UpdateConfig(resourceType int, name string, entries map[string]*string, validateOnly bool) error {
-	err := s.admin.AlterConfig(sarama.ConfigResourceType(resourceType), name, entries, validateOnly)
+// UpdateConfig uses the IncrementalAlterConfigs api, so the configs that are not part of the entries are left untouched.
+func (s *SaramaClient) UpdateConfig(resourceType int, name string, entries map[string]IncrementalConfigEntry, validateOnly bool) error {
+	saramaEntries := make(map[string]sarama.IncrementalAlterConfigsEntry, len(entries))
+	for key, entry := range entries {
+		saramaEntries[key] = sarama.IncrementalAlterConfigsEntry{
+			Operation: sarama.IncrementalAlterConfigsOperation(entry.Operation),
+			Value:     entry.Value,
+		}
+	}
+	err := s.incrementalAlterConfig(sarama.ConfigResourceType(resourceType), name, saramaEntries, validateOnly)
 	if err != nil {
 		logger.Errorf("Error while changing config for topic %v - %v\n", name, err)
 	}
@@ -176,42 +508,213 @@ func (s *SaramaClient) GetTopicResourceType() int {
 	return int(sarama.TopicResource)
 }
 
+// GetConfig describes the configs of the resource along with their synonyms.
+// The configs of a broker are described by the broker itself, the other resources by the controller.
 func (s *SaramaClient) GetConfig(resource ConfigResource) ([]ConfigEntry, error) {
-	entries, err := s.admin.DescribeConfig(sarama.ConfigResource{
-		Type:        sarama.ConfigResourceType(resource.Type),
-		Name:        resource.Name,
-		ConfigNames: resource.ConfigNames,
-	})
+	broker, err := s.configBroker(resource)
+	if err != nil {
+		logger.Errorf("Error while finding the broker to describe the config of %v - %v\n", resource.Name, err)
+		return nil, err
+	}
+	configs, err := describeConfigs(broker, []ConfigResource{resource})
 	if err != nil {
 		logger.Errorf("Error while retrieving config for %v - %v\n", resource.Name, err)
 		return nil, err
 	}
+	return configs[resource.Name], nil
+}
 
-	var configEntries []ConfigEntry
-	for _, e := range entries {
-		var configSynonyms []*ConfigSynonym
-		for _, s := range e.Synonyms {
-			configSynonyms = append(configSynonyms, &ConfigSynonym{
-				ConfigName:  s.ConfigName,
-				ConfigValue: s.ConfigValue,
-				Source:      s.Source.String(),
-			})
-		}
+func (s *SaramaClient) configBroker(resource ConfigResource) (*sarama.Broker, error) {
+	if sarama.ConfigResourceType(resource.Type) != sarama.BrokerResource || resource.Name == "" {
+		return s.admin.Controller()
+	}
+	id, err := strconv.ParseInt(resource.Name, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid broker id %v - %v", resource.Name, err)
+	}
+	return s.client.Broker(int32(id))
+}
 
-		configEntries = append(configEntries, ConfigEntry{
-			Name:      e.Name,
-			Value:     e.Value,
-			ReadOnly:  e.ReadOnly,
-			Default:   e.Default,
-			Source:    e.Source.String(),
-			Sensitive: e.Sensitive,
-			Synonyms:  configSynonyms,
+// GetConfigs describes the configs of all the resources in a single request
+func (s *SaramaClient) GetConfigs(resources []ConfigResource) (map[string][]ConfigEntry, error) {
+	broker, err := s.admin.Controller()
+	if err != nil {
+		logger.Errorf("Error while finding the controller to describe configs - %v\n", err)
+		return nil, err
+	}
+	configs, err := describeConfigs(broker, resources)
+	if err != nil {
+		logger.Errorf("Error while retrieving configs - %v\n", err)
+		return nil, err
+	}
+	return configs, nil
+}
+
+// describeConfigs builds the request instead of using the cluster admin, as the admin does not ask for the synonyms
+func describeConfigs(broker *sarama.Broker, resources []ConfigResource) (map[string][]ConfigEntry, error) {
+	request := &sarama.DescribeConfigsRequest{
+		// version 2 is supported from kafka 2.0.0, the oldest version supported
+		Version:         2,
+		IncludeSynonyms: true,
+	}
+	for _, resource := range resources {
+		request.Resources = append(request.Resources, &sarama.ConfigResource{
+			Type:        sarama.ConfigResourceType(resource.Type),
+			Name:        resource.Name,
+			ConfigNames: resource.ConfigNames,
 		})
 	}
+	response, err := broker.DescribeConfigs(request)
+	if err != nil {
+		return nil, err
+	}
 
+	configEntries := make(map[string][]ConfigEntry, len(response.Resources))
+	for _, resource := range response.Resources {
+		if resource.ErrorCode != 0 {
+			err = sarama.KError(resource.ErrorCode)
+			if resource.ErrorMsg != "" {
+				err = errors.New(resource.ErrorMsg)
+			}
+			return nil, fmt.Errorf("err while retrieving config for %v - %w", resource.Name, err)
+		}
+		entries := make([]ConfigEntry, 0, len(resource.Configs))
+		for _, e := range resource.Configs {
+			entries = append(entries, toConfigEntry(*e))
+		}
+		configEntries[resource.Name] = entries
+	}
 	return configEntries, nil
 }
 
+// ListDynamicBrokerConfigs describes the configs of each broker, as the configs of a broker are only returned by the broker itself.
+// The sensitive configs are skipped, as their values are not returned.
+func (s *SaramaClient) ListDynamicBrokerConfigs() (map[string]map[string]string, error) {
+	brokers, err := s.DescribeBrokers()
+	if err != nil {
+		return nil, err
+	}
+	// the empty name describes the cluster wide defaults
+	names := []string{""}
+	for _, broker := range brokers {
+		names = append(names, strconv.Itoa(int(broker.ID)))
+	}
+
+	configs := make(map[string]map[string]string)
+	for _, name := range names {
+		entries, describeErr := s.admin.DescribeConfig(sarama.ConfigResource{Type: sarama.BrokerResource, Name: name})
+		if describeErr != nil {
+			return nil, fmt.Errorf("err while describing the configs of broker %q - %w", name, describeErr)
+		}
+		dynamic := make(map[string]string)
+		for _, entry := range entries {
+			if entry.Sensitive || (entry.Source != sarama.SourceDynamicBroker && entry.Source != sarama.SourceDynamicDefaultBroker) {
+				continue
+			}
+			dynamic[entry.Name] = entry.Value
+		}
+		if len(dynamic) > 0 {
+			configs[name] = dynamic
+		}
+	}
+	return configs, nil
+}
+
+// UpdateBrokerConfigs uses the IncrementalAlterConfigs api, so the other dynamic configs of the broker are left untouched
+func (s *SaramaClient) UpdateBrokerConfigs(broker string, configs map[string]string) error {
+	entries := make(map[string]sarama.IncrementalAlterConfigsEntry, len(configs))
+	for name, value := range configs {
+		value := value
+		entries[name] = sarama.IncrementalAlterConfigsEntry{Operation: sarama.IncrementalAlterConfigsOperationSet, Value: &value}
+	}
+	if err := s.incrementalAlterConfig(sarama.BrokerResource, broker, entries, false); err != nil {
+		logger.Errorf("Error while changing config for broker %q - %v\n", broker, err)
+		return err
+	}
+	return nil
+}
+
+func (s *SaramaClient) incrementalAlterConfig(resourceType sarama.ConfigResourceType, name string,
+	entries map[string]sarama.IncrementalAlterConfigsEntry, validateOnly bool) error {
+	if s.legacyAlterConfigs {
+		return s.alterConfig(resourceType, name, entries, validateOnly)
+	}
+	return s.admin.IncrementalAlterConfig(resourceType, name, entries, validateOnly)
+}
+
+// alterConfig changes the configs on brokers older than 2.3.0. The AlterConfigs api replaces all the configs of the resource,
+// so the configs set on the resource are read, the entries are applied on them and all of them are written back.
+func (s *SaramaClient) alterConfig(resourceType sarama.ConfigResourceType, name string, entries map[string]sarama.IncrementalAlterConfigsEntry,
+	validateOnly bool) error {
+	configs, err := s.configsSetOn(resourceType, name, entries)
+	if err != nil {
+		return err
+	}
+	for key, entry := range entries {
+		switch entry.Operation {
+		case sarama.IncrementalAlterConfigsOperationSet:
+			configs[key] = entry.Value
+		case sarama.IncrementalAlterConfigsOperationDelete:
+			delete(configs, key)
+		default:
+			return fmt.Errorf("err while changing config %v - only set and delete are supported by kafka versions older than 2.3.0", key)
+		}
+	}
+	return s.admin.AlterConfig(resourceType, name, configs, validateOnly)
+}
+
+// configsSetOn returns the configs set on the resource itself. It fails if a sensitive config is set which is not part of the
+// entries, as its value is not returned and it would be lost when the configs are written back.
+func (s *SaramaClient) configsSetOn(resourceType sarama.ConfigResourceType, name string,
+	entries map[string]sarama.IncrementalAlterConfigsEntry) (map[string]*string, error) {
+	current, err := s.admin.DescribeConfig(sarama.ConfigResource{Type: resourceType, Name: name})
+	if err != nil {
+		return nil, err
+	}
+	source := sarama.SourceTopic
+	if resourceType == sarama.BrokerResource {
+		source = sarama.SourceDynamicBroker
+		if name == "" {
+			source = sarama.SourceDynamicDefaultBroker
+		}
+	}
+
+	configs := make(map[string]*string)
+	for _, entry := range current {
+		if entry.Source != source {
+			continue
+		}
+		if _, ok := entries[entry.Name]; entry.Sensitive && !ok {
+			return nil, fmt.Errorf("err while changing configs of %q - sensitive config %v would be lost, kafka 2.3.0 or newer is needed",
+				name, entry.Name)
+		}
+		value := entry.Value
+		configs[entry.Name] = &value
+	}
+	return configs, nil
+}
+
+func toConfigEntry(e sarama.ConfigEntry) ConfigEntry {
+	var configSynonyms []*ConfigSynonym
+	for _, s := range e.Synonyms {
+		configSynonyms = append(configSynonyms, &ConfigSynonym{
+			ConfigName:  s.ConfigName,
+			ConfigValue: s.ConfigValue,
+			Source:      s.Source.String(),
+		})
+	}
+
+	return ConfigEntry{
+		Name:      e.Name,
+		Value:     e.Value,
+		ReadOnly:  e.ReadOnly,
+		Default:   e.Default,
+		Source:    e.Source.String(),
+		Sensitive: e.Sensitive,
+		Synonyms:  configSynonyms,
+	}
+}
+
 func (s *SaramaClient) DescribeLogDirs(brokerIDs []int32) (map[int32][]DescribeLogDirsResponseDirMetadata, error) {
 	metaData, err := s.admin.DescribeLogDirs(brokerIDs)
 	if err != nil {
@@ -262,3 +765,176 @@ func getLogDirsPartition(partitions []sarama.DescribeLogDirsResponsePartition) [
 	}
 	return list
 }
+
+func (s *SaramaClient) Partitions(topic string) ([]int32, error) {
+	return s.client.Partitions(topic)
+}
+
+func (s *SaramaClient) GetOffset(topic string, partition int32, timeInMs int64) (int64, error) {
+	return s.client.GetOffset(topic, partition, timeInMs)
+}
+
+func (s *SaramaClient) DeleteRecords(topic string, partitionOffsets map[int32]int64) error {
+	return s.admin.DeleteRecords(topic, partitionOffsets)
+}
+
+func (s *SaramaClient) ReadMessages(ctx context.Context, topic string, partition int32, startOffset, endOffset int64,
+	handler func(*Message) error) error {
+	if endOffset != OffsetNewest && startOffset >= endOffset {
+		return nil
+	}
+	partitionConsumer, err := s.consumePartition(topic, partition, startOffset)
+	if err != nil {
+		return err
+	}
+	defer closePartitionConsumer(partitionConsumer, topic, partition)
+
+	next := startOffset
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-idleTimeout(endOffset):
+			return s.checkEndReached(topic, partition, next, endOffset)
+		case msg, ok := <-partitionConsumer.Messages():
+			if !ok {
+				return nil
+			}
+			next = msg.Offset + 1
+			if done, handlerErr := handleMessage(msg, endOffset, handler); done {
+				return handlerErr
+			}
+		}
+	}
+}
+
+// checkEndReached returns ErrReadIdle if the partition has messages left between the next offset and the end offset
+func (s *SaramaClient) checkEndReached(topic string, partition int32, next, endOffset int64) error {
+	position, err := s.fetchPosition(topic, partition, next)
+	if err != nil {
+		return fmt.Errorf("err while checking the offsets left on partition %d of topic %v - %w", partition, topic, err)
+	}
+	if position >= endOffset {
+		logger.Debugf("No messages left on partition %d of topic %v between offsets %d and %d\n", partition, topic, next, endOffset)
+		return nil
+	}
+	return fmt.Errorf("stopped at offset %d of %d - %w", next, endOffset, ErrReadIdle)
+}
+
+// fetchPosition returns the offset after the batches the leader returns when fetching from the offset, including the
+// batches of transaction markers that are not delivered to the consumers. The high watermark is returned when there are
+// no batches left, as happens when the messages at the end of the partition are compacted.
+func (s *SaramaClient) fetchPosition(topic string, partition int32, offset int64) (int64, error) {
+	broker, err := s.client.Leader(topic, partition)
+	if err != nil {
+		return 0, err
+	}
+	config := s.client.Config()
+	request := &sarama.FetchRequest{MinBytes: 1, MaxBytes: sarama.MaxResponseSize, Isolation: sarama.ReadUncommitted}
+	if config.Version.IsAtLeast(sarama.V0_11_0_0) {
+		request.Version = 4
+	}
+	request.AddBlock(topic, partition, offset, config.Consumer.Fetch.Default)
+	response, err := broker.Fetch(request)
+	if err != nil {
+		return 0, err
+	}
+	block := response.GetBlock(topic, partition)
+	if block == nil {
+		return 0, sarama.ErrIncompleteResponse
+	}
+	if !errors.Is(block.Err, sarama.ErrNoError) {
+		return 0, block.Err
+	}
+	if len(block.RecordsSet) == 0 {
+		return block.HighWaterMarkOffset, nil
+	}
+	return lastBatchOffset(block.RecordsSet, offset), nil
+}
+
+// lastBatchOffset returns the offset after the complete batches of the records, or the offset when there are none
+func lastBatchOffset(recordsSet []*sarama.Records, offset int64) int64 {
+	position := offset
+	for _, records := range recordsSet {
+		if batch := records.RecordBatch; batch != nil && !batch.PartialTrailingRecord && batch.LastOffset()+1 > position {
+			position = batch.LastOffset() + 1
+		}
+		if records.MsgSet == nil {
+			continue
+		}
+		for _, block := range records.MsgSet.Messages {
+			if block.Offset+1 > position {
+				position = block.Offset + 1
+			}
+		}
+	}
+	return position
+}
+
+// idleTimeout returns a channel that fires when no message is received for a while, unless the partition is followed
+func idleTimeout(endOffset int64) <-chan time.Time {
+	if endOffset == OffsetNewest {
+		return nil
+	}
+	return time.After(readIdleTimeout)
+}
+
+// handleMessage passes the message to the handler, and returns true when the reading should stop
+func handleMessage(msg *sarama.ConsumerMessage, endOffset int64, handler func(*Message) error) (bool, error) {
+	if err := handler(toMessage(msg)); err != nil {
+		if err == ErrStopReading {
+			return true, nil
+		}
+		return true, err
+	}
+	return endOffset != OffsetNewest && msg.Offset+1 >= endOffset, nil
+}
+
+// closePartitionConsumer closes the consumer and waits for it to stop, so that the partition can be consumed again
+// right after reading it
+func closePartitionConsumer(partitionConsumer sarama.PartitionConsumer, topic string, partition int32) {
+	if err := partitionConsumer.Close(); err != nil {
+		logger.Debugf("Err while closing the consumer of partition %d of topic %v - %v\n", partition, topic, err)
+	}
+}
+
+func (s *SaramaClient) consumePartition(topic string, partition int32, startOffset int64) (sarama.PartitionConsumer, error) {
+	consumer, err := s.getConsumer()
+	if err != nil {
+		return nil, err
+	}
+	partitionConsumer, err := consumer.ConsumePartition(topic, partition, startOffset)
+	if err != nil {
+		return nil, fmt.Errorf("err while consuming partition %d of topic %v - %w", partition, topic, err)
+	}
+	return partitionConsumer, nil
+}
+
+func (s *SaramaClient) getConsumer() (sarama.Consumer, error) {
+	s.consumerMu.Lock()
+	defer s.consumerMu.Unlock()
+	if s.consumer == nil {
+		consumer, err := sarama.NewConsumerFromClient(s.client)
+		if err != nil {
+			return nil, err
+		}
+		s.consumer = consumer
+	}
+	return s.consumer, nil
+}
+
+func toMessage(msg *sarama.ConsumerMessage) *Message {
+	headers := make([]MessageHeader, 0, len(msg.Headers))
+	for _, header := range msg.Headers {
+		headers = append(headers, MessageHeader{Key: header.Key, Value: header.Value})
+	}
+	return &Message{
+		Topic:     msg.Topic,
+		Partition: msg.Partition,
+		Offset:    msg.Offset,
+		Key:       msg.Key,
+		Value:     msg.Value,
+		Headers:   headers,
+		Timestamp: msg.Timestamp,
+	}
+}
@@ -1,12 +1,17 @@
 package client
 
 import (
+	"bytes"
+	"context"
+	"encoding/binary"
 	"errors"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -97,9 +102,17 @@ func TestSaramaClient_UpdateConfigSuccess(t *testing.T) {
 	client := SaramaClient{admin: admin}
 
 	topic := "topic1"
-	entries := map[string]*string{}
+	value := "val1"
+	entries := map[string]IncrementalConfigEntry{
+		"key1": {Operation: ConfigOperationSet, Value: &value},
+		"key2": {Operation: ConfigOperationDelete},
+	}
+	saramaEntries := map[string]sarama.IncrementalAlterConfigsEntry{
+		"key1": {Operation: sarama.IncrementalAlterConfigsOperationSet, Value: &value},
+		"key2": {Operation: sarama.IncrementalAlterConfigsOperationDelete},
+	}
 	validateOnly := false
-	admin.On("AlterConfig", sarama.TopicResource, topic, entries, validateOnly).Return(nil)
+	admin.On("IncrementalAlterConfig", sarama.TopicResource, topic, saramaEntries, validateOnly).Return(nil)
 
 	err := client.UpdateConfig(client.GetTopicResourceType(), topic, entries, validateOnly)
 	assert.NoError(t, err)
@@ -112,9 +125,9 @@ func TestSaramaClient_UpdateConfigFailure(t *testing.T) {
 	expectedErr := errors.New("error")
 
 	topic := "topic1"
-	entries := map[string]*string{}
+	entries := map[string]IncrementalConfigEntry{}
 	validateOnly := false
-	admin.On("AlterConfig", sarama.TopicResource, topic, entries, validateOnly).Return(expectedErr)
+	admin.On("IncrementalAlterConfig", sarama.TopicResource, topic, map[string]sarama.IncrementalAlterConfigsEntry{}, validateOnly).Return(expectedErr)
 
 	err := client.UpdateConfig(client.GetTopicResourceType(), topic, entries, validateOnly)
 	assert.Error(t, err)
@@ -123,70 +136,189 @@ func TestSaramaClient_UpdateConfigFailure(t *testing.T) {
 }
 
 func TestSaramaClient_ShowConfigSuccess(t *testing.T) {
+	seedBroker := sarama.NewMockBroker(t, 1)
+	defer seedBroker.Close()
+	seedBroker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"DescribeConfigsRequest": sarama.NewMockDescribeConfigsResponse(t),
+	})
+	broker := openBroker(t, seedBroker.Addr())
+	defer broker.Close()
 	admin := &MockClusterAdmin{}
 	client := SaramaClient{admin: admin}
-	configResource := ConfigResource{
-		Type:        client.GetTopicResourceType(),
-		Name:        "topic1",
-		ConfigNames: nil,
-	}
-	saramaConfigResource := sarama.ConfigResource{
-		Type:        sarama.TopicResource,
-		Name:        "topic1",
-		ConfigNames: nil,
-	}
-	saramaConfigEntries := []sarama.ConfigEntry{
-		{
-			Name:      "key1",
-			Value:     "val1",
-			ReadOnly:  false,
-			Default:   false,
-			Source:    0,
-			Sensitive: false,
-			Synonyms:  nil,
-		},
+	admin.On("Controller").Return(broker, nil)
+
+	configEntries, err := client.GetConfig(ConfigResource{Type: client.GetTopicResourceType(), Name: "topic1"})
+
+	require.NoError(t, err)
+	require.NotEmpty(t, configEntries)
+	assert.Equal(t, "max.message.bytes", configEntries[0].Name)
+	assert.Equal(t, "1000000", configEntries[0].Value)
+	require.Len(t, configEntries[0].Synonyms, 1)
+	assert.Equal(t, "max.message.bytes", configEntries[0].Synonyms[0].ConfigName)
+	assert.Equal(t, "500000", configEntries[0].Synonyms[0].ConfigValue)
+	admin.AssertExpectations(t)
+}
+
+func TestSaramaClient_ListACLsSuccess(t *testing.T) {
+	admin := &MockClusterAdmin{}
+	client := SaramaClient{admin: admin}
+	filter := sarama.AclFilter{
+		ResourceType:              sarama.AclResourceAny,
+		ResourcePatternTypeFilter: sarama.AclPatternAny,
+		Operation:                 sarama.AclOperationAny,
+		PermissionType:            sarama.AclPermissionAny,
 	}
-	expectedConfigEntries := []ConfigEntry{
-		{
-			Name:      "key1",
-			Value:     "val1",
-			ReadOnly:  false,
-			Default:   false,
-			Source:    "Unknown",
-			Sensitive: false,
-			Synonyms:  nil,
+	resourceACLs := []sarama.ResourceAcls{{
+		Resource: sarama.Resource{ResourceType: sarama.AclResourceTopic, ResourceName: "topic1", ResourcePatternType: sarama.AclPatternLiteral},
+		Acls: []*sarama.Acl{
+			{Principal: "User:alice", Host: "*", Operation: sarama.AclOperationRead, PermissionType: sarama.AclPermissionAllow},
+			{Principal: "User:bob", Host: "*", Operation: sarama.AclOperationWrite, PermissionType: sarama.AclPermissionDeny},
 		},
+	}}
+	expectedACLs := []ACL{
+		{ResourceType: "Topic", ResourceName: "topic1", PatternType: "Literal", Principal: "User:alice", Host: "*", Operation: "Read", PermissionType: "Allow"},
+		{ResourceType: "Topic", ResourceName: "topic1", PatternType: "Literal", Principal: "User:bob", Host: "*", Operation: "Write", PermissionType: "Deny"},
 	}
+	admin.On("ListAcls", filter).Return(resourceACLs, nil)
 
-	admin.On("DescribeConfig", saramaConfigResource).Return(saramaConfigEntries, nil)
-
-	configEntries, err := client.GetConfig(configResource)
+	acls, err := client.ListACLs()
 	assert.NoError(t, err)
-	assert.Equal(t, expectedConfigEntries, configEntries)
+	assert.Equal(t, expectedACLs, acls)
 	admin.AssertExpectations(t)
 }
 
-func TestSaramaClient_ShowConfigFailure(t *testing.T) {
+func TestSaramaClient_ListACLsFailure(t *testing.T) {
 	admin := &MockClusterAdmin{}
 	client := SaramaClient{admin: admin}
 	expectedErr := errors.New("error")
+	admin.On("ListAcls", mock.Anything).Return([]sarama.ResourceAcls{}, expectedErr)
+
+	_, err := client.ListACLs()
+	assert.Equal(t, expectedErr, err)
+	admin.AssertExpectations(t)
+}
 
-	configResource := ConfigResource{
-		Type:        client.GetTopicResourceType(),
-		Name:        "topic1",
-		ConfigNames: nil,
+func TestSaramaClient_CreateACLsSuccess(t *testing.T) {
+	admin := &MockClusterAdmin{}
+	client := SaramaClient{admin: admin}
+	acls := []ACL{
+		{ResourceType: "Topic", ResourceName: "topic1", PatternType: "Literal", Principal: "User:alice", Host: "*", Operation: "Read", PermissionType: "Allow"},
+		{ResourceType: "Topic", ResourceName: "topic1", PatternType: "Literal", Principal: "User:bob", Host: "*", Operation: "Write", PermissionType: "Deny"},
 	}
-	saramaConfigResource := sarama.ConfigResource{
-		Type:        sarama.TopicResource,
-		Name:        "topic1",
-		ConfigNames: nil,
+	resource := sarama.Resource{ResourceType: sarama.AclResourceTopic, ResourceName: "topic1", ResourcePatternType: sarama.AclPatternLiteral}
+	admin.On("CreateACLs", []*sarama.ResourceAcls{
+		{Resource: resource, Acls: []*sarama.Acl{
+			{Principal: "User:alice", Host: "*", Operation: sarama.AclOperationRead, PermissionType: sarama.AclPermissionAllow},
+		}},
+		{Resource: resource, Acls: []*sarama.Acl{
+			{Principal: "User:bob", Host: "*", Operation: sarama.AclOperationWrite, PermissionType: sarama.AclPermissionDeny},
+		}},
+	}).Return(nil)
+
+	err := client.CreateACLs(acls)
+	assert.NoError(t, err)
+	admin.AssertExpectations(t)
+}
+
+func TestSaramaClient_CreateACLsFailsForInvalidOperation(t *testing.T) {
+	admin := &MockClusterAdmin{}
+	client := SaramaClient{admin: admin}
+	acls := []ACL{{ResourceType: "Topic", ResourceName: "topic1", PatternType: "Literal", Principal: "User:alice", Host: "*",
+		Operation: "Fly", PermissionType: "Allow"}}
+
+	err := client.CreateACLs(acls)
+	assert.Error(t, err)
+	admin.AssertNotCalled(t, "CreateACLs", mock.Anything)
+}
+
+func TestSaramaClient_CreateACLsFailure(t *testing.T) {
+	admin := &MockClusterAdmin{}
+	client := SaramaClient{admin: admin}
+	expectedErr := errors.New("error")
+	acls := []ACL{{ResourceType: "Topic", ResourceName: "topic1", PatternType: "Literal", Principal: "User:alice", Host: "*",
+		Operation: "Read", PermissionType: "Allow"}}
+	admin.On("CreateACLs", mock.Anything).Return(expectedErr)
+
+	err := client.CreateACLs(acls)
+	assert.Equal(t, expectedErr, err)
+	admin.AssertExpectations(t)
+}
+
+func TestSaramaClient_GetConfigsSuccess(t *testing.T) {
+	seedBroker := sarama.NewMockBroker(t, 1)
+	defer seedBroker.Close()
+	seedBroker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"DescribeConfigsRequest": sarama.NewMockDescribeConfigsResponse(t),
+	})
+	broker := openBroker(t, seedBroker.Addr())
+	defer broker.Close()
+	admin := &MockClusterAdmin{}
+	client := SaramaClient{admin: admin}
+	admin.On("Controller").Return(broker, nil)
+
+	configs, err := client.GetConfigs([]ConfigResource{
+		{Type: client.GetTopicResourceType(), Name: "topic1"},
+		{Type: client.GetTopicResourceType(), Name: "topic2"},
+	})
+	require.NoError(t, err)
+	assert.Len(t, configs, 2)
+	for _, topic := range []string{"topic1", "topic2"} {
+		require.NotEmpty(t, configs[topic])
+		assert.Equal(t, "max.message.bytes", configs[topic][0].Name)
+		assert.Equal(t, "1000000", configs[topic][0].Value)
+		assert.Equal(t, "Default", configs[topic][0].Source)
+		assert.Len(t, configs[topic][0].Synonyms, 1)
 	}
+	admin.AssertExpectations(t)
+}
 
-	admin.On("DescribeConfig", saramaConfigResource).Return([]sarama.ConfigEntry{}, expectedErr)
+func TestSaramaClient_GetConfigsResourceFailure(t *testing.T) {
+	seedBroker := sarama.NewMockBroker(t, 1)
+	defer seedBroker.Close()
+	seedBroker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"DescribeConfigsRequest": sarama.NewMockDescribeConfigsResponseWithErrorCode(t),
+	})
+	broker := openBroker(t, seedBroker.Addr())
+	defer broker.Close()
+	admin := &MockClusterAdmin{}
+	client := SaramaClient{admin: admin}
+	admin.On("Controller").Return(broker, nil)
 
-	_, err := client.GetConfig(configResource)
+	_, err := client.GetConfigs([]ConfigResource{{Type: client.GetTopicResourceType(), Name: "topic1"}})
 	assert.Error(t, err)
+	admin.AssertExpectations(t)
+}
+
+func TestSaramaClient_GetConfigsControllerFailure(t *testing.T) {
+	admin := &MockClusterAdmin{}
+	client := SaramaClient{admin: admin}
+	expectedErr := errors.New("error")
+	admin.On("Controller").Return(nil, expectedErr)
+
+	_, err := client.GetConfigs([]ConfigResource{{Type: client.GetTopicResourceType(), Name: "topic1"}})
+	assert.Equal(t, expectedErr, err)
+	admin.AssertExpectations(t)
+}
+
+func openBroker(t *testing.T, addr string) *sarama.Broker {
+	cfg := sarama.NewConfig()
+	cfg.Version = sarama.V2_0_0_0
+	broker := sarama.NewBroker(addr)
+	require.NoError(t, broker.Open(cfg))
+	return broker
+}
+
+func TestSaramaClient_ShowConfigFailure(t *testing.T) {
+	admin := &MockClusterAdmin{}
+	client := SaramaClient{admin: admin}
+	expectedErr := errors.New("error")
+	admin.On("Controller").Return(nil, expectedErr)
+
+	_, err := client.GetConfig(ConfigResource{Type: client.GetTopicResourceType(), Name: "topic1"})
 	assert.Equal(t, expectedErr, err)
+
+	_, err = client.GetConfig(ConfigResource{Type: int(sarama.BrokerResource), Name: "one"})
+	assert.EqualError(t, err, `invalid broker id one - strconv.ParseInt: parsing "one": invalid syntax`)
 	admin.AssertExpectations(t)
 }
 
@@ -195,6 +327,8 @@ func TestSaramaClient_DeleteTopicSuccess(t *testing.T) {
 	client := SaramaClient{admin: admin}
 	topics := []string{"topic-1", "topic-2"}
 	admin.On("DeleteTopic", mock.Anything).Return(nil)
+	admin.On("DescribeTopics", []string{"topic-1"}).Return([]*sarama.TopicMetadata{{Name: "topic-1", Err: sarama.ErrUnknownTopicOrPartition}}, nil)
+	admin.On("DescribeTopics", []string{"topic-2"}).Return([]*sarama.TopicMetadata{{Name: "topic-2", Err: sarama.ErrUnknownTopicOrPartition}}, nil)
 
 	err := client.DeleteTopic(topics)
 
@@ -202,6 +336,55 @@ func TestSaramaClient_DeleteTopicSuccess(t *testing.T) {
 	admin.AssertExpectations(t)
 }
 
+func TestSaramaClient_DeleteTopicReturnsTheErrorsOfTheTopicsNotDeleted(t *testing.T) {
+	admin := &MockClusterAdmin{}
+	client := SaramaClient{admin: admin}
+	topics := []string{"topic-1", "topic-2", "topic-3"}
+	admin.On("DeleteTopic", "topic-1").Return(nil)
+	admin.On("DeleteTopic", "topic-2").Return(sarama.ErrTopicAuthorizationFailed)
+	admin.On("DeleteTopic", "topic-3").Return(sarama.ErrUnknownTopicOrPartition)
+	admin.On("DescribeTopics", []string{"topic-1"}).Return([]*sarama.TopicMetadata{{Name: "topic-1", Err: sarama.ErrUnknownTopicOrPartition}}, nil)
+
+	err := client.DeleteTopic(topics)
+
+	var deleteErr *DeleteTopicsError
+	require.True(t, errors.As(err, &deleteErr))
+	assert.Equal(t, 3, deleteErr.Total)
+	assert.Equal(t, map[string]error{"topic-2": sarama.ErrTopicAuthorizationFailed, "topic-3": sarama.ErrUnknownTopicOrPartition}, deleteErr.Errors)
+	assert.Equal(t, "err while deleting 2 of 3 topics - topic-2: "+sarama.ErrTopicAuthorizationFailed.Error()+", topic-3: "+
+		sarama.ErrUnknownTopicOrPartition.Error(), err.Error())
+	admin.AssertExpectations(t)
+}
+
+func TestSaramaClient_DeleteTopicFailsIfTheTopicRemainsInTheMetadata(t *testing.T) {
+	timeout, pollInterval := deleteTopicTimeout, deleteTopicPollInterval
+	deleteTopicTimeout, deleteTopicPollInterval = 20*time.Millisecond, 5*time.Millisecond
+	defer func() { deleteTopicTimeout, deleteTopicPollInterval = timeout, pollInterval }()
+	admin := &MockClusterAdmin{}
+	client := SaramaClient{admin: admin}
+	admin.On("DeleteTopic", "topic-1").Return(nil)
+	admin.On("DescribeTopics", []string{"topic-1"}).Return([]*sarama.TopicMetadata{{Name: "topic-1", Err: sarama.ErrNoError}}, nil)
+
+	err := client.DeleteTopic([]string{"topic-1"})
+
+	var deleteErr *DeleteTopicsError
+	require.True(t, errors.As(err, &deleteErr))
+	assert.Contains(t, deleteErr.Errors, "topic-1")
+	admin.AssertExpectations(t)
+}
+
+func TestSaramaClient_DeleteRecordsSuccess(t *testing.T) {
+	admin := &MockClusterAdmin{}
+	client := SaramaClient{admin: admin}
+	partitionOffsets := map[int32]int64{0: 10, 1: 20}
+	admin.On("DeleteRecords", "topic-1", partitionOffsets).Return(nil)
+
+	err := client.DeleteRecords("topic-1", partitionOffsets)
+
+	assert.NoError(t, err)
+	admin.AssertExpectations(t)
+}
+
 func TestSaramaClient_ListBrokersSuccess(t *testing.T) {
 	saramaClient := &MockSaramaClient{}
 	client := SaramaClient{client: saramaClient}
@@ -228,6 +411,45 @@ func TestSaramaClient_CreateTopicSuccess(t *testing.T) {
 	admin.AssertExpectations(t)
 }
 
+func TestSaramaClient_CreateTopicWithReplicaAssignment(t *testing.T) {
+	admin := &MockClusterAdmin{}
+	client := SaramaClient{admin: admin}
+	topicName := "topic-1"
+	assignment := map[int32][]int32{0: {1, 2}}
+	detail := TopicDetail{NumPartitions: 1, ReplicationFactor: 2, ReplicaAssignment: assignment}
+	adminDetail := &sarama.TopicDetail{NumPartitions: -1, ReplicationFactor: -1, ReplicaAssignment: assignment}
+	admin.On("CreateTopic", topicName, adminDetail, false).Return(nil)
+
+	err := client.CreateTopic(topicName, detail, false)
+
+	assert.NoError(t, err)
+	admin.AssertExpectations(t)
+}
+
+func TestSaramaClient_DescribeBrokersSuccess(t *testing.T) {
+	admin := &MockClusterAdmin{}
+	client := SaramaClient{admin: admin}
+	admin.On("DescribeCluster").Return([]*sarama.Broker{sarama.NewBroker("broker1:9092")}, int32(1), nil)
+
+	brokers, err := client.DescribeBrokers()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Broker{{ID: -1, Addr: "broker1:9092"}}, brokers)
+	admin.AssertExpectations(t)
+}
+
+func TestSaramaClient_DescribeBrokersFailure(t *testing.T) {
+	admin := &MockClusterAdmin{}
+	client := SaramaClient{admin: admin}
+	expectedErr := errors.New("error")
+	admin.On("DescribeCluster").Return([]*sarama.Broker{}, int32(0), expectedErr)
+
+	_, err := client.DescribeBrokers()
+
+	assert.Equal(t, expectedErr, err)
+	admin.AssertExpectations(t)
+}
+
 func TestSaramaClient_CreateTopicFailure(t *testing.T) {
 	admin := &MockClusterAdmin{}
 	client := SaramaClient{admin: admin}
@@ -303,6 +525,99 @@ func TestSaramaClient_GetConsumerGroupsForTopic(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// memberAssignment encodes the assignment of the partitions of the topic to a consumer group member
+func memberAssignment(topic string, partitions ...int32) []byte {
+	buf := &bytes.Buffer{}
+	_ = binary.Write(buf, binary.BigEndian, int16(0))
+	_ = binary.Write(buf, binary.BigEndian, int32(1))
+	_ = binary.Write(buf, binary.BigEndian, int16(len(topic)))
+	buf.WriteString(topic)
+	_ = binary.Write(buf, binary.BigEndian, int32(len(partitions)))
+	for _, partition := range partitions {
+		_ = binary.Write(buf, binary.BigEndian, partition)
+	}
+	_ = binary.Write(buf, binary.BigEndian, int32(-1))
+	return buf.Bytes()
+}
+
+func TestSaramaClient_ListSubscribedTopics(t *testing.T) {
+	admin := &MockClusterAdmin{}
+	client := SaramaClient{admin: admin}
+	admin.On("ListConsumerGroups").Return(map[string]string{"group-2": "consumer", "group-1": "consumer", "group-3": "consumer"}, nil)
+	admin.On("DescribeConsumerGroups", []string{"group-1", "group-2", "group-3"}).Return([]*sarama.GroupDescription{
+		{GroupId: "group-1", Members: map[string]*sarama.GroupMemberDescription{
+			"member-1": {MemberAssignment: memberAssignment("orders", 0)},
+			"member-2": {MemberAssignment: memberAssignment("orders", 1)},
+		}},
+		{GroupId: "group-2", Members: map[string]*sarama.GroupMemberDescription{
+			"member-1": {MemberAssignment: memberAssignment("payments", 0, 1)},
+		}},
+		{GroupId: "group-3", Members: map[string]*sarama.GroupMemberDescription{}},
+	}, nil)
+
+	subscriptions, err := client.ListSubscribedTopics()
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{"orders": {"group-1"}, "payments": {"group-2"}}, subscriptions)
+	admin.AssertExpectations(t)
+}
+
+func TestSaramaClient_ListCommittedTopics(t *testing.T) {
+	admin := &MockClusterAdmin{}
+	client := SaramaClient{admin: admin}
+	admin.On("ListConsumerGroups").Return(map[string]string{"group-1": "consumer", "group-2": "consumer"}, nil)
+	admin.On("ListConsumerGroupOffsets", "group-1", map[string][]int32(nil)).Return(&sarama.OffsetFetchResponse{
+		Blocks: map[string]map[int32]*sarama.OffsetFetchResponseBlock{
+			"orders":   {0: {Offset: 10}, 1: {Offset: -1}},
+			"payments": {0: {Offset: -1}},
+		},
+	}, nil)
+	admin.On("ListConsumerGroupOffsets", "group-2", map[string][]int32(nil)).Return(&sarama.OffsetFetchResponse{
+		Blocks: map[string]map[int32]*sarama.OffsetFetchResponseBlock{"orders": {0: {Offset: 5}}},
+	}, nil)
+
+	committed, err := client.ListCommittedTopics()
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{"orders": {"group-1", "group-2"}}, committed)
+	admin.AssertExpectations(t)
+}
+
+func TestSaramaClient_ListCommittedOffsets(t *testing.T) {
+	admin := &MockClusterAdmin{}
+	client := SaramaClient{admin: admin}
+	admin.On("ListConsumerGroups").Return(map[string]string{"group-1": "consumer", "group-2": "consumer"}, nil)
+	admin.On("ListConsumerGroupOffsets", "group-1", map[string][]int32(nil)).Return(&sarama.OffsetFetchResponse{
+		Blocks: map[string]map[int32]*sarama.OffsetFetchResponseBlock{
+			"orders":   {0: {Offset: 10}, 1: {Offset: -1}},
+			"payments": {0: {Offset: -1}},
+		},
+	}, nil)
+	admin.On("ListConsumerGroupOffsets", "group-2", map[string][]int32(nil)).Return(&sarama.OffsetFetchResponse{
+		Blocks: map[string]map[int32]*sarama.OffsetFetchResponseBlock{"orders": {0: {Offset: 5}, 1: {Offset: 7}}},
+	}, nil)
+
+	offsets, err := client.ListCommittedOffsets()
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]map[int32]int64{
+		"orders": {"group-1": {0: 10}, "group-2": {0: 5, 1: 7}},
+	}, offsets)
+	admin.AssertExpectations(t)
+}
+
+func TestSaramaClient_ListCommittedOffsetsFailure(t *testing.T) {
+	admin := &MockClusterAdmin{}
+	client := SaramaClient{admin: admin}
+	admin.On("ListConsumerGroups").Return(map[string]string{"group-1": "consumer"}, nil)
+	admin.On("ListConsumerGroupOffsets", "group-1", map[string][]int32(nil)).Return(&sarama.OffsetFetchResponse{}, errors.New("error"))
+
+	_, err := client.ListCommittedOffsets()
+
+	assert.Error(t, err)
+	admin.AssertExpectations(t)
+}
+
 func TestSaramaClient_GetEmptyTopicsSuccess(t *testing.T) {
 	admin := &MockClusterAdmin{}
 	mockClient := &MockSaramaClient{}
@@ -408,3 +723,281 @@ func getSaramaBrokerMetaData(configMap map[int32][]string, err sarama.KError) ma
 	}
 	return brokerMap
 }
+
+func TestSaramaClient_ReadMessagesUntilEndOffset(t *testing.T) {
+	consumer := mocks.NewConsumer(t, nil)
+	partitionConsumer := consumer.ExpectConsumePartition("topic", 0, 5)
+	for i := 0; i < 3; i++ {
+		partitionConsumer.YieldMessage(&sarama.ConsumerMessage{Key: []byte("key"), Value: []byte(strconv.Itoa(i)),
+			Headers: []*sarama.RecordHeader{{Key: []byte("h"), Value: []byte("v")}}})
+	}
+	client := SaramaClient{consumer: consumer}
+
+	var messages []*Message
+	err := client.ReadMessages(context.Background(), "topic", 0, 5, 7, func(msg *Message) error {
+		messages = append(messages, msg)
+		return nil
+	})
+
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	assert.Equal(t, int64(5), messages[0].Offset)
+	assert.Equal(t, []byte("1"), messages[1].Value)
+	assert.Equal(t, []MessageHeader{{Key: []byte("h"), Value: []byte("v")}}, messages[1].Headers)
+}
+
+func TestSaramaClient_ReadMessagesStopsOnHandlerError(t *testing.T) {
+	consumer := mocks.NewConsumer(t, nil)
+	partitionConsumer := consumer.ExpectConsumePartition("topic", 0, OffsetOldest)
+	partitionConsumer.YieldMessage(&sarama.ConsumerMessage{Value: []byte("value")})
+	client := SaramaClient{consumer: consumer}
+
+	err := client.ReadMessages(context.Background(), "topic", 0, OffsetOldest, OffsetNewest, func(msg *Message) error {
+		return ErrStopReading
+	})
+	assert.NoError(t, err)
+}
+
+func TestSaramaClient_ReadMessagesCancelled(t *testing.T) {
+	consumer := mocks.NewConsumer(t, nil)
+	consumer.ExpectConsumePartition("topic", 0, OffsetNewest)
+	client := SaramaClient{consumer: consumer}
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	cancelFunc()
+
+	err := client.ReadMessages(ctx, "topic", 0, OffsetNewest, OffsetNewest, func(msg *Message) error {
+		return nil
+	})
+	assert.Equal(t, context.Canceled, err)
+}
+
+func idleClient(t *testing.T, fetchResponse *sarama.MockFetchResponse) (*SaramaClient, func()) {
+	readIdleTimeout = 10 * time.Millisecond
+	seedBroker := sarama.NewMockBroker(t, 1)
+	seedBroker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).SetBroker(seedBroker.Addr(), seedBroker.BrokerID()).
+			SetLeader("topic", 0, seedBroker.BrokerID()),
+		"FetchRequest": fetchResponse,
+	})
+	config := sarama.NewConfig()
+	config.Version = sarama.V0_11_0_0
+	saramaClient, err := sarama.NewClient([]string{seedBroker.Addr()}, config)
+	require.NoError(t, err)
+	consumer := mocks.NewConsumer(t, nil)
+	consumer.ExpectConsumePartition("topic", 0, 5)
+	return &SaramaClient{client: saramaClient, consumer: consumer}, func() {
+		readIdleTimeout = 5 * time.Second
+		_ = saramaClient.Close()
+		seedBroker.Close()
+	}
+}
+
+func TestSaramaClient_ReadMessagesReachesTheEndWhenNoMessagesAreLeft(t *testing.T) {
+	client, closeClient := idleClient(t, sarama.NewMockFetchResponse(t, 1).SetHighWaterMark("topic", 0, 7))
+	defer closeClient()
+
+	err := client.ReadMessages(context.Background(), "topic", 0, 5, 7, func(msg *Message) error {
+		return nil
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestSaramaClient_ReadMessagesFailsWhenIdleBeforeTheMessagesLeft(t *testing.T) {
+	client, closeClient := idleClient(t, sarama.NewMockFetchResponse(t, 1).SetMessage("topic", 0, 5, sarama.StringEncoder("value")).
+		SetHighWaterMark("topic", 0, 7))
+	defer closeClient()
+
+	err := client.ReadMessages(context.Background(), "topic", 0, 5, 7, func(msg *Message) error {
+		return nil
+	})
+
+	assert.True(t, errors.Is(err, ErrReadIdle))
+	assert.EqualError(t, err, "stopped at offset 5 of 7 - no messages were received for a while")
+}
+
+func TestLastBatchOffset_IncludesTheBatchesOfTransactionMarkers(t *testing.T) {
+	recordsSet := []*sarama.Records{
+		{RecordBatch: &sarama.RecordBatch{FirstOffset: 5, LastOffsetDelta: 1}},
+		{RecordBatch: &sarama.RecordBatch{FirstOffset: 7, Control: true}},
+		{RecordBatch: &sarama.RecordBatch{FirstOffset: 8, LastOffsetDelta: 3, PartialTrailingRecord: true}},
+	}
+
+	assert.Equal(t, int64(8), lastBatchOffset(recordsSet, 5))
+	assert.Equal(t, int64(5), lastBatchOffset(nil, 5))
+}
+
+func TestSaramaClient_ListDynamicBrokerConfigs(t *testing.T) {
+	admin := &MockClusterAdmin{}
+	client := SaramaClient{admin: admin}
+	admin.On("DescribeCluster").Return([]*sarama.Broker{sarama.NewBroker("broker1:9092")}, int32(1), nil)
+	admin.On("DescribeConfig", sarama.ConfigResource{Type: sarama.BrokerResource, Name: ""}).Return([]sarama.ConfigEntry{
+		{Name: "log.retention.ms", Value: "1000", Source: sarama.SourceDynamicDefaultBroker},
+	}, nil)
+	admin.On("DescribeConfig", sarama.ConfigResource{Type: sarama.BrokerResource, Name: "-1"}).Return([]sarama.ConfigEntry{
+		{Name: "log.cleaner.threads", Value: "2", Source: sarama.SourceDynamicBroker},
+		{Name: "log.retention.ms", Value: "1000", Source: sarama.SourceDynamicDefaultBroker},
+		{Name: "num.io.threads", Value: "8", Source: sarama.SourceStaticBroker},
+		{Name: "ssl.keystore.password", Source: sarama.SourceDynamicBroker, Sensitive: true},
+	}, nil)
+
+	configs, err := client.ListDynamicBrokerConfigs()
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]string{
+		"":   {"log.retention.ms": "1000"},
+		"-1": {"log.cleaner.threads": "2", "log.retention.ms": "1000"},
+	}, configs)
+	admin.AssertExpectations(t)
+}
+
+func TestSaramaClient_ListDynamicBrokerConfigsFailure(t *testing.T) {
+	admin := &MockClusterAdmin{}
+	client := SaramaClient{admin: admin}
+	admin.On("DescribeCluster").Return([]*sarama.Broker{}, int32(1), nil)
+	admin.On("DescribeConfig", sarama.ConfigResource{Type: sarama.BrokerResource, Name: ""}).Return([]sarama.ConfigEntry{}, errors.New("not authorized"))
+
+	_, err := client.ListDynamicBrokerConfigs()
+
+	assert.EqualError(t, err, `err while describing the configs of broker "" - not authorized`)
+}
+
+func TestSaramaClient_UpdateBrokerConfigs(t *testing.T) {
+	admin := &MockClusterAdmin{}
+	client := SaramaClient{admin: admin}
+	value := "2"
+	admin.On("IncrementalAlterConfig", sarama.BrokerResource, "1", map[string]sarama.IncrementalAlterConfigsEntry{
+		"log.cleaner.threads": {Operation: sarama.IncrementalAlterConfigsOperationSet, Value: &value},
+	}, false).Return(nil)
+
+	err := client.UpdateBrokerConfigs("1", map[string]string{"log.cleaner.threads": "2"})
+
+	assert.NoError(t, err)
+	admin.AssertExpectations(t)
+}
+
+func TestSaramaClient_UpdateBrokerConfigsOnLegacyBrokers(t *testing.T) {
+	admin := &MockClusterAdmin{}
+	client := SaramaClient{admin: admin, legacyAlterConfigs: true}
+	admin.On("DescribeConfig", sarama.ConfigResource{Type: sarama.BrokerResource, Name: "1"}).Return([]sarama.ConfigEntry{
+		{Name: "log.cleaner.threads", Value: "1", Source: sarama.SourceDynamicBroker},
+		{Name: "log.retention.ms", Value: "1000", Source: sarama.SourceDynamicBroker},
+		{Name: "num.io.threads", Value: "8", Source: sarama.SourceStaticBroker},
+	}, nil)
+	threads, retention := "2", "1000"
+	admin.On("AlterConfig", sarama.BrokerResource, "1", map[string]*string{"log.cleaner.threads": &threads, "log.retention.ms": &retention},
+		false).Return(nil)
+
+	err := client.UpdateBrokerConfigs("1", map[string]string{"log.cleaner.threads": "2"})
+
+	assert.NoError(t, err)
+	admin.AssertExpectations(t)
+}
+
+func TestSaramaClient_UpdateConfigOnLegacyBrokers(t *testing.T) {
+	admin := &MockClusterAdmin{}
+	client := SaramaClient{admin: admin, legacyAlterConfigs: true}
+	admin.On("DescribeConfig", sarama.ConfigResource{Type: sarama.TopicResource, Name: "orders"}).Return([]sarama.ConfigEntry{
+		{Name: "retention.ms", Value: "1000", Source: sarama.SourceTopic},
+		{Name: "cleanup.policy", Value: "compact", Source: sarama.SourceTopic},
+		{Name: "segment.ms", Value: "10", Source: sarama.SourceDefault},
+	}, nil)
+	value := "delete"
+	admin.On("AlterConfig", sarama.TopicResource, "orders", map[string]*string{"cleanup.policy": &value}, true).Return(nil)
+
+	err := client.UpdateConfig(client.GetTopicResourceType(), "orders", map[string]IncrementalConfigEntry{
+		"cleanup.policy": {Operation: ConfigOperationSet, Value: &value},
+		"retention.ms":   {Operation: ConfigOperationDelete},
+	}, true)
+
+	assert.NoError(t, err)
+	admin.AssertExpectations(t)
+	admin.AssertNotCalled(t, "IncrementalAlterConfig", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSaramaClient_UpdateConfigOnLegacyBrokersFailures(t *testing.T) {
+	admin := &MockClusterAdmin{}
+	client := SaramaClient{admin: admin, legacyAlterConfigs: true}
+	admin.On("DescribeConfig", sarama.ConfigResource{Type: sarama.BrokerResource, Name: ""}).Return([]sarama.ConfigEntry{
+		{Name: "ssl.keystore.password", Sensitive: true, Source: sarama.SourceDynamicDefaultBroker},
+	}, nil)
+	admin.On("DescribeConfig", sarama.ConfigResource{Type: sarama.TopicResource, Name: "orders"}).Return([]sarama.ConfigEntry{}, nil)
+
+	err := client.UpdateBrokerConfigs("", map[string]string{"log.cleaner.threads": "2"})
+	assert.EqualError(t, err, `err while changing configs of "" - sensitive config ssl.keystore.password would be lost, kafka 2.3.0 or newer is needed`)
+
+	value := "1"
+	err = client.UpdateConfig(client.GetTopicResourceType(), "orders", map[string]IncrementalConfigEntry{
+		"retention.ms": {Operation: ConfigOperationAppend, Value: &value},
+	}, false)
+	assert.EqualError(t, err, "err while changing config retention.ms - only set and delete are supported by kafka versions older than 2.3.0")
+	admin.AssertNotCalled(t, "AlterConfig", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSetKafkaVersion(t *testing.T) {
+	defer func() {
+		require.NoError(t, SetKafkaVersion(DefaultKafkaVersion))
+	}()
+
+	assert.NoError(t, SetKafkaVersion("2.8.0"))
+	assert.Equal(t, sarama.V2_8_0_0, kafkaVersion)
+	assert.EqualError(t, SetKafkaVersion("1.1.0"), "kafka version 1.1.0 is not supported, expected at least 2.0.0")
+	assert.Error(t, SetKafkaVersion("two"))
+}
+
+func TestSaramaClient_FilterACLs(t *testing.T) {
+	admin := &MockClusterAdmin{}
+	client := SaramaClient{admin: admin}
+	name, principal := "orders", "User:alice"
+	filter := sarama.AclFilter{
+		ResourceType:              sarama.AclResourceTopic,
+		ResourceName:              &name,
+		ResourcePatternTypeFilter: sarama.AclPatternPrefixed,
+		Principal:                 &principal,
+		Operation:                 sarama.AclOperationAny,
+		PermissionType:            sarama.AclPermissionAllow,
+	}
+	admin.On("ListAcls", filter).Return([]sarama.ResourceAcls{{
+		Resource: sarama.Resource{ResourceType: sarama.AclResourceTopic, ResourceName: "orders", ResourcePatternType: sarama.AclPatternPrefixed},
+		Acls:     []*sarama.Acl{{Principal: "User:alice", Host: "*", Operation: sarama.AclOperationRead, PermissionType: sarama.AclPermissionAllow}},
+	}}, nil)
+
+	acls, err := client.FilterACLs(ACLFilter{ResourceType: "topic", ResourceName: "orders", PatternType: "prefixed", Principal: "User:alice",
+		PermissionType: "allow"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []ACL{{ResourceType: "Topic", ResourceName: "orders", PatternType: "Prefixed", Principal: "User:alice", Host: "*",
+		Operation: "Read", PermissionType: "Allow"}}, acls)
+	admin.AssertExpectations(t)
+}
+
+func TestSaramaClient_FilterACLsFailsForInvalidOperation(t *testing.T) {
+	admin := &MockClusterAdmin{}
+	client := SaramaClient{admin: admin}
+
+	_, err := client.FilterACLs(ACLFilter{Operation: "reed"})
+
+	assert.EqualError(t, err, "invalid operation reed - no acl operation with name reed")
+	admin.AssertNotCalled(t, "ListAcls", mock.Anything)
+}
+
+func TestSaramaClient_DeleteACLsDeletesEachACLExactly(t *testing.T) {
+	admin := &MockClusterAdmin{}
+	client := SaramaClient{admin: admin}
+	name, principal, host := "orders", "User:alice", "*"
+	admin.On("DeleteACL", sarama.AclFilter{
+		ResourceType:              sarama.AclResourceTopic,
+		ResourceName:              &name,
+		ResourcePatternTypeFilter: sarama.AclPatternLiteral,
+		Principal:                 &principal,
+		Host:                      &host,
+		Operation:                 sarama.AclOperationRead,
+		PermissionType:            sarama.AclPermissionAllow,
+	}, false).Return([]sarama.MatchingAcl{}, nil)
+
+	err := client.DeleteACLs([]ACL{{ResourceType: "Topic", ResourceName: "orders", PatternType: "Literal", Principal: "User:alice", Host: "*",
+		Operation: "Read", PermissionType: "Allow"}})
+
+	assert.NoError(t, err)
+	admin.AssertExpectations(t)
+}
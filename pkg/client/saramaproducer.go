@@ -0,0 +1,168 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/Shopify/sarama"
+)
+
+// Partitioners to choose the partition of the messages written by the producer
+const (
+	// PartitionerManual writes the messages to the partition set on them
+	PartitionerManual     = "manual"
+	PartitionerHash       = "hash"
+	PartitionerRandom     = "random"
+	PartitionerRoundRobin = "roundrobin"
+)
+
+// Compression codecs of the messages written by the producer
+const (
+	CompressionNone   = "none"
+	CompressionGzip   = "gzip"
+	CompressionSnappy = "snappy"
+	CompressionLz4    = "lz4"
+	CompressionZstd   = "zstd"
+)
+
+// Acks are the acknowledgements the producer waits for before a message is considered written
+const (
+	AcksAll    = "all"
+	AcksLeader = "leader"
+	AcksNone   = "none"
+)
+
+type ProducerConfig struct {
+	Partitioner string
+	// Compression defaults to no compression
+	Compression string
+	// Acks defaults to waiting for all the in sync replicas
+	Acks string
+	// Idempotent makes the broker discard the duplicates written on retries, and needs the acks to be all
+	Idempotent bool
+}
+
+type SaramaProducer struct {
+	client   sarama.Client
+	producer sarama.SyncProducer
+}
+
+func NewSaramaProducer(addr []string, config ProducerConfig) (*SaramaProducer, error) {
+	cfg, err := newProducerConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := sarama.NewClient(addr, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("err on creating client for %s - %v", addr, err)
+	}
+	producer, err := sarama.NewSyncProducerFromClient(client)
+	if err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("err on creating producer for %s - %v", addr, err)
+	}
+	return &SaramaProducer{client: client, producer: producer}, nil
+}
+
+func newProducerConfig(config ProducerConfig) (*sarama.Config, error) {
+	cfg := sarama.NewConfig()
+	cfg.Version = kafkaVersion
+	cfg.Producer.Return.Successes = true
+	partitioner, err := newPartitioner(config.Partitioner)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Producer.Partitioner = partitioner
+
+	compressions := map[string]sarama.CompressionCodec{
+		"":                sarama.CompressionNone,
+		CompressionNone:   sarama.CompressionNone,
+		CompressionGzip:   sarama.CompressionGZIP,
+		CompressionSnappy: sarama.CompressionSnappy,
+		CompressionLz4:    sarama.CompressionLZ4,
+		CompressionZstd:   sarama.CompressionZSTD,
+	}
+	var ok bool
+	if cfg.Producer.Compression, ok = compressions[config.Compression]; !ok {
+		return nil, fmt.Errorf("invalid compression %v, expected one of none|gzip|snappy|lz4|zstd", config.Compression)
+	}
+	if cfg.Producer.Compression == sarama.CompressionZSTD && !cfg.Version.IsAtLeast(sarama.V2_1_0_0) {
+		// zstd is supported from kafka 2.1.0
+		cfg.Version = sarama.V2_1_0_0
+	}
+
+	acks := map[string]sarama.RequiredAcks{
+		"":         sarama.WaitForAll,
+		AcksAll:    sarama.WaitForAll,
+		AcksLeader: sarama.WaitForLocal,
+		AcksNone:   sarama.NoResponse,
+	}
+	if cfg.Producer.RequiredAcks, ok = acks[config.Acks]; !ok {
+		return nil, fmt.Errorf("invalid acks %v, expected one of all|leader|none", config.Acks)
+	}
+
+	if config.Idempotent {
+		if cfg.Producer.RequiredAcks != sarama.WaitForAll {
+			return nil, fmt.Errorf("idempotent producer needs the acks to be all")
+		}
+		cfg.Producer.Idempotent = true
+		cfg.Net.MaxOpenRequests = 1
+	}
+	return cfg, cfg.Validate()
+}
+
+func newPartitioner(partitioner string) (sarama.PartitionerConstructor, error) {
+	switch partitioner {
+	case PartitionerManual:
+		return sarama.NewManualPartitioner, nil
+	case PartitionerHash, "":
+		return sarama.NewHashPartitioner, nil
+	case PartitionerRandom:
+		return sarama.NewRandomPartitioner, nil
+	case PartitionerRoundRobin:
+		return sarama.NewRoundRobinPartitioner, nil
+	default:
+		return nil, fmt.Errorf("invalid partitioner %v, expected one of manual|hash|random|roundrobin", partitioner)
+	}
+}
+
+func (p *SaramaProducer) Partitions(topic string) ([]int32, error) {
+	return p.client.Partitions(topic)
+}
+
+func (p *SaramaProducer) WriteMessages(messages []*Message) error {
+	producerMessages := make([]*sarama.ProducerMessage, 0, len(messages))
+	for _, msg := range messages {
+		producerMessages = append(producerMessages, toProducerMessage(msg))
+	}
+	err := p.producer.SendMessages(producerMessages)
+	if producerErrs, ok := err.(sarama.ProducerErrors); ok && len(producerErrs) > 0 {
+		return &WriteError{Failed: len(producerErrs), Total: len(messages), Err: producerErrs[0].Err}
+	}
+	return err
+}
+
+func (p *SaramaProducer) Close() error {
+	if err := p.producer.Close(); err != nil {
+		return err
+	}
+	return p.client.Close()
+}
+
+func toProducerMessage(msg *Message) *sarama.ProducerMessage {
+	producerMessage := &sarama.ProducerMessage{
+		Topic:     msg.Topic,
+		Partition: msg.Partition,
+		Timestamp: msg.Timestamp,
+	}
+	if msg.Key != nil {
+		producerMessage.Key = sarama.ByteEncoder(msg.Key)
+	}
+	if msg.Value != nil {
+		producerMessage.Value = sarama.ByteEncoder(msg.Value)
+	}
+	for _, header := range msg.Headers {
+		producerMessage.Headers = append(producerMessage.Headers, sarama.RecordHeader{Key: header.Key, Value: header.Value})
+	}
+	return producerMessage
+}
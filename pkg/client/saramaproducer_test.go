@@ -0,0 +1,98 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaramaProducer_WriteMessagesSuccess(t *testing.T) {
+	producer := mocks.NewSyncProducer(t, nil)
+	producer.ExpectSendMessageWithCheckerFunctionAndSucceed(func(val []byte) error {
+		if string(val) != "value" {
+			return errors.New("unexpected value")
+		}
+		return nil
+	})
+	p := SaramaProducer{producer: producer}
+
+	err := p.WriteMessages([]*Message{{Topic: "topic", Key: []byte("key"), Value: []byte("value")}})
+	require.NoError(t, err)
+	assert.NoError(t, producer.Close())
+}
+
+func TestSaramaProducer_WriteMessagesFailure(t *testing.T) {
+	producer := mocks.NewSyncProducer(t, nil)
+	producer.ExpectSendMessageAndSucceed()
+	producer.ExpectSendMessageAndFail(sarama.ErrNotLeaderForPartition)
+	p := SaramaProducer{producer: producer}
+
+	err := p.WriteMessages([]*Message{{Topic: "topic", Value: []byte("1")}, {Topic: "topic", Value: []byte("2")}})
+	assert.Error(t, err)
+}
+
+func TestToProducerMessage(t *testing.T) {
+	msg := toProducerMessage(&Message{Topic: "topic", Partition: 2, Key: []byte("key"),
+		Headers: []MessageHeader{{Key: []byte("h"), Value: []byte("v")}}})
+
+	assert.Equal(t, int32(2), msg.Partition)
+	assert.Equal(t, sarama.ByteEncoder("key"), msg.Key)
+	assert.Nil(t, msg.Value)
+	assert.Equal(t, []sarama.RecordHeader{{Key: []byte("h"), Value: []byte("v")}}, msg.Headers)
+}
+
+func TestNewPartitioner(t *testing.T) {
+	for _, partitioner := range []string{"", PartitionerManual, PartitionerHash, PartitionerRandom, PartitionerRoundRobin} {
+		_, err := newPartitioner(partitioner)
+		assert.NoError(t, err)
+	}
+	_, err := newPartitioner("sticky")
+	assert.EqualError(t, err, "invalid partitioner sticky, expected one of manual|hash|random|roundrobin")
+}
+
+func TestNewProducerConfig(t *testing.T) {
+	cfg, err := newProducerConfig(ProducerConfig{Compression: CompressionZstd, Acks: AcksAll, Idempotent: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, sarama.CompressionZSTD, cfg.Producer.Compression)
+	assert.Equal(t, sarama.V2_3_0_0, cfg.Version)
+	assert.Equal(t, sarama.WaitForAll, cfg.Producer.RequiredAcks)
+	assert.True(t, cfg.Producer.Idempotent)
+	assert.Equal(t, 1, cfg.Net.MaxOpenRequests)
+}
+
+func TestNewProducerConfig_RaisesTheVersionForZstd(t *testing.T) {
+	require.NoError(t, SetKafkaVersion("2.0.0"))
+	defer func() {
+		require.NoError(t, SetKafkaVersion(DefaultKafkaVersion))
+	}()
+
+	cfg, err := newProducerConfig(ProducerConfig{Compression: CompressionZstd})
+
+	require.NoError(t, err)
+	assert.Equal(t, sarama.V2_1_0_0, cfg.Version)
+}
+
+func TestNewProducerConfig_Defaults(t *testing.T) {
+	cfg, err := newProducerConfig(ProducerConfig{})
+
+	require.NoError(t, err)
+	assert.Equal(t, sarama.CompressionNone, cfg.Producer.Compression)
+	assert.Equal(t, sarama.WaitForAll, cfg.Producer.RequiredAcks)
+	assert.False(t, cfg.Producer.Idempotent)
+}
+
+func TestNewProducerConfig_Invalid(t *testing.T) {
+	_, err := newProducerConfig(ProducerConfig{Compression: "brotli"})
+	assert.EqualError(t, err, "invalid compression brotli, expected one of none|gzip|snappy|lz4|zstd")
+
+	_, err = newProducerConfig(ProducerConfig{Acks: "some"})
+	assert.EqualError(t, err, "invalid acks some, expected one of all|leader|none")
+
+	_, err = newProducerConfig(ProducerConfig{Acks: AcksLeader, Idempotent: true})
+	assert.EqualError(t, err, "idempotent producer needs the acks to be all")
+}
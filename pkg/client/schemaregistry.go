@@ -0,0 +1,224 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Types of the schemas in the registry, a schema without a type is avro
+const (
+	SchemaTypeAvro       = "AVRO"
+	SchemaTypeProtobuf   = "PROTOBUF"
+	SchemaTypeJSONSchema = "JSON"
+)
+
+// SchemaVersionLatest refers to the latest version of a subject
+const SchemaVersionLatest = "latest"
+
+const (
+	schemaRegistryContentType = "application/vnd.schemaregistry.v1+json"
+	schemaRegistryTimeout     = 30 * time.Second
+)
+
+type SchemaReference struct {
+	Name    string `json:"name"`
+	Subject string `json:"subject"`
+	Version int    `json:"version"`
+}
+
+type Schema struct {
+	ID         int               `json:"id"`
+	Subject    string            `json:"subject,omitempty"`
+	Version    int               `json:"version,omitempty"`
+	SchemaType string            `json:"schemaType,omitempty"`
+	Schema     string            `json:"schema"`
+	References []SchemaReference `json:"references,omitempty"`
+}
+
+// Type returns the type of the schema, defaulting to avro
+func (s *Schema) Type() string {
+	if s.SchemaType == "" {
+		return SchemaTypeAvro
+	}
+	return s.SchemaType
+}
+
+type CompatibilityResult struct {
+	IsCompatible bool     `json:"is_compatible"`
+	Messages     []string `json:"messages"`
+}
+
+type SchemaRegistry interface {
+	Subjects() ([]string, error)
+	Versions(subject string) ([]int, error)
+	// GetSchema returns the schema of a version of the subject, the version can be SchemaVersionLatest
+	GetSchema(subject, version string) (*Schema, error)
+	GetSchemaByID(id int) (*Schema, error)
+	// CheckCompatibility checks whether the schema is compatible with a version of the subject
+	CheckCompatibility(subject, version string, schema *Schema) (*CompatibilityResult, error)
+	// CompatibilityLevel returns the compatibility level of the subject, or the global level when the subject has none
+	CompatibilityLevel(subject string) (string, error)
+}
+
+type SchemaRegistryConfig struct {
+	URL      string
+	Username string
+	Password string
+}
+
+// HTTPSchemaRegistry is a client of the confluent schema registry api. The schemas fetched by id are cached,
+// since they are immutable.
+type HTTPSchemaRegistry struct {
+	config     SchemaRegistryConfig
+	httpClient *http.Client
+	mu         sync.Mutex
+	schemas    map[int]*Schema
+}
+
+type schemaRegistryError struct {
+	ErrorCode int    `json:"error_code"`
+	Message   string `json:"message"`
+}
+
+func NewHTTPSchemaRegistry(config SchemaRegistryConfig) *HTTPSchemaRegistry {
+	config.URL = strings.TrimSuffix(config.URL, "/")
+	return &HTTPSchemaRegistry{
+		config:     config,
+		httpClient: &http.Client{Timeout: schemaRegistryTimeout},
+		schemas:    make(map[int]*Schema),
+	}
+}
+
+func (r *HTTPSchemaRegistry) Subjects() ([]string, error) {
+	var subjects []string
+	err := r.do(http.MethodGet, "/subjects", nil, &subjects)
+	return subjects, err
+}
+
+func (r *HTTPSchemaRegistry) Versions(subject string) ([]int, error) {
+	var versions []int
+	err := r.do(http.MethodGet, fmt.Sprintf("/subjects/%s/versions", url.PathEscape(subject)), nil, &versions)
+	return versions, err
+}
+
+func (r *HTTPSchemaRegistry) GetSchema(subject, version string) (*Schema, error) {
+	schema := &Schema{}
+	if err := r.do(http.MethodGet, fmt.Sprintf("/subjects/%s/versions/%s", url.PathEscape(subject), url.PathEscape(version)), nil, schema); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+func (r *HTTPSchemaRegistry) GetSchemaByID(id int) (*Schema, error) {
+	r.mu.Lock()
+	schema, ok := r.schemas[id]
+	r.mu.Unlock()
+	if ok {
+		return schema, nil
+	}
+
+	schema = &Schema{}
+	if err := r.do(http.MethodGet, "/schemas/ids/"+strconv.Itoa(id), nil, schema); err != nil {
+		return nil, err
+	}
+	schema.ID = id
+	r.mu.Lock()
+	r.schemas[id] = schema
+	r.mu.Unlock()
+	return schema, nil
+}
+
+func (r *HTTPSchemaRegistry) CheckCompatibility(subject, version string, schema *Schema) (*CompatibilityResult, error) {
+	request := Schema{SchemaType: schema.SchemaType, Schema: schema.Schema, References: schema.References}
+	result := &CompatibilityResult{}
+	path := fmt.Sprintf("/compatibility/subjects/%s/versions/%s?verbose=true", url.PathEscape(subject), url.PathEscape(version))
+	if err := r.do(http.MethodPost, path, request, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *HTTPSchemaRegistry) CompatibilityLevel(subject string) (string, error) {
+	var config struct {
+		CompatibilityLevel string `json:"compatibilityLevel"`
+	}
+	err := r.do(http.MethodGet, fmt.Sprintf("/config/%s?defaultToGlobal=true", url.PathEscape(subject)), nil, &config)
+	return config.CompatibilityLevel, err
+}
+
+// ResolveReferences fetches the schemas referenced by the schema, and the ones referenced by them, by the names of
+// the references. The names are the import paths of the protobuf schemas and the full names of the avro types.
+func ResolveReferences(registry SchemaRegistry, schema *Schema) (map[string]*Schema, error) {
+	resolved := make(map[string]*Schema)
+	return resolved, resolveReferences(registry, schema.References, resolved)
+}
+
+func resolveReferences(registry SchemaRegistry, references []SchemaReference, resolved map[string]*Schema) error {
+	for _, reference := range references {
+		if _, ok := resolved[reference.Name]; ok {
+			continue
+		}
+		schema, err := registry.GetSchema(reference.Subject, strconv.Itoa(reference.Version))
+		if err != nil {
+			return fmt.Errorf("err while fetching the schema %v referenced as %v - %v", reference.Subject, reference.Name, err)
+		}
+		resolved[reference.Name] = schema
+		if err := resolveReferences(registry, schema.References, resolved); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *HTTPSchemaRegistry) newRequest(method, path string, body interface{}) (*http.Request, error) {
+	var reqBody bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reqBody).Encode(body); err != nil {
+			return nil, err
+		}
+	}
+	req, err := http.NewRequest(method, r.config.URL+path, &reqBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", schemaRegistryContentType)
+	if body != nil {
+		req.Header.Set("Content-Type", schemaRegistryContentType)
+	}
+	if r.config.Username != "" {
+		req.SetBasicAuth(r.config.Username, r.config.Password)
+	}
+	return req, nil
+}
+
+func (r *HTTPSchemaRegistry) do(method, path string, body, result interface{}) error {
+	req, err := r.newRequest(method, path, body)
+	if err != nil {
+		return err
+	}
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("err while calling the schema registry - %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		var registryErr schemaRegistryError
+		if json.Unmarshal(respBody, &registryErr) == nil && registryErr.Message != "" {
+			return fmt.Errorf("schema registry returned %d - %v", registryErr.ErrorCode, registryErr.Message)
+		}
+		return fmt.Errorf("schema registry returned status %d", resp.StatusCode)
+	}
+	return json.Unmarshal(respBody, result)
+}
@@ -0,0 +1,143 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stubSchemaRegistry(t *testing.T, requests *int) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/subjects", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`["orders-value","orders-key"]`))
+	})
+	mux.HandleFunc("/subjects/orders-value/versions", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[1,2]`))
+	})
+	mux.HandleFunc("/subjects/orders-value/versions/latest", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"subject":"orders-value","version":2,"id":7,"schema":"\"string\""}`))
+	})
+	mux.HandleFunc("/subjects/unknown-value/versions/latest", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error_code":40401,"message":"Subject 'unknown-value' not found."}`))
+	})
+	mux.HandleFunc("/schemas/ids/7", func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		_, _ = w.Write([]byte(`{"schemaType":"PROTOBUF","schema":"syntax = \"proto3\";"}`))
+	})
+	mux.HandleFunc("/compatibility/subjects/orders-value/versions/latest", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "true", r.URL.Query().Get("verbose"))
+		body, _ := ioutil.ReadAll(r.Body)
+		var schema Schema
+		require.NoError(t, json.Unmarshal(body, &schema))
+		assert.Equal(t, `"int"`, schema.Schema)
+		_, _ = w.Write([]byte(`{"is_compatible":false,"messages":["type changed"]}`))
+	})
+	mux.HandleFunc("/config/orders-value", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "true", r.URL.Query().Get("defaultToGlobal"))
+		_, _ = w.Write([]byte(`{"compatibilityLevel":"BACKWARD"}`))
+	})
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, ok := r.BasicAuth()
+		if !ok || user != "user" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		assert.Equal(t, schemaRegistryContentType, r.Header.Get("Accept"))
+		mux.ServeHTTP(w, r)
+	}))
+}
+
+func TestHTTPSchemaRegistry(t *testing.T) {
+	requests := 0
+	server := stubSchemaRegistry(t, &requests)
+	defer server.Close()
+	registry := NewHTTPSchemaRegistry(SchemaRegistryConfig{URL: server.URL + "/", Username: "user", Password: "secret"})
+
+	subjects, err := registry.Subjects()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"orders-value", "orders-key"}, subjects)
+
+	versions, err := registry.Versions("orders-value")
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, versions)
+
+	schema, err := registry.GetSchema("orders-value", SchemaVersionLatest)
+	require.NoError(t, err)
+	assert.Equal(t, &Schema{ID: 7, Subject: "orders-value", Version: 2, Schema: `"string"`}, schema)
+	assert.Equal(t, SchemaTypeAvro, schema.Type())
+
+	result, err := registry.CheckCompatibility("orders-value", SchemaVersionLatest, &Schema{Schema: `"int"`})
+	require.NoError(t, err)
+	assert.Equal(t, &CompatibilityResult{IsCompatible: false, Messages: []string{"type changed"}}, result)
+
+	level, err := registry.CompatibilityLevel("orders-value")
+	require.NoError(t, err)
+	assert.Equal(t, "BACKWARD", level)
+}
+
+func TestHTTPSchemaRegistry_GetSchemaByIDIsCached(t *testing.T) {
+	requests := 0
+	server := stubSchemaRegistry(t, &requests)
+	defer server.Close()
+	registry := NewHTTPSchemaRegistry(SchemaRegistryConfig{URL: server.URL, Username: "user", Password: "secret"})
+
+	for i := 0; i < 2; i++ {
+		schema, err := registry.GetSchemaByID(7)
+		require.NoError(t, err)
+		assert.Equal(t, 7, schema.ID)
+		assert.Equal(t, SchemaTypeProtobuf, schema.Type())
+	}
+	assert.Equal(t, 1, requests)
+}
+
+func TestHTTPSchemaRegistry_Errors(t *testing.T) {
+	requests := 0
+	server := stubSchemaRegistry(t, &requests)
+	defer server.Close()
+
+	registry := NewHTTPSchemaRegistry(SchemaRegistryConfig{URL: server.URL, Username: "user", Password: "secret"})
+	_, err := registry.GetSchema("unknown-value", SchemaVersionLatest)
+	assert.EqualError(t, err, "schema registry returned 40401 - Subject 'unknown-value' not found.")
+
+	unauthorized := NewHTTPSchemaRegistry(SchemaRegistryConfig{URL: server.URL})
+	_, err = unauthorized.Subjects()
+	assert.EqualError(t, err, "schema registry returned status 401")
+}
+
+func TestResolveReferences(t *testing.T) {
+	registry := &MockSchemaRegistry{}
+	registry.On("GetSchema", "item-value", "1").Return(&Schema{Schema: "item", References: []SchemaReference{
+		{Name: "price.proto", Subject: "price-value", Version: 3},
+	}}, nil)
+	registry.On("GetSchema", "price-value", "3").Return(&Schema{Schema: "price"}, nil)
+	schema := &Schema{References: []SchemaReference{
+		{Name: "item.proto", Subject: "item-value", Version: 1},
+		{Name: "price.proto", Subject: "price-value", Version: 3},
+	}}
+
+	resolved, err := ResolveReferences(registry, schema)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(resolved))
+	assert.Equal(t, "item", resolved["item.proto"].Schema)
+	assert.Equal(t, "price", resolved["price.proto"].Schema)
+	registry.AssertNumberOfCalls(t, "GetSchema", 2)
+}
+
+func TestResolveReferences_Error(t *testing.T) {
+	registry := &MockSchemaRegistry{}
+	registry.On("GetSchema", "item-value", "1").Return(&Schema{}, errors.New("error"))
+
+	_, err := ResolveReferences(registry, &Schema{References: []SchemaReference{{Name: "item.proto", Subject: "item-value", Version: 1}}})
+
+	assert.EqualError(t, err, "err while fetching the schema item-value referenced as item.proto - error")
+}
@@ -24,7 +24,7 @@ func (s *SignalHandler) SetListener(ctx context.Context, cancelFunc context.Canc
 		select {
 		case <-ctx.Done():
 		case <-cancelChan:
-			logger.Info("Interrupt has been received, will stop reassignment after current batch")
+			logger.Info("Interrupt has been received, will stop after the current batch")
 			cancelFunc()
 
 		}
@@ -0,0 +1,69 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+
+	"github.com/gojek/kat/pkg/client"
+)
+
+// WriteACLs writes the ACLs as an indented json list, which is read back by LoadACLs
+func WriteACLs(out io.Writer, acls []client.ACL) error {
+	if acls == nil {
+		acls = []client.ACL{}
+	}
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(acls)
+}
+
+// aclFields are the json names of the fields of an ACL
+var aclFields = []string{"resourceType", "resourceName", "patternType", "principal", "host", "operation", "permissionType"}
+
+// LoadACLs reads a json list of ACLs, all the fields of each ACL are required
+func LoadACLs(file string) ([]client.ACL, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var acls []client.ACL
+	if err = json.Unmarshal(data, &acls); err != nil {
+		return nil, fmt.Errorf("err while parsing the acls of %v - %v", file, err)
+	}
+	for i, acl := range acls {
+		values := []string{acl.ResourceType, acl.ResourceName, acl.PatternType, acl.Principal, acl.Host, acl.Operation, acl.PermissionType}
+		for j, value := range values {
+			if value == "" {
+				return nil, fmt.Errorf("acl %d of %v has no %v", i+1, file, aclFields[j])
+			}
+		}
+	}
+	return acls, nil
+}
+
+// MissingACLs returns the ACLs that are not part of the existing ACLs
+func MissingACLs(acls, existing []client.ACL) []client.ACL {
+	existingSet := toSet(aclStrings(existing))
+	var missing []client.ACL
+	for _, acl := range acls {
+		if !existingSet[acl.String()] {
+			missing = append(missing, acl)
+		}
+	}
+	return missing
+}
+
+// SortACLs sorts the ACLs by resource type, resource name, pattern type, principal, host, operation and permission type
+func SortACLs(acls []client.ACL) {
+	sort.Slice(acls, func(i, j int) bool {
+		return aclSortKey(acls[i]) < aclSortKey(acls[j])
+	})
+}
+
+func aclSortKey(acl client.ACL) string {
+	return fmt.Sprintf("%v\x00%v\x00%v\x00%v\x00%v\x00%v\x00%v", acl.ResourceType, acl.ResourceName, acl.PatternType, acl.Principal,
+		acl.Host, acl.Operation, acl.PermissionType)
+}
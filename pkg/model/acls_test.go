@@ -0,0 +1,78 @@
+package model
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func aclFile(t *testing.T, data string) (string, string) {
+	dir, err := ioutil.TempDir("", "acls")
+	require.NoError(t, err)
+	file := filepath.Join(dir, "acls.json")
+	require.NoError(t, ioutil.WriteFile(file, []byte(data), 0644))
+	return dir, file
+}
+
+func TestWriteACLs_IsReadBackByLoadACLs(t *testing.T) {
+	acls := []client.ACL{{ResourceType: "Topic", ResourceName: "orders", PatternType: "Literal", Principal: "User:alice", Host: "*",
+		Operation: "Read", PermissionType: "Allow"}}
+	var out bytes.Buffer
+	require.NoError(t, WriteACLs(&out, acls))
+	dir, file := aclFile(t, out.String())
+	defer os.RemoveAll(dir)
+
+	loaded, err := LoadACLs(file)
+
+	require.NoError(t, err)
+	assert.Equal(t, acls, loaded)
+}
+
+func TestWriteACLs_WritesAnEmptyListForNoACLs(t *testing.T) {
+	var out bytes.Buffer
+
+	require.NoError(t, WriteACLs(&out, nil))
+
+	assert.Equal(t, "[]\n", out.String())
+}
+
+func TestLoadACLs_Errors(t *testing.T) {
+	dir, file := aclFile(t, `[{"resourceType": "Topic", "resourceName": "orders", "patternType": "Literal", "principal": "User:alice",
+		"host": "*", "permissionType": "Allow"}]`)
+	defer os.RemoveAll(dir)
+
+	_, err := LoadACLs(file)
+	assert.EqualError(t, err, "acl 1 of "+file+" has no operation")
+
+	require.NoError(t, ioutil.WriteFile(file, []byte(`{"acls": []}`), 0644))
+	_, err = LoadACLs(file)
+	assert.Error(t, err)
+
+	_, err = LoadACLs(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestMissingACLs(t *testing.T) {
+	read := client.ACL{ResourceType: "Topic", ResourceName: "orders", Operation: "Read"}
+	write := client.ACL{ResourceType: "Topic", ResourceName: "orders", Operation: "Write"}
+
+	assert.Equal(t, []client.ACL{write}, MissingACLs([]client.ACL{read, write}, []client.ACL{read}))
+	assert.Empty(t, MissingACLs([]client.ACL{read}, []client.ACL{read, write}))
+}
+
+func TestSortACLs(t *testing.T) {
+	group := client.ACL{ResourceType: "Group", ResourceName: "billing", Operation: "Read"}
+	write := client.ACL{ResourceType: "Topic", ResourceName: "orders", Operation: "Write"}
+	read := client.ACL{ResourceType: "Topic", ResourceName: "orders", Operation: "Read"}
+	acls := []client.ACL{write, read, group}
+
+	SortACLs(acls)
+
+	assert.Equal(t, []client.ACL{group, read, write}, acls)
+}
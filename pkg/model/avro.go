@@ -0,0 +1,127 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/gojek/kat/pkg/client"
+	"github.com/linkedin/goavro/v2"
+)
+
+// parseAvroSchema returns the codec of the schema, with the types of the referenced schemas defined where the schema
+// first uses them, since the codec only knows the types defined in the schema itself
+func parseAvroSchema(schema string, references map[string]*client.Schema) (*goavro.Codec, error) {
+	var definition interface{}
+	if err := json.Unmarshal([]byte(schema), &definition); err != nil {
+		return nil, fmt.Errorf("err while parsing the avro schema - %v", err)
+	}
+	referenced := make(avroReferences, len(references))
+	for name, reference := range references {
+		var referenceDefinition interface{}
+		if err := json.Unmarshal([]byte(reference.Schema), &referenceDefinition); err != nil {
+			return nil, fmt.Errorf("err while parsing the avro schema referenced as %v - %v", name, err)
+		}
+		referenced[name] = referenceDefinition
+	}
+
+	inlined, err := json.Marshal(referenced.inline(definition, ""))
+	if err != nil {
+		return nil, err
+	}
+	return goavro.NewCodec(string(inlined))
+}
+
+// avroReferences has the definitions of the referenced types by their full names, which are removed once inlined
+type avroReferences map[string]interface{}
+
+func (r avroReferences) inline(definition interface{}, namespace string) interface{} {
+	switch def := definition.(type) {
+	case string:
+		return r.take(def, namespace)
+	case []interface{}:
+		for i, branch := range def {
+			def[i] = r.inline(branch, namespace)
+		}
+	case map[string]interface{}:
+		namespace = avroNamespace(def, namespace)
+		for _, key := range []string{"type", "items", "values"} {
+			if value, ok := def[key]; ok {
+				def[key] = r.inline(value, namespace)
+			}
+		}
+		fields, _ := def["fields"].([]interface{})
+		for _, field := range fields {
+			r.inline(field, namespace)
+		}
+	}
+	return definition
+}
+
+// take returns the definition of the type if it is a referenced type used for the first time, or else the name
+func (r avroReferences) take(name, namespace string) interface{} {
+	fullName := name
+	if !strings.Contains(name, ".") && namespace != "" {
+		fullName = namespace + "." + name
+	}
+	for _, candidate := range []string{fullName, name} {
+		definition, ok := r[candidate]
+		if !ok {
+			continue
+		}
+		delete(r, candidate)
+		named, ok := definition.(map[string]interface{})
+		if !ok {
+			return definition
+		}
+		named["name"] = candidate
+		return r.inline(named, "")
+	}
+	return name
+}
+
+func avroNamespace(def map[string]interface{}, namespace string) string {
+	name, _ := def["name"].(string)
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return name[:i]
+	}
+	if ns, ok := def["namespace"].(string); ok {
+		return ns
+	}
+	return namespace
+}
+
+func decodeAvro(codec *goavro.Codec, data []byte) (interface{}, error) {
+	value, _, err := codec.NativeFromBinary(data)
+	if err != nil {
+		return nil, err
+	}
+	return avroJSON(value), nil
+}
+
+// avroJSON converts the decoded values into their json values. The unions keep the avro json encoding of
+// {"<type>": value}, the bytes and fixed values are printed as strings, the decimals as decimal strings and the
+// dates and times as RFC 3339 timestamps.
+func avroJSON(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = avroJSON(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = avroJSON(item)
+		}
+	case []byte:
+		return string(v)
+	case *big.Rat:
+		return new(big.Float).SetRat(v).Text('f', -1)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case time.Duration:
+		return v.String()
+	}
+	return value
+}
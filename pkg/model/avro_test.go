@@ -0,0 +1,105 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const orderAvroSchema = `{
+  "type": "record", "name": "Order", "namespace": "shop",
+  "fields": [
+    {"name": "id", "type": "long"},
+    {"name": "name", "type": "string"},
+    {"name": "tags", "type": {"type": "array", "items": "string"}},
+    {"name": "status", "type": {"type": "enum", "name": "Status", "symbols": ["NEW", "PAID"]}},
+    {"name": "note", "type": ["null", "string"]},
+    {"name": "attributes", "type": {"type": "map", "values": "int"}},
+    {"name": "parent", "type": ["null", "shop.Order"]}
+  ]
+}`
+
+func TestDecodeAvro(t *testing.T) {
+	codec, err := parseAvroSchema(orderAvroSchema, nil)
+	require.NoError(t, err)
+	data := []byte{
+		0x0a,           // id: 5
+		0x04, 'a', 'b', // name: ab
+		0x02, 0x02, 'x', 0x00, // tags: [x]
+		0x02,                 // status: PAID
+		0x02, 0x04, 'h', 'i', // note: hi
+		0x01, 0x08, 0x02, 'k', 0x06, 0x00, // attributes block of -1 item and 4 bytes: {k: 3}
+		0x00, // parent: null
+	}
+
+	value, err := decodeAvro(codec, data)
+	require.NoError(t, err)
+
+	out, err := json.Marshal(value)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":5,"name":"ab","tags":["x"],"status":"PAID","note":{"string":"hi"},"attributes":{"k":3},"parent":null}`, string(out))
+}
+
+func TestDecodeAvro_LogicalTypes(t *testing.T) {
+	codec, err := parseAvroSchema(`{
+  "type": "record", "name": "Payment",
+  "fields": [
+    {"name": "at", "type": {"type": "long", "logicalType": "timestamp-millis"}},
+    {"name": "on", "type": {"type": "int", "logicalType": "date"}},
+    {"name": "amount", "type": {"type": "bytes", "logicalType": "decimal", "precision": 6, "scale": 2}},
+    {"name": "code", "type": {"type": "fixed", "name": "Code", "size": 2}}
+  ]
+}`, nil)
+	require.NoError(t, err)
+	data := []byte{
+		0xd0, 0x0f, // at: 1000 millis
+		0x02,             // on: 1 day
+		0x04, 0x04, 0xd2, // amount: 1234 with scale 2
+		'a', 'b', // code: ab
+	}
+
+	value, err := decodeAvro(codec, data)
+	require.NoError(t, err)
+
+	out, err := json.Marshal(value)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"at":"1970-01-01T00:00:01Z","on":"1970-01-02T00:00:00Z","amount":"12.34","code":"ab"}`, string(out))
+}
+
+func TestDecodeAvro_References(t *testing.T) {
+	references := map[string]*client.Schema{
+		"shop.Item":  {Schema: `{"type": "record", "name": "Item", "namespace": "shop", "fields": [{"name": "price", "type": "shop.Price"}]}`},
+		"shop.Price": {Schema: `{"type": "fixed", "name": "Price", "namespace": "shop", "size": 1}`},
+	}
+	codec, err := parseAvroSchema(`{
+  "type": "record", "name": "Order", "namespace": "shop",
+  "fields": [
+    {"name": "item", "type": "Item"},
+    {"name": "items", "type": {"type": "array", "items": "shop.Item"}}
+  ]
+}`, references)
+	require.NoError(t, err)
+
+	value, err := decodeAvro(codec, []byte{'a', 0x02, 'b', 0x00})
+	require.NoError(t, err)
+
+	out, err := json.Marshal(value)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"item":{"price":"a"},"items":[{"price":"b"}]}`, string(out))
+}
+
+func TestDecodeAvro_Errors(t *testing.T) {
+	_, err := parseAvroSchema(`{"type": "record", "name": "R", "fields": [{"name": "f", "type": "Unknown"}]}`, nil)
+	assert.Error(t, err)
+
+	_, err = parseAvroSchema(`"string"`, map[string]*client.Schema{"R": {Schema: "{"}})
+	assert.EqualError(t, err, "err while parsing the avro schema referenced as R - unexpected end of JSON input")
+
+	codec, err := parseAvroSchema(`"string"`, nil)
+	require.NoError(t, err)
+	_, err = decodeAvro(codec, []byte{0x08, 'a'})
+	assert.Error(t, err)
+}
@@ -0,0 +1,165 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gojek/kat/pkg/client"
+)
+
+// ClusterState is the state of a cluster that is compared with other clusters
+type ClusterState struct {
+	Topics map[string]client.TopicDetail `json:"topics" yaml:"topics"`
+	// Configs has the configs overridden at the topic level, per topic
+	Configs        map[string]map[string]string `json:"configs" yaml:"configs"`
+	ACLs           []client.ACL                 `json:"acls,omitempty" yaml:"acls,omitempty"`
+	ConsumerGroups []string                     `json:"consumerGroups,omitempty" yaml:"consumerGroups,omitempty"`
+}
+
+// Types of the differences between two clusters, in the order they are reported
+const (
+	DiffTypeTopic             = "Topic"
+	DiffTypePartitions        = "Partitions"
+	DiffTypeReplicationFactor = "ReplicationFactor"
+	DiffTypeConfig            = "Config"
+	DiffTypeACL               = "ACL"
+	DiffTypeConsumerGroup     = "ConsumerGroup"
+	// The assignments, offsets and broker configs are compared only between snapshots
+	DiffTypeAssignment          = "Assignment"
+	DiffTypeConsumerGroupOffset = "ConsumerGroupOffset"
+	DiffTypeBrokerConfig        = "BrokerConfig"
+)
+
+const (
+	diffPresent = "present"
+	diffMissing = "missing"
+	// diffDefault is reported for a config that is not overridden on one of the clusters
+	diffDefault = "<default>"
+)
+
+var diffTypeOrder = map[string]int{
+	DiffTypeTopic:               0,
+	DiffTypePartitions:          1,
+	DiffTypeReplicationFactor:   2,
+	DiffTypeAssignment:          3,
+	DiffTypeConfig:              4,
+	DiffTypeACL:                 5,
+	DiffTypeConsumerGroup:       6,
+	DiffTypeConsumerGroupOffset: 7,
+	DiffTypeBrokerConfig:        8,
+}
+
+type Difference struct {
+	Type        string `json:"type"`
+	Resource    string `json:"resource"`
+	Field       string `json:"field,omitempty"`
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+}
+
+// DiffClusters compares the clusters in both directions. The ACLs and consumer groups are compared
+// only when they are present in either of the states.
+func DiffClusters(source, destination *ClusterState) []Difference {
+	var diffs []Difference
+	diffs = append(diffs, diffTopics(source, destination)...)
+	diffs = append(diffs, diffPresence(DiffTypeACL, aclStrings(source.ACLs), aclStrings(destination.ACLs))...)
+	diffs = append(diffs, diffPresence(DiffTypeConsumerGroup, source.ConsumerGroups, destination.ConsumerGroups)...)
+	sortDifferences(diffs)
+	return diffs
+}
+
+// sortDifferences sorts the differences by type, then by resource and field
+func sortDifferences(diffs []Difference) {
+	sort.SliceStable(diffs, func(i, j int) bool {
+		if diffs[i].Type != diffs[j].Type {
+			return diffTypeOrder[diffs[i].Type] < diffTypeOrder[diffs[j].Type]
+		}
+		if diffs[i].Resource != diffs[j].Resource {
+			return diffs[i].Resource < diffs[j].Resource
+		}
+		return diffs[i].Field < diffs[j].Field
+	})
+}
+
+func diffTopics(source, destination *ClusterState) []Difference {
+	var diffs []Difference
+	for topic, sourceDetail := range source.Topics {
+		destinationDetail, ok := destination.Topics[topic]
+		if !ok {
+			diffs = append(diffs, Difference{Type: DiffTypeTopic, Resource: topic, Source: diffPresent, Destination: diffMissing})
+			continue
+		}
+		if sourceDetail.NumPartitions != destinationDetail.NumPartitions {
+			diffs = append(diffs, Difference{Type: DiffTypePartitions, Resource: topic,
+				Source: fmt.Sprint(sourceDetail.NumPartitions), Destination: fmt.Sprint(destinationDetail.NumPartitions)})
+		}
+		if sourceDetail.ReplicationFactor != destinationDetail.ReplicationFactor {
+			diffs = append(diffs, Difference{Type: DiffTypeReplicationFactor, Resource: topic,
+				Source: fmt.Sprint(sourceDetail.ReplicationFactor), Destination: fmt.Sprint(destinationDetail.ReplicationFactor)})
+		}
+		diffs = append(diffs, diffConfigs(topic, source.Configs[topic], destination.Configs[topic])...)
+	}
+	for topic := range destination.Topics {
+		if _, ok := source.Topics[topic]; !ok {
+			diffs = append(diffs, Difference{Type: DiffTypeTopic, Resource: topic, Source: diffMissing, Destination: diffPresent})
+		}
+	}
+	return diffs
+}
+
+func diffConfigs(topic string, source, destination map[string]string) []Difference {
+	return diffValues(DiffTypeConfig, topic, source, destination, diffDefault)
+}
+
+// diffValues compares the fields of a resource, the fields missing on one of the sides have the absent value
+func diffValues(diffType, resource string, source, destination map[string]string, absent string) []Difference {
+	var diffs []Difference
+	for field, sourceValue := range source {
+		destinationValue, ok := destination[field]
+		if !ok {
+			destinationValue = absent
+		}
+		if sourceValue != destinationValue {
+			diffs = append(diffs, Difference{Type: diffType, Resource: resource, Field: field, Source: sourceValue, Destination: destinationValue})
+		}
+	}
+	for field, destinationValue := range destination {
+		if _, ok := source[field]; !ok {
+			diffs = append(diffs, Difference{Type: diffType, Resource: resource, Field: field, Source: absent, Destination: destinationValue})
+		}
+	}
+	return diffs
+}
+
+// diffPresence reports the elements that are present only on one of the sides
+func diffPresence(diffType string, source, destination []string) []Difference {
+	var diffs []Difference
+	sourceSet, destinationSet := toSet(source), toSet(destination)
+	for element := range sourceSet {
+		if !destinationSet[element] {
+			diffs = append(diffs, Difference{Type: diffType, Resource: element, Source: diffPresent, Destination: diffMissing})
+		}
+	}
+	for element := range destinationSet {
+		if !sourceSet[element] {
+			diffs = append(diffs, Difference{Type: diffType, Resource: element, Source: diffMissing, Destination: diffPresent})
+		}
+	}
+	return diffs
+}
+
+func toSet(list []string) map[string]bool {
+	set := make(map[string]bool, len(list))
+	for _, element := range list {
+		set[element] = true
+	}
+	return set
+}
+
+func aclStrings(acls []client.ACL) []string {
+	var result []string
+	for _, acl := range acls {
+		result = append(result, acl.String())
+	}
+	return result
+}
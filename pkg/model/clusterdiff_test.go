@@ -0,0 +1,58 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffClusters_ReportsDifferencesInBothDirections(t *testing.T) {
+	acl := client.ACL{ResourceType: "Topic", ResourceName: "orders", PatternType: "Literal", Principal: "User:alice", Host: "*",
+		Operation: "Read", PermissionType: "Allow"}
+	source := &ClusterState{
+		Topics: map[string]client.TopicDetail{
+			"orders":   {NumPartitions: 6, ReplicationFactor: 3},
+			"payments": {NumPartitions: 3, ReplicationFactor: 3},
+		},
+		Configs: map[string]map[string]string{
+			"orders": {"retention.ms": "1000", "cleanup.policy": "delete"},
+		},
+		ACLs:           []client.ACL{acl},
+		ConsumerGroups: []string{"group1", "group2"},
+	}
+	destination := &ClusterState{
+		Topics: map[string]client.TopicDetail{
+			"orders": {NumPartitions: 3, ReplicationFactor: 2},
+			"audit":  {NumPartitions: 1, ReplicationFactor: 3},
+		},
+		Configs: map[string]map[string]string{
+			"orders": {"retention.ms": "2000", "segment.bytes": "100"},
+		},
+		ConsumerGroups: []string{"group2", "group3"},
+	}
+
+	diffs := DiffClusters(source, destination)
+
+	assert.Equal(t, []Difference{
+		{Type: DiffTypeTopic, Resource: "audit", Source: "missing", Destination: "present"},
+		{Type: DiffTypeTopic, Resource: "payments", Source: "present", Destination: "missing"},
+		{Type: DiffTypePartitions, Resource: "orders", Source: "6", Destination: "3"},
+		{Type: DiffTypeReplicationFactor, Resource: "orders", Source: "3", Destination: "2"},
+		{Type: DiffTypeConfig, Resource: "orders", Field: "cleanup.policy", Source: "delete", Destination: "<default>"},
+		{Type: DiffTypeConfig, Resource: "orders", Field: "retention.ms", Source: "1000", Destination: "2000"},
+		{Type: DiffTypeConfig, Resource: "orders", Field: "segment.bytes", Source: "<default>", Destination: "100"},
+		{Type: DiffTypeACL, Resource: acl.String(), Source: "present", Destination: "missing"},
+		{Type: DiffTypeConsumerGroup, Resource: "group1", Source: "present", Destination: "missing"},
+		{Type: DiffTypeConsumerGroup, Resource: "group3", Source: "missing", Destination: "present"},
+	}, diffs)
+}
+
+func TestDiffClusters_NoDifferences(t *testing.T) {
+	state := &ClusterState{
+		Topics:  map[string]client.TopicDetail{"orders": {NumPartitions: 6, ReplicationFactor: 3}},
+		Configs: map[string]map[string]string{"orders": {"retention.ms": "1000"}},
+	}
+
+	assert.Empty(t, DiffClusters(state, state))
+}
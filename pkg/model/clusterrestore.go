@@ -0,0 +1,230 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+)
+
+// Actions of a cluster restore
+const (
+	RestoreActionCreate = "Create"
+	RestoreActionSet    = "Set"
+	RestoreActionSkip   = "Skip"
+)
+
+// Types of the resources of a cluster restore, in the order they are restored
+const (
+	RestoreResourceTopic                = "Topic"
+	RestoreResourceACL                  = "ACL"
+	RestoreResourceBrokerConfig         = "BrokerConfig"
+	RestoreResourceConsumerGroupOffsets = "ConsumerGroupOffsets"
+)
+
+// clusterDefaultBroker is shown in place of the empty broker id of the cluster wide defaults
+const clusterDefaultBroker = "<cluster default>"
+
+var restoreResourceOrder = map[string]int{
+	RestoreResourceTopic:                0,
+	RestoreResourceACL:                  1,
+	RestoreResourceBrokerConfig:         2,
+	RestoreResourceConsumerGroupOffsets: 3,
+}
+
+// RestoreAction is a change of a cluster restore, or a resource of the snapshot that is skipped
+type RestoreAction struct {
+	Action       string
+	ResourceType string
+	Resource     string
+	Detail       string
+}
+
+// ClusterRestorePlan is the changes that recreate a snapshot on a cluster
+type ClusterRestorePlan struct {
+	Topics        map[string]client.TopicDetail
+	ACLs          []client.ACL
+	BrokerConfigs map[string]map[string]string
+	// Skipped are the resources of the snapshot that already exist or are not restored, with the reason
+	Skipped []RestoreAction
+}
+
+// PlanClusterRestore compares the snapshot with the topics, ACLs and brokers of the cluster. The topics and ACLs that already
+// exist are skipped, as well as the configs of the brokers that are not part of the cluster. The consumer group offsets are not
+// restored, as the offsets of the recreated topics start from 0. An error is returned when the replica assignment of a topic
+// does not fit the brokers of the cluster, unless the assignment is ignored.
+func PlanClusterRestore(snapshot *ClusterSnapshot, topics map[string]client.TopicDetail, acls []client.ACL, brokers []client.Broker,
+	ignoreAssignment bool) (*ClusterRestorePlan, error) {
+	plan := &ClusterRestorePlan{Topics: make(map[string]client.TopicDetail), BrokerConfigs: make(map[string]map[string]string)}
+	if err := plan.planTopics(snapshot.Topics, topics, brokers, ignoreAssignment); err != nil {
+		return nil, err
+	}
+	plan.planACLs(snapshot.ACLs, acls)
+	plan.planBrokerConfigs(snapshot.BrokerConfigs, brokers)
+	for group, offsets := range snapshot.ConsumerGroupOffsets {
+		if len(offsets) > 0 {
+			plan.skip(RestoreResourceConsumerGroupOffsets, group, "offsets of the recreated topics start from 0")
+		}
+	}
+	return plan, nil
+}
+
+func (p *ClusterRestorePlan) planTopics(snapshotTopics map[string]SnapshotTopic, topics map[string]client.TopicDetail,
+	brokers []client.Broker, ignoreAssignment bool) error {
+	names := make([]string, 0, len(snapshotTopics))
+	for topic := range snapshotTopics {
+		names = append(names, topic)
+	}
+	sort.Strings(names)
+	for _, topic := range names {
+		if _, ok := topics[topic]; ok {
+			p.skip(RestoreResourceTopic, topic, "already exists")
+			continue
+		}
+		detail := snapshotTopics[topic].topicDetail(ignoreAssignment)
+		if len(detail.ReplicaAssignment) > 0 {
+			if err := ValidateAssignment(detail.ReplicaAssignment, brokers); err != nil {
+				return fmt.Errorf("invalid replica assignment of topic %v for the cluster, pass --ignore-assignment - %v", topic, err)
+			}
+		}
+		p.Topics[topic] = detail
+	}
+	return nil
+}
+
+func (p *ClusterRestorePlan) planACLs(snapshotACLs, acls []client.ACL) {
+	existing := toSet(aclStrings(acls))
+	for _, acl := range snapshotACLs {
+		if existing[acl.String()] {
+			p.skip(RestoreResourceACL, acl.String(), "already exists")
+			continue
+		}
+		p.ACLs = append(p.ACLs, acl)
+	}
+}
+
+func (p *ClusterRestorePlan) planBrokerConfigs(snapshotConfigs map[string]map[string]string, brokers []client.Broker) {
+	brokerIDs := map[string]bool{"": true}
+	for _, broker := range brokers {
+		brokerIDs[fmt.Sprint(broker.ID)] = true
+	}
+	for broker, configs := range snapshotConfigs {
+		if !brokerIDs[broker] {
+			p.skip(RestoreResourceBrokerConfig, broker, "broker is not part of the cluster")
+			continue
+		}
+		p.BrokerConfigs[broker] = configs
+	}
+}
+
+func (p *ClusterRestorePlan) skip(resourceType, resource, reason string) {
+	p.Skipped = append(p.Skipped, RestoreAction{Action: RestoreActionSkip, ResourceType: resourceType, Resource: resource, Detail: reason})
+}
+
+// HasChanges returns true if anything is restored
+func (p *ClusterRestorePlan) HasChanges() bool {
+	return len(p.Topics) > 0 || len(p.ACLs) > 0 || len(p.BrokerConfigs) > 0
+}
+
+// TopicNames returns the topics created, sorted by name
+func (p *ClusterRestorePlan) TopicNames() []string {
+	topics := make([]string, 0, len(p.Topics))
+	for topic := range p.Topics {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+// Actions returns the changes followed by the skipped resources, in the order they are restored
+func (p *ClusterRestorePlan) Actions() []RestoreAction {
+	var actions []RestoreAction
+	for topic, detail := range p.Topics {
+		placement := "replicas placed by the cluster"
+		if len(detail.ReplicaAssignment) > 0 {
+			placement = "replica assignment of the snapshot"
+		}
+		actions = append(actions, RestoreAction{Action: RestoreActionCreate, ResourceType: RestoreResourceTopic, Resource: topic,
+			Detail: fmt.Sprintf("%d partitions, replication factor %d, %d configs, %v", detail.NumPartitions, detail.ReplicationFactor,
+				len(detail.Config), placement)})
+	}
+	for _, acl := range p.ACLs {
+		actions = append(actions, RestoreAction{Action: RestoreActionCreate, ResourceType: RestoreResourceACL, Resource: acl.String()})
+	}
+	for broker, configs := range p.BrokerConfigs {
+		for name, value := range configs {
+			actions = append(actions, RestoreAction{Action: RestoreActionSet, ResourceType: RestoreResourceBrokerConfig,
+				Resource: brokerName(broker), Detail: name + "=" + value})
+		}
+	}
+	sortRestoreActions(actions)
+
+	skipped := append([]RestoreAction{}, p.Skipped...)
+	for i := range skipped {
+		if skipped[i].ResourceType == RestoreResourceBrokerConfig {
+			skipped[i].Resource = brokerName(skipped[i].Resource)
+		}
+	}
+	sortRestoreActions(skipped)
+	return append(actions, skipped...)
+}
+
+func sortRestoreActions(actions []RestoreAction) {
+	sort.SliceStable(actions, func(i, j int) bool {
+		if actions[i].ResourceType != actions[j].ResourceType {
+			return restoreResourceOrder[actions[i].ResourceType] < restoreResourceOrder[actions[j].ResourceType]
+		}
+		if actions[i].Resource != actions[j].Resource {
+			return actions[i].Resource < actions[j].Resource
+		}
+		return actions[i].Detail < actions[j].Detail
+	})
+}
+
+func brokerName(broker string) string {
+	if broker == "" {
+		return clusterDefaultBroker
+	}
+	return broker
+}
+
+// ClusterRestorer applies the restore plans
+type ClusterRestorer struct {
+	creator client.Creator
+	acls    client.ACLCreator
+	brokers client.BrokerConfigUpdater
+}
+
+func NewClusterRestorer(creator client.Creator, acls client.ACLCreator, brokers client.BrokerConfigUpdater) *ClusterRestorer {
+	return &ClusterRestorer{creator: creator, acls: acls, brokers: brokers}
+}
+
+// Apply creates the topics, then the ACLs, then sets the broker configs. It stops at the first change that fails.
+func (r *ClusterRestorer) Apply(plan *ClusterRestorePlan) error {
+	for _, topic := range plan.TopicNames() {
+		if err := r.creator.Create(topic, plan.Topics[topic], false); err != nil {
+			return fmt.Errorf("err while creating topic %v - %v", topic, err)
+		}
+		logger.Infof("Created topic %v\n", topic)
+	}
+	if err := r.acls.CreateACLs(plan.ACLs); err != nil {
+		return fmt.Errorf("err while creating the acls - %v", err)
+	}
+	if len(plan.ACLs) > 0 {
+		logger.Infof("Created %d acls\n", len(plan.ACLs))
+	}
+
+	brokers := make([]string, 0, len(plan.BrokerConfigs))
+	for broker := range plan.BrokerConfigs {
+		brokers = append(brokers, broker)
+	}
+	sort.Strings(brokers)
+	for _, broker := range brokers {
+		if err := r.brokers.UpdateBrokerConfigs(broker, plan.BrokerConfigs[broker]); err != nil {
+			return fmt.Errorf("err while setting the configs of broker %v - %v", brokerName(broker), err)
+		}
+		logger.Infof("Set %d configs of broker %v\n", len(plan.BrokerConfigs[broker]), brokerName(broker))
+	}
+	return nil
+}
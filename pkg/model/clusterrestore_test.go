@@ -0,0 +1,122 @@
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var groupACL = client.ACL{ResourceType: "Group", ResourceName: "group-1", PatternType: "Literal", Principal: "User:alice", Host: "*",
+	Operation: "Read", PermissionType: "Allow"}
+
+func restoreSnapshot() *ClusterSnapshot {
+	return &ClusterSnapshot{
+		FormatVersion: ClusterSnapshotFormatVersion,
+		Topics: map[string]SnapshotTopic{
+			"orders": {NumPartitions: 2, ReplicationFactor: 2, ReplicaAssignment: map[int32][]int32{0: {1, 2}, 1: {2, 1}},
+				Configs: map[string]string{"retention.ms": "1000"}},
+			"payments": {NumPartitions: 1, ReplicationFactor: 1, Configs: map[string]string{}},
+		},
+		ACLs:                 []client.ACL{ordersACL, groupACL},
+		ConsumerGroupOffsets: map[string]map[string]map[int32]int64{"group-1": {"orders": {0: 5}}, "idle-group": {}},
+		BrokerConfigs: map[string]map[string]string{
+			"":  {"log.retention.ms": "1000"},
+			"1": {"log.cleaner.threads": "2"},
+			"5": {"log.cleaner.threads": "4"},
+		},
+	}
+}
+
+func TestPlanClusterRestore_SkipsExistingResources(t *testing.T) {
+	existingTopics := map[string]client.TopicDetail{"payments": {NumPartitions: 1, ReplicationFactor: 1}}
+	existingACLs := []client.ACL{ordersACL}
+	brokers := []client.Broker{{ID: 1}, {ID: 2}}
+	retention := "1000"
+
+	plan, err := PlanClusterRestore(restoreSnapshot(), existingTopics, existingACLs, brokers, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]client.TopicDetail{"orders": {NumPartitions: 2, ReplicationFactor: 2,
+		ReplicaAssignment: map[int32][]int32{0: {1, 2}, 1: {2, 1}}, Config: map[string]*string{"retention.ms": &retention}}}, plan.Topics)
+	assert.Equal(t, []client.ACL{groupACL}, plan.ACLs)
+	assert.Equal(t, map[string]map[string]string{"": {"log.retention.ms": "1000"}, "1": {"log.cleaner.threads": "2"}}, plan.BrokerConfigs)
+	assert.True(t, plan.HasChanges())
+	assert.Equal(t, []string{"orders"}, plan.TopicNames())
+	assert.Equal(t, []RestoreAction{
+		{Action: RestoreActionCreate, ResourceType: RestoreResourceTopic, Resource: "orders",
+			Detail: "2 partitions, replication factor 2, 1 configs, replica assignment of the snapshot"},
+		{Action: RestoreActionCreate, ResourceType: RestoreResourceACL, Resource: groupACL.String()},
+		{Action: RestoreActionSet, ResourceType: RestoreResourceBrokerConfig, Resource: "1", Detail: "log.cleaner.threads=2"},
+		{Action: RestoreActionSet, ResourceType: RestoreResourceBrokerConfig, Resource: "<cluster default>", Detail: "log.retention.ms=1000"},
+		{Action: RestoreActionSkip, ResourceType: RestoreResourceTopic, Resource: "payments", Detail: "already exists"},
+		{Action: RestoreActionSkip, ResourceType: RestoreResourceACL, Resource: ordersACL.String(), Detail: "already exists"},
+		{Action: RestoreActionSkip, ResourceType: RestoreResourceBrokerConfig, Resource: "5", Detail: "broker is not part of the cluster"},
+		{Action: RestoreActionSkip, ResourceType: RestoreResourceConsumerGroupOffsets, Resource: "group-1",
+			Detail: "offsets of the recreated topics start from 0"},
+	}, plan.Actions())
+}
+
+func TestPlanClusterRestore_IgnoresAssignment(t *testing.T) {
+	plan, err := PlanClusterRestore(restoreSnapshot(), map[string]client.TopicDetail{}, nil, nil, true)
+	require.NoError(t, err)
+
+	assert.Nil(t, plan.Topics["orders"].ReplicaAssignment)
+	assert.Equal(t, "2 partitions, replication factor 2, 1 configs, replicas placed by the cluster", plan.Actions()[0].Detail)
+}
+
+func TestPlanClusterRestore_HasNoChangesWhenEverythingExists(t *testing.T) {
+	snapshot := &ClusterSnapshot{Topics: map[string]SnapshotTopic{"orders": {NumPartitions: 1, ReplicationFactor: 1}}}
+
+	plan, err := PlanClusterRestore(snapshot, map[string]client.TopicDetail{"orders": {}}, nil, nil, false)
+	require.NoError(t, err)
+
+	assert.False(t, plan.HasChanges())
+}
+
+func TestPlanClusterRestore_FailsForAssignmentOnUnknownBrokers(t *testing.T) {
+	_, err := PlanClusterRestore(restoreSnapshot(), map[string]client.TopicDetail{}, nil, []client.Broker{{ID: 1}, {ID: 3}}, false)
+
+	assert.EqualError(t, err, "invalid replica assignment of topic orders for the cluster, pass --ignore-assignment - "+
+		"partition 0 has a replica on unknown broker 2")
+}
+
+func TestClusterRestorer_Apply(t *testing.T) {
+	plan, err := PlanClusterRestore(restoreSnapshot(), map[string]client.TopicDetail{}, []client.ACL{ordersACL}, []client.Broker{{ID: 1}, {ID: 2}},
+		false)
+	require.NoError(t, err)
+	creator := &client.MockCreator{}
+	creator.On("Create", "orders", plan.Topics["orders"], false).Return(nil)
+	creator.On("Create", "payments", plan.Topics["payments"], false).Return(nil)
+	aclCreator := &client.MockACLCreator{}
+	aclCreator.On("CreateACLs", plan.ACLs).Return(nil)
+	brokers := &client.MockBrokerConfigUpdater{}
+	brokers.On("UpdateBrokerConfigs", "", map[string]string{"log.retention.ms": "1000"}).Return(nil)
+	brokers.On("UpdateBrokerConfigs", "1", map[string]string{"log.cleaner.threads": "2"}).Return(nil)
+
+	err = NewClusterRestorer(creator, aclCreator, brokers).Apply(plan)
+
+	require.NoError(t, err)
+	creator.AssertExpectations(t)
+	aclCreator.AssertExpectations(t)
+	brokers.AssertExpectations(t)
+}
+
+func TestClusterRestorer_ApplyStopsAtFirstFailure(t *testing.T) {
+	plan, err := PlanClusterRestore(restoreSnapshot(), map[string]client.TopicDetail{}, nil, nil, true)
+	require.NoError(t, err)
+	creator := &client.MockCreator{}
+	creator.On("Create", "orders", mock.Anything, false).Return(errors.New("not authorized"))
+	aclCreator := &client.MockACLCreator{}
+	brokers := &client.MockBrokerConfigUpdater{}
+
+	err = NewClusterRestorer(creator, aclCreator, brokers).Apply(plan)
+
+	assert.EqualError(t, err, "err while creating topic orders - not authorized")
+	creator.AssertNotCalled(t, "Create", "payments", mock.Anything, false)
+	aclCreator.AssertNotCalled(t, "CreateACLs", mock.Anything)
+	brokers.AssertNotCalled(t, "UpdateBrokerConfigs", mock.Anything, mock.Anything)
+}
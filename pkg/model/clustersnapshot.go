@@ -0,0 +1,133 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"time"
+
+	"github.com/gojek/kat/pkg/client"
+)
+
+// ClusterSnapshotFormatVersion is the version of the snapshots written. When the format changes, the version is bumped and the
+// snapshots of the older versions are upgraded in LoadClusterSnapshot, so that they keep loading.
+const ClusterSnapshotFormatVersion = 1
+
+// ClusterSnapshot is the metadata of a cluster, to recreate it on another cluster or to compare it with a later snapshot
+type ClusterSnapshot struct {
+	FormatVersion int                      `json:"formatVersion"`
+	CreatedAt     time.Time                `json:"createdAt"`
+	Topics        map[string]SnapshotTopic `json:"topics"`
+	ACLs          []client.ACL             `json:"acls"`
+	// ConsumerGroupOffsets are the committed offsets per consumer group, topic and partition
+	ConsumerGroupOffsets map[string]map[string]map[int32]int64 `json:"consumerGroupOffsets"`
+	// BrokerConfigs are the dynamic configs per broker id, the cluster wide defaults are under the empty id
+	BrokerConfigs map[string]map[string]string `json:"brokerConfigs"`
+}
+
+type SnapshotTopic struct {
+	NumPartitions     int32             `json:"numPartitions"`
+	ReplicationFactor int16             `json:"replicationFactor"`
+	ReplicaAssignment map[int32][]int32 `json:"replicaAssignment"`
+	// Configs are the configs set at the topic level
+	Configs map[string]string `json:"configs"`
+}
+
+// NewClusterSnapshot builds the snapshot from the state of the cluster, the committed offsets per topic, consumer group and
+// partition, and the dynamic broker configs. The consumer groups of the state without committed offsets are kept with no offsets.
+func NewClusterSnapshot(state *ClusterState, offsets map[string]map[string]map[int32]int64,
+	brokerConfigs map[string]map[string]string) *ClusterSnapshot {
+	snapshot := &ClusterSnapshot{
+		FormatVersion:        ClusterSnapshotFormatVersion,
+		CreatedAt:            time.Now().UTC(),
+		Topics:               make(map[string]SnapshotTopic, len(state.Topics)),
+		ACLs:                 state.ACLs,
+		ConsumerGroupOffsets: make(map[string]map[string]map[int32]int64),
+		BrokerConfigs:        brokerConfigs,
+	}
+	for topic, detail := range state.Topics {
+		configs := state.Configs[topic]
+		if configs == nil {
+			configs = map[string]string{}
+		}
+		snapshot.Topics[topic] = SnapshotTopic{NumPartitions: detail.NumPartitions, ReplicationFactor: detail.ReplicationFactor,
+			ReplicaAssignment: detail.ReplicaAssignment, Configs: configs}
+	}
+	for _, group := range state.ConsumerGroups {
+		snapshot.ConsumerGroupOffsets[group] = map[string]map[int32]int64{}
+	}
+	for topic, groups := range offsets {
+		for group, partitions := range groups {
+			if snapshot.ConsumerGroupOffsets[group] == nil {
+				snapshot.ConsumerGroupOffsets[group] = map[string]map[int32]int64{}
+			}
+			snapshot.ConsumerGroupOffsets[group][topic] = partitions
+		}
+	}
+	if snapshot.ACLs == nil {
+		snapshot.ACLs = []client.ACL{}
+	}
+	if snapshot.BrokerConfigs == nil {
+		snapshot.BrokerConfigs = map[string]map[string]string{}
+	}
+	return snapshot
+}
+
+// State returns the topics, configs, ACLs and consumer groups of the snapshot, to compare them with DiffClusters
+func (s *ClusterSnapshot) State() *ClusterState {
+	state := &ClusterState{
+		Topics:  make(map[string]client.TopicDetail, len(s.Topics)),
+		Configs: make(map[string]map[string]string),
+		ACLs:    s.ACLs,
+	}
+	for topic, snapshotTopic := range s.Topics {
+		state.Topics[topic] = snapshotTopic.topicDetail(false)
+		if len(snapshotTopic.Configs) > 0 {
+			state.Configs[topic] = snapshotTopic.Configs
+		}
+	}
+	for group := range s.ConsumerGroupOffsets {
+		state.ConsumerGroups = append(state.ConsumerGroups, group)
+	}
+	sort.Strings(state.ConsumerGroups)
+	return state
+}
+
+func (t SnapshotTopic) topicDetail(ignoreAssignment bool) client.TopicDetail {
+	detail := client.TopicDetail{NumPartitions: t.NumPartitions, ReplicationFactor: t.ReplicationFactor,
+		Config: make(map[string]*string, len(t.Configs))}
+	for name, value := range t.Configs {
+		value := value
+		detail.Config[name] = &value
+	}
+	if !ignoreAssignment {
+		detail.ReplicaAssignment = t.ReplicaAssignment
+	}
+	return detail
+}
+
+// WriteClusterSnapshot writes the snapshot as indented json
+func WriteClusterSnapshot(out io.Writer, snapshot *ClusterSnapshot) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(snapshot)
+}
+
+// LoadClusterSnapshot reads the snapshot file, it fails for snapshots written by a newer version of kat
+func LoadClusterSnapshot(file string) (*ClusterSnapshot, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &ClusterSnapshot{}
+	if err = json.Unmarshal(data, snapshot); err != nil {
+		return nil, fmt.Errorf("err while parsing the snapshot %v - %v", file, err)
+	}
+	if snapshot.FormatVersion < 1 || snapshot.FormatVersion > ClusterSnapshotFormatVersion {
+		return nil, fmt.Errorf("unsupported format version %d of the snapshot %v, expected at most %d", snapshot.FormatVersion, file,
+			ClusterSnapshotFormatVersion)
+	}
+	return snapshot, nil
+}
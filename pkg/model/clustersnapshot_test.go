@@ -0,0 +1,98 @@
+package model
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClusterSnapshot(t *testing.T) {
+	state := &ClusterState{
+		Topics: map[string]client.TopicDetail{
+			"orders":   {NumPartitions: 2, ReplicationFactor: 2, ReplicaAssignment: map[int32][]int32{0: {1, 2}, 1: {2, 1}}},
+			"payments": {NumPartitions: 1, ReplicationFactor: 1},
+		},
+		Configs:        map[string]map[string]string{"orders": {"retention.ms": "1000"}},
+		ACLs:           []client.ACL{ordersACL},
+		ConsumerGroups: []string{"group-1", "idle-group"},
+	}
+	offsets := map[string]map[string]map[int32]int64{"orders": {"group-1": {0: 5, 1: 7}}}
+	brokerConfigs := map[string]map[string]string{"1": {"log.cleaner.threads": "2"}}
+
+	snapshot := NewClusterSnapshot(state, offsets, brokerConfigs)
+
+	assert.Equal(t, ClusterSnapshotFormatVersion, snapshot.FormatVersion)
+	assert.Equal(t, map[string]SnapshotTopic{
+		"orders": {NumPartitions: 2, ReplicationFactor: 2, ReplicaAssignment: map[int32][]int32{0: {1, 2}, 1: {2, 1}},
+			Configs: map[string]string{"retention.ms": "1000"}},
+		"payments": {NumPartitions: 1, ReplicationFactor: 1, Configs: map[string]string{}},
+	}, snapshot.Topics)
+	assert.Equal(t, []client.ACL{ordersACL}, snapshot.ACLs)
+	assert.Equal(t, map[string]map[string]map[int32]int64{
+		"group-1":    {"orders": {0: 5, 1: 7}},
+		"idle-group": {},
+	}, snapshot.ConsumerGroupOffsets)
+	assert.Equal(t, brokerConfigs, snapshot.BrokerConfigs)
+}
+
+func TestClusterSnapshot_State(t *testing.T) {
+	retention := "1000"
+	snapshot := &ClusterSnapshot{
+		Topics: map[string]SnapshotTopic{
+			"orders":   {NumPartitions: 2, ReplicationFactor: 2, Configs: map[string]string{"retention.ms": "1000"}},
+			"payments": {NumPartitions: 1, ReplicationFactor: 1, Configs: map[string]string{}},
+		},
+		ACLs:                 []client.ACL{ordersACL},
+		ConsumerGroupOffsets: map[string]map[string]map[int32]int64{"group-2": {}, "group-1": {"orders": {0: 1}}},
+	}
+
+	state := snapshot.State()
+
+	assert.Equal(t, &ClusterState{
+		Topics: map[string]client.TopicDetail{
+			"orders":   {NumPartitions: 2, ReplicationFactor: 2, Config: map[string]*string{"retention.ms": &retention}},
+			"payments": {NumPartitions: 1, ReplicationFactor: 1, Config: map[string]*string{}},
+		},
+		Configs:        map[string]map[string]string{"orders": {"retention.ms": "1000"}},
+		ACLs:           []client.ACL{ordersACL},
+		ConsumerGroups: []string{"group-1", "group-2"},
+	}, state)
+}
+
+func TestWriteAndLoadClusterSnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	snapshot := NewClusterSnapshot(&ClusterState{Topics: map[string]client.TopicDetail{"orders": {NumPartitions: 1, ReplicationFactor: 1}}},
+		map[string]map[string]map[int32]int64{"orders": {"group-1": {0: 3}}}, map[string]map[string]string{"": {"log.retention.ms": "1000"}})
+	var out bytes.Buffer
+	require.NoError(t, WriteClusterSnapshot(&out, snapshot))
+	file := filepath.Join(dir, "snapshot.json")
+	require.NoError(t, ioutil.WriteFile(file, out.Bytes(), 0644))
+
+	loaded, err := LoadClusterSnapshot(file)
+
+	require.NoError(t, err)
+	assert.Equal(t, snapshot.Topics, loaded.Topics)
+	assert.Equal(t, snapshot.ConsumerGroupOffsets, loaded.ConsumerGroupOffsets)
+	assert.Equal(t, snapshot.BrokerConfigs, loaded.BrokerConfigs)
+	assert.True(t, snapshot.CreatedAt.Equal(loaded.CreatedAt))
+}
+
+func TestLoadClusterSnapshot_FailsForNewerVersion(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "snapshot.json")
+	require.NoError(t, ioutil.WriteFile(file, []byte(`{"formatVersion": 2, "topics": {}}`), 0644))
+
+	_, err = LoadClusterSnapshot(file)
+
+	assert.EqualError(t, err, "unsupported format version 2 of the snapshot "+file+", expected at most 1")
+}
@@ -0,0 +1,188 @@
+package model
+
+import (
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/gojek/kat/pkg/client"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigPolicy is a baseline of topic configs, declared per topic regex
+type ConfigPolicy struct {
+	Rules []*ConfigPolicyRule `yaml:"rules"`
+}
+
+type ConfigPolicyRule struct {
+	Topics string `yaml:"topics"`
+	// ReplicationFactor restricts the rule to topics with the given replication factor, when set
+	ReplicationFactor int16                       `yaml:"replicationFactor"`
+	Configs           map[string]ConfigConstraint `yaml:"configs"`
+	topicRegex        *regexp.Regexp
+}
+
+type ConfigConstraint struct {
+	// Required configs must be overridden at the topic level
+	Required bool `yaml:"required"`
+	// Forbidden configs must not be overridden at the topic level
+	Forbidden bool    `yaml:"forbidden"`
+	Value     *string `yaml:"value"`
+	Min       *int64  `yaml:"min"`
+	Max       *int64  `yaml:"max"`
+}
+
+type ConfigViolation struct {
+	Topic    string `json:"topic"`
+	Config   string `json:"config"`
+	Value    string `json:"value"`
+	Expected string `json:"expected"`
+	// Fix is the change that resolves the violation, nil if it cannot be resolved automatically
+	Fix *client.IncrementalConfigEntry `json:"-"`
+}
+
+func LoadConfigPolicy(fileName string) (*ConfigPolicy, error) {
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	return parseConfigPolicy(data)
+}
+
+func parseConfigPolicy(data []byte) (*ConfigPolicy, error) {
+	policy := &ConfigPolicy{}
+	if err := yaml.Unmarshal(data, policy); err != nil {
+		return nil, fmt.Errorf("err while parsing policy - %v", err)
+	}
+	for _, rule := range policy.Rules {
+		regex, err := regexp.Compile(rule.Topics)
+		if err != nil {
+			return nil, fmt.Errorf("invalid topic regex %v - %v", rule.Topics, err)
+		}
+		rule.topicRegex = regex
+		for name, constraint := range rule.Configs {
+			if validationErr := constraint.validate(); validationErr != nil {
+				return nil, fmt.Errorf("invalid constraint for config %v on topics %v - %v", name, rule.Topics, validationErr)
+			}
+		}
+	}
+	return policy, nil
+}
+
+func (c ConfigConstraint) validate() error {
+	if c.Forbidden && (c.Required || c.Value != nil || c.hasRange()) {
+		return fmt.Errorf("forbidden cannot be combined with other constraints")
+	}
+	if c.Value != nil && c.hasRange() {
+		return fmt.Errorf("value cannot be combined with min or max")
+	}
+	if c.Min != nil && c.Max != nil && *c.Min > *c.Max {
+		return fmt.Errorf("min %d is greater than max %d", *c.Min, *c.Max)
+	}
+	return nil
+}
+
+func (c ConfigConstraint) hasRange() bool {
+	return c.Min != nil || c.Max != nil
+}
+
+// Evaluate returns the violations of all the rules that apply to the topic, sorted by config name
+func (p *ConfigPolicy) Evaluate(topic string, detail client.TopicDetail, configs []client.ConfigEntry) []ConfigViolation {
+	configMap := make(map[string]client.ConfigEntry, len(configs))
+	for _, config := range configs {
+		configMap[config.Name] = config
+	}
+
+	var violations []ConfigViolation
+	for _, rule := range p.Rules {
+		if !rule.appliesTo(topic, detail) {
+			continue
+		}
+		names := make([]string, 0, len(rule.Configs))
+		for name := range rule.Configs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if violation, ok := rule.Configs[name].evaluate(configMap[name]); !ok {
+				violation.Topic = topic
+				violation.Config = name
+				violations = append(violations, violation)
+			}
+		}
+	}
+	return violations
+}
+
+func (r *ConfigPolicyRule) appliesTo(topic string, detail client.TopicDetail) bool {
+	if r.ReplicationFactor != 0 && r.ReplicationFactor != detail.ReplicationFactor {
+		return false
+	}
+	return r.topicRegex.MatchString(topic)
+}
+
+func (c ConfigConstraint) evaluate(config client.ConfigEntry) (ConfigViolation, bool) {
+	violation := ConfigViolation{Value: config.Value}
+	if expected, fix, ok := c.evaluateValue(config); !ok {
+		violation.Expected = expected
+		violation.Fix = fix
+		return violation, false
+	}
+
+	if c.Required && !config.IsOverridden() {
+		violation.Expected = "overridden"
+		if c.Value != nil {
+			violation.Fix = &client.IncrementalConfigEntry{Operation: client.ConfigOperationSet, Value: c.Value}
+		}
+		return violation, false
+	}
+	return violation, true
+}
+
+func (c ConfigConstraint) evaluateValue(config client.ConfigEntry) (expected string, fix *client.IncrementalConfigEntry, ok bool) {
+	switch {
+	case c.Forbidden:
+		if config.IsOverridden() {
+			return "not overridden", &client.IncrementalConfigEntry{Operation: client.ConfigOperationDelete}, false
+		}
+	case c.Value != nil:
+		if config.Value != *c.Value {
+			return fmt.Sprintf("= %v", *c.Value), &client.IncrementalConfigEntry{Operation: client.ConfigOperationSet, Value: c.Value}, false
+		}
+	case c.Min != nil || c.Max != nil:
+		if fix, ok = c.evaluateRange(config.Value); !ok {
+			return c.rangeString(), fix, false
+		}
+	}
+	return "", nil, true
+}
+
+func (c ConfigConstraint) evaluateRange(value string) (*client.IncrementalConfigEntry, bool) {
+	val, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, false
+	}
+	var bound *int64
+	if c.Min != nil && val < *c.Min {
+		bound = c.Min
+	} else if c.Max != nil && val > *c.Max {
+		bound = c.Max
+	} else {
+		return nil, true
+	}
+	fixValue := strconv.FormatInt(*bound, 10)
+	return &client.IncrementalConfigEntry{Operation: client.ConfigOperationSet, Value: &fixValue}, false
+}
+
+func (c ConfigConstraint) rangeString() string {
+	switch {
+	case c.Min != nil && c.Max != nil:
+		return fmt.Sprintf("between %d and %d", *c.Min, *c.Max)
+	case c.Min != nil:
+		return fmt.Sprintf(">= %d", *c.Min)
+	default:
+		return fmt.Sprintf("<= %d", *c.Max)
+	}
+}
@@ -0,0 +1,106 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPolicy = `
+rules:
+  - topics: ".*"
+    configs:
+      retention.ms:
+        min: 3600000
+        max: 604800000
+  - topics: ".*-changelog$"
+    configs:
+      cleanup.policy:
+        value: compact
+      segment.bytes:
+        forbidden: true
+  - topics: ".*"
+    replicationFactor: 3
+    configs:
+      min.insync.replicas:
+        required: true
+        min: 2
+`
+
+func TestConfigPolicy_ParseSuccess(t *testing.T) {
+	policy, err := parseConfigPolicy([]byte(testPolicy))
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, len(policy.Rules))
+	assert.Equal(t, int16(3), policy.Rules[2].ReplicationFactor)
+	assert.Equal(t, "compact", *policy.Rules[1].Configs["cleanup.policy"].Value)
+}
+
+func TestConfigPolicy_ParseFailure(t *testing.T) {
+	_, err := parseConfigPolicy([]byte("rules:\n  - topics: \"[\"\n"))
+	assert.Error(t, err)
+
+	_, err = parseConfigPolicy([]byte("rules:\n  - topics: \".*\"\n    configs:\n      retention.ms:\n        min: 10\n        max: 1\n"))
+	assert.Error(t, err)
+
+	_, err = parseConfigPolicy([]byte("rules:\n  - topics: \".*\"\n    configs:\n      retention.ms:\n        forbidden: true\n        min: 1\n"))
+	assert.Error(t, err)
+}
+
+func TestConfigPolicy_EvaluateReturnsViolations(t *testing.T) {
+	policy, err := parseConfigPolicy([]byte(testPolicy))
+	require.NoError(t, err)
+	configs := []client.ConfigEntry{
+		{Name: "retention.ms", Value: "100", Source: client.ConfigSourceTopic},
+		{Name: "cleanup.policy", Value: "delete", Source: client.ConfigSourceDefault, Default: true},
+		{Name: "segment.bytes", Value: "1000", Source: client.ConfigSourceTopic},
+		{Name: "min.insync.replicas", Value: "1", Source: client.ConfigSourceDefault, Default: true},
+	}
+
+	violations := policy.Evaluate("orders-changelog", client.TopicDetail{ReplicationFactor: 3}, configs)
+
+	require.Equal(t, 4, len(violations))
+	assert.Equal(t, "retention.ms", violations[0].Config)
+	assert.Equal(t, "between 3600000 and 604800000", violations[0].Expected)
+	assert.Equal(t, "3600000", *violations[0].Fix.Value)
+	assert.Equal(t, "cleanup.policy", violations[1].Config)
+	assert.Equal(t, client.ConfigOperationSet, violations[1].Fix.Operation)
+	assert.Equal(t, "segment.bytes", violations[2].Config)
+	assert.Equal(t, client.ConfigOperationDelete, violations[2].Fix.Operation)
+	assert.Equal(t, "min.insync.replicas", violations[3].Config)
+	assert.Equal(t, "2", *violations[3].Fix.Value)
+	for _, violation := range violations {
+		assert.Equal(t, "orders-changelog", violation.Topic)
+	}
+}
+
+func TestConfigPolicy_EvaluateSkipsRulesThatDoNotApply(t *testing.T) {
+	policy, err := parseConfigPolicy([]byte(testPolicy))
+	require.NoError(t, err)
+	configs := []client.ConfigEntry{
+		{Name: "retention.ms", Value: "86400000", Source: client.ConfigSourceTopic},
+		{Name: "cleanup.policy", Value: "delete", Source: client.ConfigSourceDefault, Default: true},
+		{Name: "min.insync.replicas", Value: "1", Source: client.ConfigSourceDefault, Default: true},
+	}
+
+	violations := policy.Evaluate("orders", client.TopicDetail{ReplicationFactor: 2}, configs)
+
+	assert.Empty(t, violations)
+}
+
+func TestConfigPolicy_EvaluateRequiredWithoutOverride(t *testing.T) {
+	policy, err := parseConfigPolicy([]byte(testPolicy))
+	require.NoError(t, err)
+	configs := []client.ConfigEntry{
+		{Name: "retention.ms", Value: "86400000", Source: client.ConfigSourceTopic},
+		{Name: "min.insync.replicas", Value: "2", Source: "DynamicBroker"},
+	}
+
+	violations := policy.Evaluate("orders", client.TopicDetail{ReplicationFactor: 3}, configs)
+
+	require.Equal(t, 1, len(violations))
+	assert.Equal(t, "overridden", violations[0].Expected)
+	assert.Nil(t, violations[0].Fix)
+}
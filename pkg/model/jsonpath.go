@@ -0,0 +1,142 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const jsonPathWildcard = -1
+
+// JSONPath is a subset of JSONPath with the root $, fields as .name, array indexes as [n], and [*] for any array element.
+// An expression matches the json values where the path is present and not null, or where the path is
+// equal to the value in an expression like $.order.id==42. Strings are compared without the quotes.
+type JSONPath struct {
+	steps []jsonPathStep
+	value *string
+}
+
+type jsonPathStep struct {
+	field string
+	index int
+	// isIndex is set for the array steps
+	isIndex bool
+}
+
+func ParseJSONPath(expr string) (*JSONPath, error) {
+	path := &JSONPath{}
+	if i := strings.Index(expr, "=="); i >= 0 {
+		value := strings.TrimSpace(expr[i+2:])
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		}
+		path.value = &value
+		expr = strings.TrimSpace(expr[:i])
+	}
+	if !strings.HasPrefix(expr, "$") {
+		return nil, fmt.Errorf("invalid json path %v, expected it to start with $", expr)
+	}
+
+	rest := expr[1:]
+	for rest != "" {
+		step, remaining, err := parseJSONPathStep(rest)
+		if err != nil {
+			return nil, fmt.Errorf("invalid json path %v - %v", expr, err)
+		}
+		path.steps = append(path.steps, step)
+		rest = remaining
+	}
+	return path, nil
+}
+
+func parseJSONPathStep(rest string) (jsonPathStep, string, error) {
+	switch rest[0] {
+	case '.':
+		end := strings.IndexAny(rest[1:], ".[")
+		if end < 0 {
+			end = len(rest) - 1
+		}
+		field := rest[1 : end+1]
+		if field == "" {
+			return jsonPathStep{}, "", fmt.Errorf("empty field name")
+		}
+		return jsonPathStep{field: field}, rest[end+1:], nil
+	case '[':
+		end := strings.Index(rest, "]")
+		if end < 0 {
+			return jsonPathStep{}, "", fmt.Errorf("unclosed [")
+		}
+		if rest[1:end] == "*" {
+			return jsonPathStep{index: jsonPathWildcard, isIndex: true}, rest[end+1:], nil
+		}
+		index, err := strconv.Atoi(rest[1:end])
+		if err != nil || index < 0 {
+			return jsonPathStep{}, "", fmt.Errorf("invalid index %v", rest[1:end])
+		}
+		return jsonPathStep{index: index, isIndex: true}, rest[end+1:], nil
+	default:
+		return jsonPathStep{}, "", fmt.Errorf("unexpected %q", rest[0])
+	}
+}
+
+// Match returns true when any of the values selected by the path matches. Data that is not json does not match.
+func (p *JSONPath) Match(data []byte) bool {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return false
+	}
+	for _, value := range p.selectValues(document, p.steps) {
+		if value == nil {
+			continue
+		}
+		if p.value == nil || jsonString(value) == *p.value {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *JSONPath) selectValues(value interface{}, steps []jsonPathStep) []interface{} {
+	if len(steps) == 0 {
+		return []interface{}{value}
+	}
+	step := steps[0]
+	if !step.isIndex {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		return p.selectValues(object[step.field], steps[1:])
+	}
+
+	array, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+	if step.index != jsonPathWildcard {
+		if step.index >= len(array) {
+			return nil
+		}
+		return p.selectValues(array[step.index], steps[1:])
+	}
+	var values []interface{}
+	for _, element := range array {
+		values = append(values, p.selectValues(element, steps[1:])...)
+	}
+	return values
+}
+
+func jsonString(value interface{}) string {
+	if str, ok := value.(string); ok {
+		return str
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
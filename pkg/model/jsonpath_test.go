@@ -0,0 +1,61 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const orderJSON = `{"order":{"id":42,"status":"paid","items":[{"sku":"A1"},{"sku":"B2"}],"note":null}}`
+
+func TestJSONPath_Match(t *testing.T) {
+	tests := []struct {
+		expr  string
+		match bool
+	}{
+		{"$.order.id", true},
+		{"$.order.id==42", true},
+		{"$.order.id == 43", false},
+		{`$.order.status=="paid"`, true},
+		{"$.order.status==paid", true},
+		{"$.order.items[1].sku==B2", true},
+		{"$.order.items[2].sku", false},
+		{`$.order.items[*].sku=="A1"`, true},
+		{"$.order.items[*].sku==C3", false},
+		{"$.order.note", false},
+		{"$.order.missing", false},
+		{"$.order.id.value", false},
+		{`$.order.items==[{"sku":"A1"},{"sku":"B2"}]`, true},
+	}
+	for _, test := range tests {
+		path, err := ParseJSONPath(test.expr)
+		require.NoError(t, err, test.expr)
+		assert.Equal(t, test.match, path.Match([]byte(orderJSON)), test.expr)
+	}
+}
+
+func TestJSONPath_MatchIgnoresInvalidJSON(t *testing.T) {
+	path, err := ParseJSONPath("$")
+	require.NoError(t, err)
+
+	assert.True(t, path.Match([]byte(`"text"`)))
+	assert.False(t, path.Match([]byte("text")))
+}
+
+func TestParseJSONPath_Invalid(t *testing.T) {
+	_, err := ParseJSONPath("order.id")
+	assert.EqualError(t, err, "invalid json path order.id, expected it to start with $")
+
+	_, err = ParseJSONPath("$.order[a]")
+	assert.EqualError(t, err, "invalid json path $.order[a] - invalid index a")
+
+	_, err = ParseJSONPath("$.order[1")
+	assert.EqualError(t, err, "invalid json path $.order[1 - unclosed [")
+
+	_, err = ParseJSONPath("$..id")
+	assert.EqualError(t, err, "invalid json path $..id - empty field name")
+
+	_, err = ParseJSONPath("$order")
+	assert.EqualError(t, err, `invalid json path $order - unexpected 'o'`)
+}
@@ -0,0 +1,85 @@
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/gojek/kat/pkg/client"
+)
+
+// MessageFilter matches the messages that satisfy all of the criteria that are set
+type MessageFilter struct {
+	// Key is the exact key of the messages, not checked when nil
+	Key      []byte
+	Header   *client.MessageHeader
+	Value    *regexp.Regexp
+	JSONPath *JSONPath
+	// DecodeValue decodes the values before the value regex and json path are matched, the values that fail to be decoded are matched as is
+	DecodeValue func([]byte) ([]byte, error)
+}
+
+// NewMessageFilter creates the filter from the arguments of the command, the empty arguments are ignored.
+// The header is given as name=value.
+func NewMessageFilter(key, header, valueRegex, jsonPath string) (*MessageFilter, error) {
+	filter := &MessageFilter{}
+	if key != "" {
+		filter.Key = []byte(key)
+	}
+	if header != "" {
+		keyValue := strings.SplitN(header, "=", 2)
+		if len(keyValue) != 2 || keyValue[0] == "" {
+			return nil, fmt.Errorf("invalid header %v, expected name=value", header)
+		}
+		filter.Header = &client.MessageHeader{Key: []byte(keyValue[0]), Value: []byte(keyValue[1])}
+	}
+	var err error
+	if valueRegex != "" {
+		if filter.Value, err = regexp.Compile(valueRegex); err != nil {
+			return nil, fmt.Errorf("invalid value regex - %v", err)
+		}
+	}
+	if jsonPath != "" {
+		if filter.JSONPath, err = ParseJSONPath(jsonPath); err != nil {
+			return nil, err
+		}
+	}
+	return filter, nil
+}
+
+func (f *MessageFilter) Match(msg *client.Message) bool {
+	if f.Key != nil && !bytes.Equal(f.Key, msg.Key) {
+		return false
+	}
+	if f.Header != nil && !hasHeader(msg, f.Header) {
+		return false
+	}
+	if f.Value == nil && f.JSONPath == nil {
+		return true
+	}
+	value := f.decodedValue(msg.Value)
+	if f.Value != nil && !f.Value.Match(value) {
+		return false
+	}
+	return f.JSONPath == nil || f.JSONPath.Match(value)
+}
+
+func hasHeader(msg *client.Message, header *client.MessageHeader) bool {
+	for _, h := range msg.Headers {
+		if bytes.Equal(h.Key, header.Key) && bytes.Equal(h.Value, header.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *MessageFilter) decodedValue(value []byte) []byte {
+	if f.DecodeValue == nil {
+		return value
+	}
+	if decoded, err := f.DecodeValue(value); err == nil {
+		return decoded
+	}
+	return value
+}
@@ -0,0 +1,48 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var filteredMessage = &client.Message{
+	Key:     []byte("order-42"),
+	Value:   []byte(orderJSON),
+	Headers: []client.MessageHeader{{Key: []byte("source"), Value: []byte("checkout")}},
+}
+
+func TestMessageFilter_Match(t *testing.T) {
+	tests := []struct {
+		key, header, valueRegex, jsonPath string
+		match                             bool
+	}{
+		{"order-42", "", "", "", true},
+		{"order-43", "", "", "", false},
+		{"", "source=checkout", "", "", true},
+		{"", "source=cart", "", "", false},
+		{"", "", `"status":"pa`, "", true},
+		{"", "", "refunded", "", false},
+		{"", "", "", "$.order.id==42", true},
+		{"order-42", "source=checkout", "paid", "$.order.id==43", false},
+		{"order-42", "source=checkout", "paid", "$.order.id==42", true},
+	}
+	for _, test := range tests {
+		filter, err := NewMessageFilter(test.key, test.header, test.valueRegex, test.jsonPath)
+		require.NoError(t, err)
+		assert.Equal(t, test.match, filter.Match(filteredMessage), test)
+	}
+}
+
+func TestNewMessageFilter_Invalid(t *testing.T) {
+	_, err := NewMessageFilter("", "source", "", "")
+	assert.EqualError(t, err, "invalid header source, expected name=value")
+
+	_, err = NewMessageFilter("", "", "(", "")
+	assert.Error(t, err)
+
+	_, err = NewMessageFilter("", "", "", "order")
+	assert.Error(t, err)
+}
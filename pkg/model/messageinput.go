@@ -0,0 +1,223 @@
+package model
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gojek/kat/pkg/client"
+)
+
+// Formats of the messages read by the MessageInput
+const (
+	InputFormatJSONLines = "jsonl"
+	InputFormatCSV       = "csv"
+	InputFormatDelimited = "delimited"
+)
+
+const (
+	maxInputLineSize = 10 * 1024 * 1024
+	csvHeaderPrefix  = "header."
+)
+
+// jsonMessage is a message in the json lines input. The key and value are taken as is when they are
+// json strings, and as the json text otherwise.
+type jsonMessage struct {
+	Key       json.RawMessage   `json:"key"`
+	Value     json.RawMessage   `json:"value"`
+	Partition *int32            `json:"partition"`
+	Headers   map[string]string `json:"headers"`
+	// Timestamp is either a RFC3339 time or the epoch time in milliseconds
+	Timestamp json.RawMessage `json:"timestamp"`
+}
+
+// MessageInput reads the messages to be produced, one message per line, or per record for csv.
+// The partition of the messages is -1 when it is not in the input.
+type MessageInput struct {
+	format     string
+	delimiter  string
+	lines      *bufio.Scanner
+	csv        *csv.Reader
+	csvColumns []string
+	line       int
+}
+
+// NewMessageInput reads the messages in one of the formats:
+// jsonl - a json object per line with the key, value, partition, headers and timestamp
+// csv - a header row naming the columns, which are key, value, partition, timestamp, or header.<name> for a header
+// delimited - the key and the value on each line, separated by the delimiter. A line without the delimiter has only the value.
+func NewMessageInput(r io.Reader, format, delimiter string) (*MessageInput, error) {
+	input := &MessageInput{format: format, delimiter: delimiter}
+	switch format {
+	case InputFormatJSONLines, InputFormatDelimited:
+		input.lines = bufio.NewScanner(r)
+		input.lines.Buffer(make([]byte, 0, 64*1024), maxInputLineSize)
+	case InputFormatCSV:
+		input.csv = csv.NewReader(r)
+		columns, err := input.csv.Read()
+		if err != nil {
+			return nil, fmt.Errorf("err while reading the csv header - %v", err)
+		}
+		if err = validateCSVColumns(columns); err != nil {
+			return nil, err
+		}
+		input.csvColumns = columns
+		input.line = 1
+	default:
+		return nil, fmt.Errorf("invalid input format %v, expected one of jsonl|csv|delimited", format)
+	}
+	if format == InputFormatDelimited && delimiter == "" {
+		return nil, fmt.Errorf("delimiter is needed for the delimited format")
+	}
+	return input, nil
+}
+
+func validateCSVColumns(columns []string) error {
+	for _, column := range columns {
+		switch column {
+		case "key", "value", "partition", "timestamp":
+		default:
+			if !strings.HasPrefix(column, csvHeaderPrefix) {
+				return fmt.Errorf("invalid csv column %v, expected one of key|value|partition|timestamp|header.<name>", column)
+			}
+		}
+	}
+	return nil
+}
+
+// Next returns the next message, or io.EOF after the last message. Empty lines are skipped.
+func (m *MessageInput) Next() (*client.Message, error) {
+	if m.csv != nil {
+		record, err := m.csv.Read()
+		if err != nil {
+			return nil, m.lineErr(err)
+		}
+		m.line++
+		msg, err := m.csvMessage(record)
+		return msg, m.lineErr(err)
+	}
+
+	for m.lines.Scan() {
+		m.line++
+		line := m.lines.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		if m.format == InputFormatJSONLines {
+			msg, err := jsonLineMessage(line)
+			return msg, m.lineErr(err)
+		}
+		return m.delimitedMessage(string(line)), nil
+	}
+	if err := m.lines.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+func (m *MessageInput) lineErr(err error) error {
+	if err == nil || err == io.EOF {
+		return err
+	}
+	return fmt.Errorf("err on line %d - %v", m.line, err)
+}
+
+func (m *MessageInput) delimitedMessage(line string) *client.Message {
+	index := strings.Index(line, m.delimiter)
+	if index < 0 {
+		return &client.Message{Value: []byte(line), Partition: -1}
+	}
+	return &client.Message{Key: []byte(line[:index]), Value: []byte(line[index+len(m.delimiter):]), Partition: -1}
+}
+
+func (m *MessageInput) csvMessage(record []string) (*client.Message, error) {
+	msg := &client.Message{Partition: -1}
+	for i, column := range m.csvColumns {
+		value := record[i]
+		var err error
+		switch column {
+		case "key":
+			msg.Key = []byte(value)
+		case "value":
+			msg.Value = []byte(value)
+		case "partition":
+			msg.Partition, err = parsePartition(value)
+		case "timestamp":
+			msg.Timestamp, err = parseTimestamp(value)
+		default:
+			msg.Headers = append(msg.Headers, client.MessageHeader{Key: []byte(strings.TrimPrefix(column, csvHeaderPrefix)), Value: []byte(value)})
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return msg, nil
+}
+
+func jsonLineMessage(line []byte) (*client.Message, error) {
+	var jsonMsg jsonMessage
+	if err := json.Unmarshal(line, &jsonMsg); err != nil {
+		return nil, err
+	}
+	msg := &client.Message{Key: jsonBytes(jsonMsg.Key), Value: jsonBytes(jsonMsg.Value), Partition: -1}
+	if jsonMsg.Partition != nil {
+		msg.Partition = *jsonMsg.Partition
+	}
+	for key, value := range jsonMsg.Headers {
+		msg.Headers = append(msg.Headers, client.MessageHeader{Key: []byte(key), Value: []byte(value)})
+	}
+	if len(jsonMsg.Timestamp) > 0 {
+		var timestamp string
+		if err := json.Unmarshal(jsonMsg.Timestamp, &timestamp); err != nil {
+			timestamp = string(jsonMsg.Timestamp)
+		}
+		var err error
+		if msg.Timestamp, err = parseTimestamp(timestamp); err != nil {
+			return nil, err
+		}
+	}
+	return msg, nil
+}
+
+func jsonBytes(raw json.RawMessage) []byte {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	var str string
+	if err := json.Unmarshal(raw, &str); err == nil {
+		return []byte(str)
+	}
+	return raw
+}
+
+func parsePartition(value string) (int32, error) {
+	if value == "" {
+		return -1, nil
+	}
+	partition, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || partition < 0 {
+		return 0, fmt.Errorf("invalid partition %v", value)
+	}
+	return int32(partition), nil
+}
+
+// parseTimestamp parses a RFC3339 time or the epoch time in milliseconds
+func parseTimestamp(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if ms, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(0, ms*int64(time.Millisecond)), nil
+	}
+	timestamp, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp %v, expected a RFC3339 time or the epoch time in milliseconds", value)
+	}
+	return timestamp, nil
+}
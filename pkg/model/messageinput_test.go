@@ -0,0 +1,97 @@
+package model
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readAll(t *testing.T, input *MessageInput) []*client.Message {
+	var messages []*client.Message
+	for {
+		msg, err := input.Next()
+		if err == io.EOF {
+			return messages
+		}
+		require.NoError(t, err)
+		messages = append(messages, msg)
+	}
+}
+
+func TestMessageInput_JSONLines(t *testing.T) {
+	in := `{"key":"k1","value":{"id":1},"partition":2,"headers":{"h":"v"},"timestamp":"2021-03-04T05:06:07Z"}
+
+{"value":"v2","timestamp":1614834367000}
+`
+	input, err := NewMessageInput(strings.NewReader(in), InputFormatJSONLines, "")
+	require.NoError(t, err)
+
+	messages := readAll(t, input)
+
+	require.Len(t, messages, 2)
+	assert.Equal(t, &client.Message{Key: []byte("k1"), Value: []byte(`{"id":1}`), Partition: 2,
+		Headers: []client.MessageHeader{{Key: []byte("h"), Value: []byte("v")}}, Timestamp: time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)}, messages[0])
+	assert.Nil(t, messages[1].Key)
+	assert.Equal(t, []byte("v2"), messages[1].Value)
+	assert.Equal(t, int32(-1), messages[1].Partition)
+	assert.Equal(t, int64(1614834367000), messages[1].Timestamp.UnixNano()/int64(time.Millisecond))
+}
+
+func TestMessageInput_JSONLinesInvalid(t *testing.T) {
+	input, err := NewMessageInput(strings.NewReader("{\"value\":\"v\"}\n{\"value\":\n"), InputFormatJSONLines, "")
+	require.NoError(t, err)
+
+	_, err = input.Next()
+	require.NoError(t, err)
+	_, err = input.Next()
+	assert.EqualError(t, err, "err on line 2 - unexpected end of JSON input")
+}
+
+func TestMessageInput_CSV(t *testing.T) {
+	in := "key,value,partition,header.source\nk1,v1,1,test\nk2,\"v,2\",,test\n"
+	input, err := NewMessageInput(strings.NewReader(in), InputFormatCSV, "")
+	require.NoError(t, err)
+
+	messages := readAll(t, input)
+
+	require.Len(t, messages, 2)
+	assert.Equal(t, &client.Message{Key: []byte("k1"), Value: []byte("v1"), Partition: 1,
+		Headers: []client.MessageHeader{{Key: []byte("source"), Value: []byte("test")}}}, messages[0])
+	assert.Equal(t, []byte("v,2"), messages[1].Value)
+	assert.Equal(t, int32(-1), messages[1].Partition)
+}
+
+func TestMessageInput_CSVInvalid(t *testing.T) {
+	_, err := NewMessageInput(strings.NewReader("key,offset\n"), InputFormatCSV, "")
+	assert.EqualError(t, err, "invalid csv column offset, expected one of key|value|partition|timestamp|header.<name>")
+
+	input, err := NewMessageInput(strings.NewReader("value,timestamp\nv1,yesterday\n"), InputFormatCSV, "")
+	require.NoError(t, err)
+	_, err = input.Next()
+	assert.EqualError(t, err, "err on line 2 - invalid timestamp yesterday, expected a RFC3339 time or the epoch time in milliseconds")
+}
+
+func TestMessageInput_Delimited(t *testing.T) {
+	input, err := NewMessageInput(strings.NewReader("k1:v:1\nv2\n"), InputFormatDelimited, ":")
+	require.NoError(t, err)
+
+	messages := readAll(t, input)
+
+	assert.Equal(t, []*client.Message{
+		{Key: []byte("k1"), Value: []byte("v:1"), Partition: -1},
+		{Value: []byte("v2"), Partition: -1},
+	}, messages)
+}
+
+func TestNewMessageInput_Invalid(t *testing.T) {
+	_, err := NewMessageInput(strings.NewReader(""), "avro", "")
+	assert.EqualError(t, err, "invalid input format avro, expected one of jsonl|csv|delimited")
+
+	_, err = NewMessageInput(strings.NewReader(""), InputFormatDelimited, "")
+	assert.EqualError(t, err, "delimiter is needed for the delimited format")
+}
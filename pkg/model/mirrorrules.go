@@ -0,0 +1,166 @@
+package model
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math"
+	"regexp"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MirrorRules control which configs are mirrored for a topic, how their values are transformed
+// and how the topic is named on the destination cluster
+type MirrorRules struct {
+	Topics  []*TopicMappingRule `yaml:"topics"`
+	Configs []*ConfigMirrorRule `yaml:"configs"`
+}
+
+// TopicMappingRule renames the topics matching the regex. Replace is a regex replacement template, eg: "dr-$1",
+// and the prefix and suffix are added to the replaced name. Only the first matching rule is applied.
+type TopicMappingRule struct {
+	Match      string `yaml:"match"`
+	Replace    string `yaml:"replace"`
+	Prefix     string `yaml:"prefix"`
+	Suffix     string `yaml:"suffix"`
+	matchRegex *regexp.Regexp
+}
+
+// ConfigMirrorRule applies to the topics matching the regex. When include lists are present,
+// only the configs in them are mirrored. All the matching rules are applied in order.
+type ConfigMirrorRule struct {
+	Topics     string                     `yaml:"topics"`
+	Include    []string                   `yaml:"include"`
+	Exclude    []string                   `yaml:"exclude"`
+	Transforms map[string]ConfigTransform `yaml:"transforms"`
+	topicRegex *regexp.Regexp
+}
+
+type ConfigTransform struct {
+	// Scale multiplies numeric values by the factor, -1 values are left as is
+	Scale *float64 `yaml:"scale"`
+	// Value replaces the value of the config
+	Value *string `yaml:"value"`
+}
+
+func LoadMirrorRules(fileName string) (*MirrorRules, error) {
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	return parseMirrorRules(data)
+}
+
+func parseMirrorRules(data []byte) (*MirrorRules, error) {
+	rules := &MirrorRules{}
+	if err := yaml.Unmarshal(data, rules); err != nil {
+		return nil, fmt.Errorf("err while parsing mirror rules - %v", err)
+	}
+	for _, rule := range rules.Topics {
+		regex, err := regexp.Compile(rule.Match)
+		if err != nil {
+			return nil, fmt.Errorf("invalid topic regex %v - %v", rule.Match, err)
+		}
+		rule.matchRegex = regex
+	}
+	for _, rule := range rules.Configs {
+		regex, err := regexp.Compile(rule.Topics)
+		if err != nil {
+			return nil, fmt.Errorf("invalid topic regex %v - %v", rule.Topics, err)
+		}
+		rule.topicRegex = regex
+		for name, transform := range rule.Transforms {
+			if transform.Scale != nil && transform.Value != nil {
+				return nil, fmt.Errorf("transform for config %v on topics %v cannot have both scale and value", name, rule.Topics)
+			}
+		}
+	}
+	return rules, nil
+}
+
+// DestinationTopic returns the name of the topic on the destination cluster
+func (r *MirrorRules) DestinationTopic(topic string) string {
+	if r == nil {
+		return topic
+	}
+	for _, rule := range r.Topics {
+		if !rule.matchRegex.MatchString(topic) {
+			continue
+		}
+		name := topic
+		if rule.Replace != "" {
+			name = rule.matchRegex.ReplaceAllString(topic, rule.Replace)
+		}
+		return rule.Prefix + name + rule.Suffix
+	}
+	return topic
+}
+
+// ShouldMirror returns false if the config is excluded for the topic, or if include lists apply and it is not part of them
+func (r *MirrorRules) ShouldMirror(topic, config string) bool {
+	if r == nil {
+		return true
+	}
+	hasIncludes, included := false, false
+	for _, rule := range r.matchingConfigRules(topic) {
+		if (ListUtil{List: rule.Exclude}).Contains(config) {
+			return false
+		}
+		if len(rule.Include) > 0 {
+			hasIncludes = true
+			included = included || (ListUtil{List: rule.Include}).Contains(config)
+		}
+	}
+	return !hasIncludes || included
+}
+
+// Transform returns the value to be mirrored to the destination cluster
+func (r *MirrorRules) Transform(topic, config, value string) (string, error) {
+	if r == nil {
+		return value, nil
+	}
+	for _, rule := range r.matchingConfigRules(topic) {
+		transform, ok := rule.Transforms[config]
+		if !ok {
+			continue
+		}
+		transformed, err := transform.apply(value)
+		if err != nil {
+			return "", fmt.Errorf("err while transforming config %v for topic %v - %v", config, topic, err)
+		}
+		value = transformed
+	}
+	return value, nil
+}
+
+func (r *MirrorRules) matchingConfigRules(topic string) []*ConfigMirrorRule {
+	var rules []*ConfigMirrorRule
+	for _, rule := range r.Configs {
+		if rule.topicRegex.MatchString(topic) {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+func (t ConfigTransform) apply(value string) (string, error) {
+	if t.Value != nil {
+		return *t.Value, nil
+	}
+	if t.Scale == nil {
+		return value, nil
+	}
+	val, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("cannot scale non numeric value %v", value)
+	}
+	if val == -1 || val == math.MaxInt64 {
+		return value, nil
+	}
+	scaled := float64(val) * *t.Scale
+	if scaled >= math.MaxInt64 || scaled < math.MinInt64 {
+		return "", fmt.Errorf("scaling %v by %v overflows a long", value, *t.Scale)
+	}
+	return strconv.FormatInt(int64(scaled), 10), nil
+}
@@ -0,0 +1,94 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMirrorRules = `
+topics:
+  - match: "^orders-(.*)$"
+    replace: "orders-dr-$1"
+  - match: ".*"
+    prefix: "dr."
+    suffix: ".v1"
+configs:
+  - topics: ".*"
+    exclude: [segment.bytes]
+  - topics: "^orders-.*"
+    include: [retention.ms, cleanup.policy]
+    transforms:
+      retention.ms:
+        scale: 2
+  - topics: "^payments$"
+    transforms:
+      cleanup.policy:
+        value: compact
+`
+
+func TestMirrorRules_ParseFailure(t *testing.T) {
+	_, err := parseMirrorRules([]byte("topics:\n  - match: \"[\"\n"))
+	assert.Error(t, err)
+
+	_, err = parseMirrorRules([]byte("configs:\n  - topics: \".*\"\n    transforms:\n      retention.ms:\n        scale: 2\n        value: \"1\"\n"))
+	assert.Error(t, err)
+}
+
+func TestMirrorRules_DestinationTopic(t *testing.T) {
+	rules, err := parseMirrorRules([]byte(testMirrorRules))
+	require.NoError(t, err)
+
+	assert.Equal(t, "orders-dr-created", rules.DestinationTopic("orders-created"))
+	assert.Equal(t, "dr.payments.v1", rules.DestinationTopic("payments"))
+}
+
+func TestMirrorRules_ShouldMirror(t *testing.T) {
+	rules, err := parseMirrorRules([]byte(testMirrorRules))
+	require.NoError(t, err)
+
+	assert.True(t, rules.ShouldMirror("orders-created", "retention.ms"))
+	assert.False(t, rules.ShouldMirror("orders-created", "min.insync.replicas"))
+	assert.False(t, rules.ShouldMirror("orders-created", "segment.bytes"))
+	assert.True(t, rules.ShouldMirror("payments", "min.insync.replicas"))
+	assert.False(t, rules.ShouldMirror("payments", "segment.bytes"))
+}
+
+func TestMirrorRules_Transform(t *testing.T) {
+	rules, err := parseMirrorRules([]byte(testMirrorRules))
+	require.NoError(t, err)
+
+	value, err := rules.Transform("orders-created", "retention.ms", "3600000")
+	assert.NoError(t, err)
+	assert.Equal(t, "7200000", value)
+
+	value, err = rules.Transform("orders-created", "retention.ms", "-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "-1", value)
+
+	value, err = rules.Transform("orders-created", "retention.ms", "9223372036854775807")
+	assert.NoError(t, err)
+	assert.Equal(t, "9223372036854775807", value)
+
+	_, err = rules.Transform("orders-created", "retention.ms", "9223372036854775806")
+	assert.EqualError(t, err, "err while transforming config retention.ms for topic orders-created - "+
+		"scaling 9223372036854775806 by 2 overflows a long")
+
+	value, err = rules.Transform("payments", "cleanup.policy", "delete")
+	assert.NoError(t, err)
+	assert.Equal(t, "compact", value)
+
+	_, err = rules.Transform("orders-created", "retention.ms", "abc")
+	assert.Error(t, err)
+}
+
+func TestMirrorRules_NilRulesMirrorEverything(t *testing.T) {
+	var rules *MirrorRules
+
+	assert.Equal(t, "topic1", rules.DestinationTopic("topic1"))
+	assert.True(t, rules.ShouldMirror("topic1", "retention.ms"))
+	value, err := rules.Transform("topic1", "retention.ms", "1000")
+	assert.NoError(t, err)
+	assert.Equal(t, "1000", value)
+}
@@ -0,0 +1,76 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gojek/kat/pkg/client"
+)
+
+// OffsetSpec is a position in a partition, given as an absolute offset, a time, or the oldest or newest offset
+type OffsetSpec struct {
+	offset int64
+	time   *time.Time
+}
+
+var (
+	OffsetSpecOldest = OffsetSpec{offset: client.OffsetOldest}
+	OffsetSpecNewest = OffsetSpec{offset: client.OffsetNewest}
+)
+
+// ParseOffsetSpec parses one of earliest|latest, an offset, a RFC3339 time, or a duration before now, eg: -1h
+func ParseOffsetSpec(spec string) (OffsetSpec, error) {
+	switch strings.ToLower(spec) {
+	case "earliest", "oldest":
+		return OffsetSpecOldest, nil
+	case "latest", "newest":
+		return OffsetSpecNewest, nil
+	}
+	if offset, err := strconv.ParseInt(spec, 10, 64); err == nil && offset >= 0 {
+		return OffsetSpec{offset: offset}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, spec); err == nil {
+		return OffsetSpec{time: &t}, nil
+	}
+	if strings.HasPrefix(spec, "-") {
+		if duration, err := time.ParseDuration(spec); err == nil {
+			t := time.Now().Add(duration)
+			return OffsetSpec{time: &t}, nil
+		}
+	}
+	return OffsetSpec{}, fmt.Errorf("invalid offset %v, expected one of earliest|latest, an offset, a RFC3339 time or a duration like -1h", spec)
+}
+
+// Resolve returns the offset in the partition. A time after the last message resolves to the newest offset.
+func (o OffsetSpec) Resolve(reader client.MessageReader, topic string, partition int32) (int64, error) {
+	if o.time == nil {
+		if o.offset >= 0 {
+			return o.offset, nil
+		}
+		return reader.GetOffset(topic, partition, o.offset)
+	}
+
+	offset, err := reader.GetOffset(topic, partition, o.time.UnixNano()/int64(time.Millisecond))
+	if err != nil {
+		return 0, err
+	}
+	if offset < 0 {
+		return reader.GetOffset(topic, partition, client.OffsetNewest)
+	}
+	return offset, nil
+}
+
+func (o OffsetSpec) String() string {
+	switch {
+	case o.time != nil:
+		return o.time.Format(time.RFC3339)
+	case o.offset == client.OffsetOldest:
+		return "earliest"
+	case o.offset == client.OffsetNewest:
+		return "latest"
+	default:
+		return strconv.FormatInt(o.offset, 10)
+	}
+}
@@ -0,0 +1,76 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOffsetSpec(t *testing.T) {
+	spec, err := ParseOffsetSpec("earliest")
+	require.NoError(t, err)
+	assert.Equal(t, OffsetSpecOldest, spec)
+
+	spec, err = ParseOffsetSpec("latest")
+	require.NoError(t, err)
+	assert.Equal(t, OffsetSpecNewest, spec)
+
+	spec, err = ParseOffsetSpec("42")
+	require.NoError(t, err)
+	assert.Equal(t, "42", spec.String())
+
+	spec, err = ParseOffsetSpec("2021-03-04T05:06:07Z")
+	require.NoError(t, err)
+	assert.Equal(t, "2021-03-04T05:06:07Z", spec.String())
+
+	spec, err = ParseOffsetSpec("-1h")
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(-time.Hour), *spec.time, time.Minute)
+}
+
+func TestParseOffsetSpec_Invalid(t *testing.T) {
+	for _, spec := range []string{"", "-5", "yesterday", "1h"} {
+		_, err := ParseOffsetSpec(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestOffsetSpec_Resolve(t *testing.T) {
+	reader := &client.MockMessageReader{}
+	reader.On("GetOffset", "topic", int32(1), int64(client.OffsetOldest)).Return(int64(10), nil)
+
+	offset, err := OffsetSpecOldest.Resolve(reader, "topic", 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), offset)
+
+	offset, err = OffsetSpec{offset: 25}.Resolve(reader, "topic", 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(25), offset)
+	reader.AssertExpectations(t)
+}
+
+func TestOffsetSpec_ResolveTime(t *testing.T) {
+	reader := &client.MockMessageReader{}
+	at := time.Unix(1600000000, 0)
+	reader.On("GetOffset", "topic", int32(0), int64(1600000000000)).Return(int64(-1), nil)
+	reader.On("GetOffset", "topic", int32(0), int64(client.OffsetNewest)).Return(int64(99), nil)
+
+	offset, err := OffsetSpec{time: &at}.Resolve(reader, "topic", 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(99), offset)
+	reader.AssertExpectations(t)
+}
+
+func TestOffsetSpec_ResolveFailure(t *testing.T) {
+	reader := &client.MockMessageReader{}
+	reader.On("GetOffset", "topic", int32(0), int64(client.OffsetNewest)).Return(int64(0), errors.New("error"))
+
+	_, err := OffsetSpecNewest.Resolve(reader, "topic", 0)
+
+	assert.EqualError(t, err, "error")
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gojek/kat/pkg/client"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/desc/protoparse"
+	"github.com/jhump/protoreflect/dynamic"
+)
+
+// protoSchemaFile is the file name of the parsed schema, its imports are the names of the schema references
+const protoSchemaFile = "schema.proto"
+
+var errProtoShortBuffer = errors.New("unexpected end of protobuf data")
+
+// parseProtoSchema parses the .proto schema, with its imports resolved from the referenced schemas.
+// The well known google/protobuf imports need no references.
+func parseProtoSchema(schema string, references map[string]*client.Schema) (*desc.FileDescriptor, error) {
+	files := map[string]string{protoSchemaFile: schema}
+	for name, reference := range references {
+		files[name] = reference.Schema
+	}
+	parser := protoparse.Parser{Accessor: protoparse.FileContentsFromMap(files)}
+	parsed, err := parser.ParseFiles(protoSchemaFile)
+	if err != nil {
+		return nil, fmt.Errorf("err while parsing the protobuf schema - %v", err)
+	}
+	return parsed[0], nil
+}
+
+// protoMessage returns the message at the indexes, which are the index of a top level message followed by the
+// indexes of the nested messages
+func protoMessage(file *desc.FileDescriptor, indexes []int) (*desc.MessageDescriptor, error) {
+	messages := file.GetMessageTypes()
+	var msg *desc.MessageDescriptor
+	for _, index := range indexes {
+		if index < 0 || index >= len(messages) {
+			return nil, fmt.Errorf("invalid protobuf message index %v", indexes)
+		}
+		msg = messages[index]
+		messages = msg.GetNestedMessageTypes()
+	}
+	if msg == nil {
+		return nil, fmt.Errorf("invalid protobuf message index %v", indexes)
+	}
+	return msg, nil
+}
+
+// decodeProto decodes the protobuf binary encoding of the message into its json mapping, with the field names as
+// declared in the schema
+func decodeProto(msg *desc.MessageDescriptor, data []byte) ([]byte, error) {
+	value := dynamic.NewMessage(msg)
+	if err := value.Unmarshal(data); err != nil {
+		return nil, fmt.Errorf("err while decoding %v - %v", msg.GetFullyQualifiedName(), err)
+	}
+	return value.MarshalJSONPB(&jsonpb.Marshaler{OrigName: true})
+}
@@ -0,0 +1,109 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const orderProtoSchema = `
+syntax = "proto3";
+package shop;
+
+import "google/protobuf/timestamp.proto";
+option java_package = "com.shop";
+
+message Order {
+  int64 id = 1;
+  string name = 2;
+  repeated int32 quantities = 3;
+  Status status = 4;
+  map<string, string> labels = 5;
+  Item item = 6;
+  oneof payment {
+    string card = 7;
+    string wallet = 8;
+  }
+  google.protobuf.Timestamp created_at = 9;
+
+  message Item {
+    string sku = 1 [deprecated = true];
+  }
+  enum Status {
+    UNKNOWN = 0;
+    PAID = 1;
+  }
+}
+
+message Refund {
+  sint64 amount = 1;
+}
+`
+
+func TestDecodeProto(t *testing.T) {
+	file, err := parseProtoSchema(orderProtoSchema, nil)
+	require.NoError(t, err)
+	msg, err := protoMessage(file, []int{0})
+	require.NoError(t, err)
+	data := []byte{
+		0x08, 0x96, 0x01, // id: 150
+		0x12, 0x02, 'a', 'b', // name: ab
+		0x1a, 0x02, 0x01, 0x02, // quantities: packed [1, 2]
+		0x20, 0x01, // status: PAID
+		0x2a, 0x06, 0x0a, 0x01, 'k', 0x12, 0x01, 'v', // labels: {k: v}
+		0x32, 0x04, 0x0a, 0x02, 's', '1', // item: {sku: s1}
+		0x42, 0x01, 'w', // wallet: w
+		0x4a, 0x02, 0x08, 0x01, // created_at: 1 second
+		0x50, 0x07, // unknown field 10: 7
+	}
+
+	out, err := decodeProto(msg, data)
+
+	require.NoError(t, err)
+	assert.Equal(t, `{"id":"150","name":"ab","quantities":[1,2],"status":"PAID","labels":{"k":"v"},"item":{"sku":"s1"},`+
+		`"wallet":"w","created_at":"1970-01-01T00:00:01Z"}`, string(out))
+}
+
+func TestDecodeProto_SecondMessage(t *testing.T) {
+	file, err := parseProtoSchema(orderProtoSchema, nil)
+	require.NoError(t, err)
+	msg, err := protoMessage(file, []int{1})
+	require.NoError(t, err)
+
+	out, err := decodeProto(msg, []byte{0x08, 0x03})
+
+	require.NoError(t, err)
+	assert.Equal(t, `{"amount":"-2"}`, string(out))
+}
+
+func TestDecodeProto_Imports(t *testing.T) {
+	references := map[string]*client.Schema{
+		"shop/item.proto": {Schema: `syntax = "proto3"; package shop; message Item { string sku = 1; }`},
+	}
+	file, err := parseProtoSchema(`syntax = "proto3"; package shop; import "shop/item.proto"; message Order { Item item = 1; }`, references)
+	require.NoError(t, err)
+	msg, err := protoMessage(file, []int{0})
+	require.NoError(t, err)
+
+	out, err := decodeProto(msg, []byte{0x0a, 0x03, 0x0a, 0x01, 's'})
+
+	require.NoError(t, err)
+	assert.Equal(t, `{"item":{"sku":"s"}}`, string(out))
+}
+
+func TestDecodeProto_Errors(t *testing.T) {
+	_, err := parseProtoSchema(`syntax = "proto3"; import "shop/item.proto";`, nil)
+	assert.Error(t, err)
+
+	file, err := parseProtoSchema(orderProtoSchema, nil)
+	require.NoError(t, err)
+	_, err = protoMessage(file, []int{2})
+	assert.EqualError(t, err, "invalid protobuf message index [2]")
+
+	msg, err := protoMessage(file, []int{0, 0})
+	require.NoError(t, err)
+	_, err = decodeProto(msg, []byte{0x0a, 0x05, 's'})
+	assert.Error(t, err)
+}
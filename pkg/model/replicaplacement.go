@@ -0,0 +1,104 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gojek/kat/pkg/client"
+)
+
+// AssignReplicas computes a rack aware replica assignment for the partitions. The replicas of a partition are placed on
+// different racks as long as there are enough racks, and the leaders are spread across the brokers.
+// Brokers without a rack are treated as being on the same rack.
+func AssignReplicas(brokers []client.Broker, numPartitions int32, replicationFactor int16) (map[int32][]int32, error) {
+	if replicationFactor <= 0 {
+		return nil, fmt.Errorf("replication factor should be greater than 0, got %d", replicationFactor)
+	}
+	if int(replicationFactor) > len(brokers) {
+		return nil, fmt.Errorf("replication factor %d is greater than the number of brokers %d", replicationFactor, len(brokers))
+	}
+
+	ordered := rackAlternatedBrokers(brokers)
+	assignment := make(map[int32][]int32, numPartitions)
+	for partition := int32(0); partition < numPartitions; partition++ {
+		assignment[partition] = pickReplicas(ordered, int(partition)%len(ordered), int(replicationFactor))
+	}
+	return assignment, nil
+}
+
+// pickReplicas walks the brokers from the start index, first picking brokers on racks without a replica,
+// and then filling up the remaining replicas from the other brokers
+func pickReplicas(brokers []client.Broker, start, replicationFactor int) []int32 {
+	replicas := make([]int32, 0, replicationFactor)
+	usedBrokers := make(map[int32]bool)
+	usedRacks := make(map[string]bool)
+	for _, distinctRacks := range []bool{true, false} {
+		for i := 0; i < len(brokers) && len(replicas) < replicationFactor; i++ {
+			broker := brokers[(start+i)%len(brokers)]
+			if usedBrokers[broker.ID] || (distinctRacks && usedRacks[broker.Rack]) {
+				continue
+			}
+			replicas = append(replicas, broker.ID)
+			usedBrokers[broker.ID] = true
+			usedRacks[broker.Rack] = true
+		}
+	}
+	return replicas
+}
+
+// rackAlternatedBrokers orders the brokers so that consecutive brokers are on different racks, eg:
+// rack1: [1, 2], rack2: [3], rack3: [4, 5] is ordered as [1, 3, 4, 2, 5]
+func rackAlternatedBrokers(brokers []client.Broker) []client.Broker {
+	brokersByRack := make(map[string][]client.Broker)
+	for _, broker := range brokers {
+		brokersByRack[broker.Rack] = append(brokersByRack[broker.Rack], broker)
+	}
+	racks := make([]string, 0, len(brokersByRack))
+	for rack, rackBrokers := range brokersByRack {
+		racks = append(racks, rack)
+		sort.Slice(rackBrokers, func(i, j int) bool {
+			return rackBrokers[i].ID < rackBrokers[j].ID
+		})
+	}
+	sort.Strings(racks)
+
+	ordered := make([]client.Broker, 0, len(brokers))
+	for i := 0; len(ordered) < len(brokers); i++ {
+		for _, rack := range racks {
+			if i < len(brokersByRack[rack]) {
+				ordered = append(ordered, brokersByRack[rack][i])
+			}
+		}
+	}
+	return ordered
+}
+
+// ValidateAssignment returns an error if the assignment has replicas on brokers that are not part of the cluster,
+// or the same broker more than once for a partition
+func ValidateAssignment(assignment map[int32][]int32, brokers []client.Broker) error {
+	brokerIDs := make(map[int32]bool, len(brokers))
+	for _, broker := range brokers {
+		brokerIDs[broker.ID] = true
+	}
+
+	partitions := make([]int32, 0, len(assignment))
+	for partition := range assignment {
+		partitions = append(partitions, partition)
+	}
+	sort.Slice(partitions, func(i, j int) bool {
+		return partitions[i] < partitions[j]
+	})
+	for _, partition := range partitions {
+		replicas := make(map[int32]bool)
+		for _, replica := range assignment[partition] {
+			if !brokerIDs[replica] {
+				return fmt.Errorf("partition %d has a replica on unknown broker %d", partition, replica)
+			}
+			if replicas[replica] {
+				return fmt.Errorf("partition %d has more than one replica on broker %d", partition, replica)
+			}
+			replicas[replica] = true
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssignReplicas_SpreadsReplicasAcrossRacks(t *testing.T) {
+	brokers := []client.Broker{
+		{ID: 1, Rack: "a"}, {ID: 2, Rack: "a"},
+		{ID: 3, Rack: "b"}, {ID: 4, Rack: "b"},
+		{ID: 5, Rack: "c"}, {ID: 6, Rack: "c"},
+	}
+
+	assignment, err := AssignReplicas(brokers, 6, 3)
+
+	require.NoError(t, err)
+	assert.Equal(t, map[int32][]int32{
+		0: {1, 3, 5},
+		1: {3, 5, 2},
+		2: {5, 2, 4},
+		3: {2, 4, 6},
+		4: {4, 6, 1},
+		5: {6, 1, 3},
+	}, assignment)
+	brokerRacks := map[int32]string{1: "a", 2: "a", 3: "b", 4: "b", 5: "c", 6: "c"}
+	for partition, replicas := range assignment {
+		racks := make(map[string]bool)
+		for _, replica := range replicas {
+			racks[brokerRacks[replica]] = true
+		}
+		assert.Len(t, racks, 3, "replicas of partition %d are not on distinct racks", partition)
+	}
+}
+
+func TestAssignReplicas_FillsUpWhenRacksAreFewerThanReplicationFactor(t *testing.T) {
+	brokers := []client.Broker{{ID: 1, Rack: "a"}, {ID: 2, Rack: "a"}, {ID: 3, Rack: "b"}}
+
+	assignment, err := AssignReplicas(brokers, 2, 3)
+
+	require.NoError(t, err)
+	assert.Equal(t, map[int32][]int32{0: {1, 3, 2}, 1: {3, 2, 1}}, assignment)
+}
+
+func TestAssignReplicas_WithoutRacks(t *testing.T) {
+	brokers := []client.Broker{{ID: 3}, {ID: 1}, {ID: 2}}
+
+	assignment, err := AssignReplicas(brokers, 3, 2)
+
+	require.NoError(t, err)
+	assert.Equal(t, map[int32][]int32{0: {1, 2}, 1: {2, 3}, 2: {3, 1}}, assignment)
+}
+
+func TestAssignReplicas_Failure(t *testing.T) {
+	brokers := []client.Broker{{ID: 1}, {ID: 2}}
+
+	_, err := AssignReplicas(brokers, 3, 3)
+	assert.EqualError(t, err, "replication factor 3 is greater than the number of brokers 2")
+
+	_, err = AssignReplicas(brokers, 3, 0)
+	assert.Error(t, err)
+}
+
+func TestValidateAssignment(t *testing.T) {
+	brokers := []client.Broker{{ID: 1}, {ID: 2}, {ID: 3}}
+
+	assert.NoError(t, ValidateAssignment(map[int32][]int32{0: {1, 2}, 1: {2, 3}}, brokers))
+	assert.EqualError(t, ValidateAssignment(map[int32][]int32{0: {1, 2}, 1: {2, 4}}, brokers),
+		"partition 1 has a replica on unknown broker 4")
+	assert.EqualError(t, ValidateAssignment(map[int32][]int32{0: {1, 1}}, brokers),
+		"partition 0 has more than one replica on broker 1")
+}
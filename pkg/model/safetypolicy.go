@@ -0,0 +1,103 @@
+package model
+
+import (
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Destructive operations checked against the safety policy
+const (
+	// OperationDelete deletes topics or the messages of topics
+	OperationDelete = "delete"
+	// OperationReassign moves the replicas of topics across brokers
+	OperationReassign = "reassign"
+	// OperationAlterConfig sets or deletes topic configs
+	OperationAlterConfig = "alter-config"
+	// OperationCreate creates topics or partitions
+	OperationCreate = "create"
+)
+
+// SafetyPolicy guards the destructive operations on a cluster
+type SafetyPolicy struct {
+	// ProtectedTopics are regexes of the topics on which no destructive operation is permitted
+	ProtectedTopics []string `yaml:"protectedTopics"`
+	// MaxTopicsPerDelete is the maximum number of topics a single delete may remove, 0 for no limit
+	MaxTopicsPerDelete int `yaml:"maxTopicsPerDelete"`
+	// Production clusters require the confirmation phrase to be typed for every destructive operation
+	Production         bool   `yaml:"production"`
+	ConfirmationPhrase string `yaml:"confirmationPhrase"`
+	// AllowedUsers are permitted to delete and reassign, any user is permitted when empty
+	AllowedUsers     []string `yaml:"allowedUsers"`
+	protectedRegexes []*regexp.Regexp
+}
+
+func LoadSafetyPolicy(fileName string) (*SafetyPolicy, error) {
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	return parseSafetyPolicy(data)
+}
+
+func parseSafetyPolicy(data []byte) (*SafetyPolicy, error) {
+	policy := &SafetyPolicy{}
+	if err := yaml.Unmarshal(data, policy); err != nil {
+		return nil, fmt.Errorf("err while parsing safety policy - %v", err)
+	}
+	for _, protected := range policy.ProtectedTopics {
+		regex, err := regexp.Compile(protected)
+		if err != nil {
+			return nil, fmt.Errorf("invalid protected topic regex %v - %v", protected, err)
+		}
+		policy.protectedRegexes = append(policy.protectedRegexes, regex)
+	}
+	if policy.MaxTopicsPerDelete < 0 {
+		return nil, fmt.Errorf("maxTopicsPerDelete should not be negative, got %d", policy.MaxTopicsPerDelete)
+	}
+	if policy.Production && strings.TrimSpace(policy.ConfirmationPhrase) == "" {
+		return nil, fmt.Errorf("confirmationPhrase is required for a production cluster")
+	}
+	return policy, nil
+}
+
+// Check returns an error if the user is not permitted to run the operation on the topics
+func (p *SafetyPolicy) Check(operation, user string, topics []string) error {
+	if (operation == OperationDelete || operation == OperationReassign) && len(p.AllowedUsers) > 0 &&
+		!(ListUtil{List: p.AllowedUsers}).Contains(user) {
+		return fmt.Errorf("user %v is not permitted to %v topics", user, operation)
+	}
+	if operation == OperationDelete && p.MaxTopicsPerDelete > 0 && len(topics) > p.MaxTopicsPerDelete {
+		return fmt.Errorf("%d topics cannot be deleted at once, the maximum is %d", len(topics), p.MaxTopicsPerDelete)
+	}
+	if protected := p.protectedTopics(topics); len(protected) > 0 {
+		return fmt.Errorf("topics %v are protected from %v", protected, operation)
+	}
+	return nil
+}
+
+func (p *SafetyPolicy) protectedTopics(topics []string) []string {
+	var protected []string
+	for _, topic := range topics {
+		for _, regex := range p.protectedRegexes {
+			if regex.MatchString(topic) {
+				protected = append(protected, topic)
+				break
+			}
+		}
+	}
+	return protected
+}
+
+// RequiresConfirmation returns true when the confirmation phrase has to be typed before a destructive operation
+func (p *SafetyPolicy) RequiresConfirmation() bool {
+	return p.Production
+}
+
+// Confirmed returns true when the phrase matches the confirmation phrase, ignoring the surrounding spaces
+func (p *SafetyPolicy) Confirmed(phrase string) bool {
+	return strings.TrimSpace(phrase) == strings.TrimSpace(p.ConfirmationPhrase)
+}
@@ -0,0 +1,68 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSafetyPolicy = `
+protectedTopics: ["^payments", "^orders$"]
+maxTopicsPerDelete: 2
+production: true
+confirmationPhrase: "delete on kafka-prod"
+allowedUsers: [alice, bob]
+`
+
+func TestSafetyPolicy_ParseSuccess(t *testing.T) {
+	policy, err := parseSafetyPolicy([]byte(testSafetyPolicy))
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, policy.MaxTopicsPerDelete)
+	assert.Equal(t, []string{"alice", "bob"}, policy.AllowedUsers)
+	assert.True(t, policy.RequiresConfirmation())
+}
+
+func TestSafetyPolicy_ParseErrors(t *testing.T) {
+	_, err := parseSafetyPolicy([]byte(`protectedTopics: ["("]`))
+	assert.Error(t, err)
+
+	_, err = parseSafetyPolicy([]byte(`maxTopicsPerDelete: -1`))
+	assert.EqualError(t, err, "maxTopicsPerDelete should not be negative, got -1")
+
+	_, err = parseSafetyPolicy([]byte(`production: true`))
+	assert.EqualError(t, err, "confirmationPhrase is required for a production cluster")
+
+	_, err = parseSafetyPolicy([]byte(`protectedTopics: payments`))
+	assert.Error(t, err)
+}
+
+func TestSafetyPolicy_Check(t *testing.T) {
+	policy, err := parseSafetyPolicy([]byte(testSafetyPolicy))
+	require.NoError(t, err)
+
+	assert.NoError(t, policy.Check(OperationDelete, "alice", []string{"orders-retry", "test"}))
+	assert.NoError(t, policy.Check(OperationAlterConfig, "carol", []string{"test"}))
+	assert.EqualError(t, policy.Check(OperationDelete, "carol", []string{"test"}), "user carol is not permitted to delete topics")
+	assert.EqualError(t, policy.Check(OperationReassign, "carol", []string{"test"}), "user carol is not permitted to reassign topics")
+	assert.EqualError(t, policy.Check(OperationDelete, "bob", []string{"a", "b", "c"}), "3 topics cannot be deleted at once, the maximum is 2")
+	assert.NoError(t, policy.Check(OperationReassign, "bob", []string{"a", "b", "c"}))
+	assert.EqualError(t, policy.Check(OperationAlterConfig, "bob", []string{"orders", "payments-dlq", "test"}),
+		"topics [orders payments-dlq] are protected from alter-config")
+}
+
+func TestSafetyPolicy_EmptyPolicyPermitsEverything(t *testing.T) {
+	policy := &SafetyPolicy{}
+
+	assert.NoError(t, policy.Check(OperationDelete, "carol", []string{"a", "b", "c"}))
+	assert.False(t, policy.RequiresConfirmation())
+}
+
+func TestSafetyPolicy_Confirmed(t *testing.T) {
+	policy, err := parseSafetyPolicy([]byte(testSafetyPolicy))
+	require.NoError(t, err)
+
+	assert.True(t, policy.Confirmed(" delete on kafka-prod "))
+	assert.False(t, policy.Confirmed("yes"))
+}
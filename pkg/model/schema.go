@@ -0,0 +1,90 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/gojek/kat/pkg/client"
+)
+
+// Suffixes of the subjects of the topic keys and values, as per the topic name strategy of the serializers
+const (
+	keySubjectSuffix   = "-key"
+	valueSubjectSuffix = "-value"
+)
+
+// TopicSubject returns the subject of the key or value schema of the topic
+func TopicSubject(topic string, key bool) string {
+	if key {
+		return topic + keySubjectSuffix
+	}
+	return topic + valueSubjectSuffix
+}
+
+// SubjectTopic returns the topic of the subject, or an empty string when the subject does not follow the topic name strategy
+func SubjectTopic(subject string) string {
+	for _, suffix := range []string{keySubjectSuffix, valueSubjectSuffix} {
+		if strings.HasSuffix(subject, suffix) {
+			return strings.TrimSuffix(subject, suffix)
+		}
+	}
+	return ""
+}
+
+// FormatSchema indents the avro and json schemas, so that they can be read and compared line by line
+func FormatSchema(schema *client.Schema) string {
+	if schema.Type() == client.SchemaTypeProtobuf {
+		return schema.Schema
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, []byte(schema.Schema), "", "  "); err != nil {
+		return schema.Schema
+	}
+	return out.String()
+}
+
+// DiffLines returns the lines of both the texts prefixed with "- " when only in the old text,
+// "+ " when only in the new text, and "  " when in both
+func DiffLines(oldText, newText string) []string {
+	oldLines, newLines := strings.Split(oldText, "\n"), strings.Split(newText, "\n")
+	common := commonSubsequenceLengths(oldLines, newLines)
+	var diff []string
+	i, j := 0, 0
+	for i < len(oldLines) || j < len(newLines) {
+		switch {
+		case i < len(oldLines) && j < len(newLines) && oldLines[i] == newLines[j]:
+			diff = append(diff, "  "+oldLines[i])
+			i++
+			j++
+		case j < len(newLines) && (i == len(oldLines) || common[i][j+1] >= common[i+1][j]):
+			diff = append(diff, "+ "+newLines[j])
+			j++
+		default:
+			diff = append(diff, "- "+oldLines[i])
+			i++
+		}
+	}
+	return diff
+}
+
+// commonSubsequenceLengths returns the lengths of the longest common subsequences of a[i:] and b[j:] at [i][j]
+func commonSubsequenceLengths(a, b []string) [][]int {
+	common := make([][]int, len(a)+1)
+	for i := range common {
+		common[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				common[i][j] = common[i+1][j+1] + 1
+			case common[i+1][j] >= common[i][j+1]:
+				common[i][j] = common[i+1][j]
+			default:
+				common[i][j] = common[i][j+1]
+			}
+		}
+	}
+	return common
+}
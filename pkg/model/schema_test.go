@@ -0,0 +1,31 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTopicSubject(t *testing.T) {
+	assert.Equal(t, "orders-key", TopicSubject("orders", true))
+	assert.Equal(t, "orders-value", TopicSubject("orders", false))
+}
+
+func TestSubjectTopic(t *testing.T) {
+	assert.Equal(t, "orders", SubjectTopic("orders-key"))
+	assert.Equal(t, "orders", SubjectTopic("orders-value"))
+	assert.Equal(t, "", SubjectTopic("shop.Order"))
+}
+
+func TestFormatSchema(t *testing.T) {
+	assert.Equal(t, "{\n  \"type\": \"string\"\n}", FormatSchema(&client.Schema{Schema: `{"type":"string"}`}))
+	assert.Equal(t, "syntax = \"proto3\";", FormatSchema(&client.Schema{SchemaType: client.SchemaTypeProtobuf, Schema: "syntax = \"proto3\";"}))
+	assert.Equal(t, "not json", FormatSchema(&client.Schema{Schema: "not json"}))
+}
+
+func TestDiffLines(t *testing.T) {
+	diff := DiffLines("a\nb\nc\nd", "a\nc\nx\nd\ne")
+
+	assert.Equal(t, []string{"  a", "- b", "  c", "+ x", "  d", "+ e"}, diff)
+}
@@ -0,0 +1,129 @@
+package model
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/gojek/kat/pkg/client"
+	"github.com/jhump/protoreflect/desc"
+	"github.com/linkedin/goavro/v2"
+)
+
+// wireFormatMagicByte is the first byte of the values in the confluent wire format, followed by the 4 byte schema id
+const wireFormatMagicByte = 0
+
+// SchemaDecoder decodes the values in the confluent wire format into json, with the avro, protobuf or json schema
+// fetched from the registry by the schema id in the value
+type SchemaDecoder struct {
+	registry client.SchemaRegistry
+	mu       sync.Mutex
+	// parsed has the parsed avro or protobuf schemas by id
+	parsed map[int]interface{}
+}
+
+func NewSchemaDecoder(registry client.SchemaRegistry) *SchemaDecoder {
+	return &SchemaDecoder{registry: registry, parsed: make(map[int]interface{})}
+}
+
+func (d *SchemaDecoder) Decode(data []byte) ([]byte, error) {
+	if len(data) < 5 || data[0] != wireFormatMagicByte {
+		return nil, fmt.Errorf("value is not in the schema registry wire format")
+	}
+	id := int(binary.BigEndian.Uint32(data[1:5]))
+	schema, err := d.registry.GetSchemaByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("err while fetching schema %d - %v", id, err)
+	}
+
+	payload := data[5:]
+	switch schema.Type() {
+	case client.SchemaTypeAvro:
+		return d.decodeAvro(id, schema, payload)
+	case client.SchemaTypeProtobuf:
+		return d.decodeProtobuf(id, schema, payload)
+	case client.SchemaTypeJSONSchema:
+		return payload, nil
+	default:
+		return nil, fmt.Errorf("unsupported schema type %v", schema.SchemaType)
+	}
+}
+
+// parse returns the parsed schema of the id, parsing it only the first time
+func (d *SchemaDecoder) parse(id int, parse func() (interface{}, error)) (interface{}, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if parsed, ok := d.parsed[id]; ok {
+		return parsed, nil
+	}
+	parsed, err := parse()
+	if err != nil {
+		return nil, err
+	}
+	d.parsed[id] = parsed
+	return parsed, nil
+}
+
+func (d *SchemaDecoder) decodeAvro(id int, schema *client.Schema, payload []byte) ([]byte, error) {
+	parsed, err := d.parse(id, func() (interface{}, error) {
+		references, err := client.ResolveReferences(d.registry, schema)
+		if err != nil {
+			return nil, err
+		}
+		return parseAvroSchema(schema.Schema, references)
+	})
+	if err != nil {
+		return nil, err
+	}
+	value, err := decodeAvro(parsed.(*goavro.Codec), payload)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(value)
+}
+
+func (d *SchemaDecoder) decodeProtobuf(id int, schema *client.Schema, payload []byte) ([]byte, error) {
+	parsed, err := d.parse(id, func() (interface{}, error) {
+		references, err := client.ResolveReferences(d.registry, schema)
+		if err != nil {
+			return nil, err
+		}
+		return parseProtoSchema(schema.Schema, references)
+	})
+	if err != nil {
+		return nil, err
+	}
+	indexes, payload, err := readMessageIndexes(payload)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := protoMessage(parsed.(*desc.FileDescriptor), indexes)
+	if err != nil {
+		return nil, err
+	}
+	return decodeProto(msg, payload)
+}
+
+// readMessageIndexes reads the zigzag encoded count and indexes of the message in the schema.
+// A count of 0 refers to the first message.
+func readMessageIndexes(payload []byte) ([]int, []byte, error) {
+	count, n := binary.Varint(payload)
+	if n <= 0 || count < 0 {
+		return nil, nil, errProtoShortBuffer
+	}
+	payload = payload[n:]
+	if count == 0 {
+		return []int{0}, payload, nil
+	}
+	indexes := make([]int, 0, count)
+	for i := int64(0); i < count; i++ {
+		index, m := binary.Varint(payload)
+		if m <= 0 {
+			return nil, nil, errProtoShortBuffer
+		}
+		indexes = append(indexes, int(index))
+		payload = payload[m:]
+	}
+	return indexes, payload, nil
+}
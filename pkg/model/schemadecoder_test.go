@@ -0,0 +1,91 @@
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaDecoder_Avro(t *testing.T) {
+	registry := &client.MockSchemaRegistry{}
+	registry.On("GetSchemaByID", 1).Return(&client.Schema{ID: 1, Schema: `{"type": "record", "name": "R", "fields": [{"name": "id", "type": "int"}]}`}, nil)
+	decoder := NewSchemaDecoder(registry)
+
+	for _, value := range []byte{0x02, 0x04} {
+		_, err := decoder.Decode([]byte{0, 0, 0, 0, 1, value})
+		require.NoError(t, err)
+	}
+	decoded, err := decoder.Decode([]byte{0, 0, 0, 0, 1, 0x0a})
+
+	require.NoError(t, err)
+	assert.Equal(t, `{"id":5}`, string(decoded))
+	assert.Equal(t, 1, len(decoder.parsed))
+}
+
+func TestSchemaDecoder_Protobuf(t *testing.T) {
+	registry := &client.MockSchemaRegistry{}
+	registry.On("GetSchemaByID", 2).Return(&client.Schema{ID: 2, SchemaType: client.SchemaTypeProtobuf, Schema: orderProtoSchema}, nil)
+	decoder := NewSchemaDecoder(registry)
+
+	decoded, err := decoder.Decode([]byte{0, 0, 0, 0, 2, 0x00, 0x08, 0x01})
+	require.NoError(t, err)
+	assert.Equal(t, `{"id":"1"}`, string(decoded))
+
+	// message indexes [1] refer to the second message of the schema
+	decoded, err = decoder.Decode([]byte{0, 0, 0, 0, 2, 0x02, 0x02, 0x08, 0x03})
+	require.NoError(t, err)
+	assert.Equal(t, `{"amount":"-2"}`, string(decoded))
+
+	// message indexes [0, 1] refer to the second message nested in the first message, after the entry of the labels map
+	decoded, err = decoder.Decode([]byte{0, 0, 0, 0, 2, 0x04, 0x00, 0x02, 0x0a, 0x01, 's'})
+	require.NoError(t, err)
+	assert.Equal(t, `{"sku":"s"}`, string(decoded))
+}
+
+func TestSchemaDecoder_References(t *testing.T) {
+	registry := &client.MockSchemaRegistry{}
+	registry.On("GetSchemaByID", 5).Return(&client.Schema{ID: 5, SchemaType: client.SchemaTypeProtobuf,
+		Schema:     `syntax = "proto3"; import "item.proto"; message Order { Item item = 1; }`,
+		References: []client.SchemaReference{{Name: "item.proto", Subject: "item-value", Version: 2}}}, nil)
+	registry.On("GetSchema", "item-value", "2").Return(&client.Schema{SchemaType: client.SchemaTypeProtobuf,
+		Schema: `syntax = "proto3"; message Item { string sku = 1; }`}, nil)
+	registry.On("GetSchemaByID", 6).Return(&client.Schema{ID: 6,
+		Schema:     `{"type": "record", "name": "Order", "fields": [{"name": "item", "type": "Item"}]}`,
+		References: []client.SchemaReference{{Name: "Item", Subject: "avro-item-value", Version: 1}}}, nil)
+	registry.On("GetSchema", "avro-item-value", "1").Return(&client.Schema{
+		Schema: `{"type": "record", "name": "Item", "fields": [{"name": "sku", "type": "string"}]}`}, nil)
+	decoder := NewSchemaDecoder(registry)
+
+	decoded, err := decoder.Decode([]byte{0, 0, 0, 0, 5, 0x00, 0x0a, 0x03, 0x0a, 0x01, 's'})
+	require.NoError(t, err)
+	assert.Equal(t, `{"item":{"sku":"s"}}`, string(decoded))
+
+	decoded, err = decoder.Decode([]byte{0, 0, 0, 0, 6, 0x02, 's'})
+	require.NoError(t, err)
+	assert.Equal(t, `{"item":{"sku":"s"}}`, string(decoded))
+}
+
+func TestSchemaDecoder_JSONSchema(t *testing.T) {
+	registry := &client.MockSchemaRegistry{}
+	registry.On("GetSchemaByID", 3).Return(&client.Schema{ID: 3, SchemaType: client.SchemaTypeJSONSchema, Schema: `{"type": "object"}`}, nil)
+
+	decoded, err := NewSchemaDecoder(registry).Decode(append([]byte{0, 0, 0, 0, 3}, `{"id":1}`...))
+
+	require.NoError(t, err)
+	assert.Equal(t, `{"id":1}`, string(decoded))
+}
+
+func TestSchemaDecoder_Errors(t *testing.T) {
+	registry := &client.MockSchemaRegistry{}
+	registry.On("GetSchemaByID", 4).Return(&client.Schema{}, errors.New("error"))
+	decoder := NewSchemaDecoder(registry)
+
+	_, err := decoder.Decode([]byte(`{"id":1}`))
+	assert.EqualError(t, err, "value is not in the schema registry wire format")
+
+	_, err = decoder.Decode([]byte{0, 0, 0, 0, 4, 0x02})
+	assert.EqualError(t, err, "err while fetching schema 4 - error")
+}
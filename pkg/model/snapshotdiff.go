@@ -0,0 +1,63 @@
+package model
+
+import "fmt"
+
+// DiffSnapshots compares two snapshots of a cluster, taken at different times. On top of the differences of DiffClusters, it reports
+// the changes of the replica assignment of each partition, the progress of the committed offsets of the consumer groups present in
+// both snapshots, and the changes of the dynamic broker configs.
+func DiffSnapshots(before, after *ClusterSnapshot) []Difference {
+	diffs := DiffClusters(before.State(), after.State())
+	for topic, beforeTopic := range before.Topics {
+		if afterTopic, ok := after.Topics[topic]; ok {
+			diffs = append(diffs, diffValues(DiffTypeAssignment, topic, assignmentValues(beforeTopic.ReplicaAssignment),
+				assignmentValues(afterTopic.ReplicaAssignment), diffMissing)...)
+		}
+	}
+	for group, beforeOffsets := range before.ConsumerGroupOffsets {
+		if afterOffsets, ok := after.ConsumerGroupOffsets[group]; ok {
+			diffs = append(diffs, diffValues(DiffTypeConsumerGroupOffset, group, offsetValues(beforeOffsets), offsetValues(afterOffsets),
+				diffMissing)...)
+		}
+	}
+	for _, broker := range brokerIDs(before.BrokerConfigs, after.BrokerConfigs) {
+		diffs = append(diffs, diffValues(DiffTypeBrokerConfig, brokerName(broker), before.BrokerConfigs[broker], after.BrokerConfigs[broker],
+			diffDefault)...)
+	}
+	sortDifferences(diffs)
+	return diffs
+}
+
+// assignmentValues returns the replicas per partition, the partitions are the fields of the differences
+func assignmentValues(assignment map[int32][]int32) map[string]string {
+	values := make(map[string]string, len(assignment))
+	for partition, replicas := range assignment {
+		values[fmt.Sprint(partition)] = fmt.Sprint(replicas)
+	}
+	return values
+}
+
+// offsetValues returns the offsets per topic partition, named as topic-partition
+func offsetValues(offsets map[string]map[int32]int64) map[string]string {
+	values := make(map[string]string)
+	for topic, partitions := range offsets {
+		for partition, offset := range partitions {
+			values[fmt.Sprintf("%v-%d", topic, partition)] = fmt.Sprint(offset)
+		}
+	}
+	return values
+}
+
+func brokerIDs(before, after map[string]map[string]string) []string {
+	ids := make(map[string]bool, len(before)+len(after))
+	for id := range before {
+		ids[id] = true
+	}
+	for id := range after {
+		ids[id] = true
+	}
+	result := make([]string, 0, len(ids))
+	for id := range ids {
+		result = append(result, id)
+	}
+	return result
+}
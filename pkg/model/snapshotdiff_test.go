@@ -0,0 +1,53 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffSnapshots(t *testing.T) {
+	before := &ClusterSnapshot{
+		Topics: map[string]SnapshotTopic{
+			"orders": {NumPartitions: 2, ReplicationFactor: 2, ReplicaAssignment: map[int32][]int32{0: {1, 2}, 1: {2, 1}},
+				Configs: map[string]string{"retention.ms": "1000"}},
+			"removed": {NumPartitions: 1, ReplicationFactor: 1, ReplicaAssignment: map[int32][]int32{0: {1}}},
+		},
+		ACLs:                 []client.ACL{ordersACL},
+		ConsumerGroupOffsets: map[string]map[string]map[int32]int64{"group-1": {"orders": {0: 5, 1: 7}}, "gone": {}},
+		BrokerConfigs:        map[string]map[string]string{"": {"log.retention.ms": "1000"}},
+	}
+	after := &ClusterSnapshot{
+		Topics: map[string]SnapshotTopic{
+			"orders": {NumPartitions: 3, ReplicationFactor: 2, ReplicaAssignment: map[int32][]int32{0: {1, 2}, 1: {3, 1}, 2: {1, 3}},
+				Configs: map[string]string{"retention.ms": "2000"}},
+			"added": {NumPartitions: 1, ReplicationFactor: 1, ReplicaAssignment: map[int32][]int32{0: {1}}},
+		},
+		ACLs:                 []client.ACL{ordersACL},
+		ConsumerGroupOffsets: map[string]map[string]map[int32]int64{"group-1": {"orders": {0: 9, 1: 7, 2: 1}}},
+		BrokerConfigs:        map[string]map[string]string{"1": {"log.cleaner.threads": "2"}},
+	}
+
+	diffs := DiffSnapshots(before, after)
+
+	assert.Equal(t, []Difference{
+		{Type: DiffTypeTopic, Resource: "added", Source: "missing", Destination: "present"},
+		{Type: DiffTypeTopic, Resource: "removed", Source: "present", Destination: "missing"},
+		{Type: DiffTypePartitions, Resource: "orders", Source: "2", Destination: "3"},
+		{Type: DiffTypeAssignment, Resource: "orders", Field: "1", Source: "[2 1]", Destination: "[3 1]"},
+		{Type: DiffTypeAssignment, Resource: "orders", Field: "2", Source: "missing", Destination: "[1 3]"},
+		{Type: DiffTypeConfig, Resource: "orders", Field: "retention.ms", Source: "1000", Destination: "2000"},
+		{Type: DiffTypeConsumerGroup, Resource: "gone", Source: "present", Destination: "missing"},
+		{Type: DiffTypeConsumerGroupOffset, Resource: "group-1", Field: "orders-0", Source: "5", Destination: "9"},
+		{Type: DiffTypeConsumerGroupOffset, Resource: "group-1", Field: "orders-2", Source: "missing", Destination: "1"},
+		{Type: DiffTypeBrokerConfig, Resource: "1", Field: "log.cleaner.threads", Source: "<default>", Destination: "2"},
+		{Type: DiffTypeBrokerConfig, Resource: "<cluster default>", Field: "log.retention.ms", Source: "1000", Destination: "<default>"},
+	}, diffs)
+}
+
+func TestDiffSnapshots_NoDifferences(t *testing.T) {
+	snapshot := &ClusterSnapshot{Topics: map[string]SnapshotTopic{"orders": {NumPartitions: 1, ReplicationFactor: 1}}}
+
+	assert.Empty(t, DiffSnapshots(snapshot, snapshot))
+}
@@ -0,0 +1,73 @@
+package model
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+)
+
+// StaleTopicFinder finds the topics not written to since a time with the broker api, without access to the brokers.
+// A topic is stale when the last messages of all its partitions are older than the time, and no consumer group
+// has members assigned to its partitions or offsets committed for them.
+type StaleTopicFinder struct {
+	apiClient client.KafkaAPIClient
+	offsets   *OffsetsReader
+	groups    client.SubscriptionLister
+}
+
+func NewStaleTopicFinder(apiClient client.KafkaAPIClient, reader client.MessageReader, groups client.SubscriptionLister) *StaleTopicFinder {
+	offsets := &OffsetsReader{reader: reader, concurrency: defaultOffsetsConcurrency, lastOnly: true}
+	return &StaleTopicFinder{apiClient: apiClient, offsets: offsets, groups: groups}
+}
+
+// ListTopics returns the stale topics as of the last written time of the request, the data directory is not used.
+// The topics without retained messages are stale, and the topics with a partition whose messages have no
+// timestamps are not. Only the last timestamps of the partitions are read.
+func (s *StaleTopicFinder) ListTopics(request client.ListTopicsRequest) ([]string, error) {
+	topicDetails, err := s.apiClient.ListTopicDetails()
+	if err != nil {
+		return nil, err
+	}
+	topicGroups, err := consumerGroups(s.groups)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []string
+	for topic := range topicDetails {
+		if groups := topicGroups[topic]; len(groups) > 0 {
+			logger.Debugf("Topic %v is not stale as it is consumed by the groups %v\n", topic, groups)
+			continue
+		}
+		candidates = append(candidates, topic)
+	}
+	offsets, err := s.offsets.Offsets(context.Background(), candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	lastWritten := time.Unix(request.LastWritten, 0)
+	var staleTopics []string
+	for _, topicOffsets := range offsets {
+		if isStale(topicOffsets, lastWritten) {
+			staleTopics = append(staleTopics, topicOffsets.Topic)
+		}
+	}
+	sort.Strings(staleTopics)
+	return staleTopics, nil
+}
+
+func isStale(topicOffsets TopicOffsets, lastWritten time.Time) bool {
+	for _, partition := range topicOffsets.Partitions {
+		if partition.Messages() <= 0 {
+			continue
+		}
+		if partition.LastTimestamp.IsZero() || !partition.LastTimestamp.Before(lastWritten) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,71 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaleTopicFinder_ListTopics(t *testing.T) {
+	apiClient := &client.MockKafkaAPIClient{}
+	reader := &client.MockMessageReader{}
+	groups := &client.MockSubscriptionLister{}
+	lastWritten := time.Date(2021, 3, 4, 0, 0, 0, 0, time.Local)
+	apiClient.On("ListTopicDetails").Return(map[string]client.TopicDetail{
+		"stale": {}, "written": {}, "consumed": {}, "committed": {}, "empty": {}, "unreadable": {},
+	}, nil)
+	groups.On("ListSubscribedTopics").Return(map[string][]string{"consumed": {"group-1"}}, nil)
+	groups.On("ListCommittedTopics").Return(map[string][]string{"committed": {"group-2"}}, nil)
+	for _, topic := range []string{"stale", "written", "empty", "unreadable"} {
+		reader.On("Partitions", topic).Return([]int32{0}, nil)
+	}
+	setupTopicOffsets(reader, "stale", 0, 0, 2)
+	setupLastTimestamp(reader, "stale", 0, lastWritten.Add(-time.Hour))
+	setupTopicOffsets(reader, "written", 0, 0, 2)
+	setupLastTimestamp(reader, "written", 0, lastWritten.Add(time.Hour))
+	setupTopicOffsets(reader, "empty", 0, 5, 5)
+	setupTopicOffsets(reader, "unreadable", 0, 0, 2)
+	setupLastTimestamp(reader, "unreadable", 0, time.Time{})
+	finder := NewStaleTopicFinder(apiClient, reader, groups)
+
+	topics, err := finder.ListTopics(client.ListTopicsRequest{LastWritten: lastWritten.Unix()})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"empty", "stale"}, topics)
+	reader.AssertNotCalled(t, "Partitions", "consumed")
+	reader.AssertNotCalled(t, "Partitions", "committed")
+	reader.AssertNotCalled(t, "ReadMessages", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestStaleTopicFinder_ListTopicsFailure(t *testing.T) {
+	apiClient := &client.MockKafkaAPIClient{}
+	groups := &client.MockSubscriptionLister{}
+	apiClient.On("ListTopicDetails").Return(map[string]client.TopicDetail{"topic": {}}, nil)
+	groups.On("ListSubscribedTopics").Return(map[string][]string{}, errors.New("error"))
+	finder := NewStaleTopicFinder(apiClient, &client.MockMessageReader{}, groups)
+
+	_, err := finder.ListTopics(client.ListTopicsRequest{})
+
+	assert.EqualError(t, err, "err while describing consumer groups - error")
+}
+
+func TestTopic_ListLastWrittenTopicsWithBrokerAPI(t *testing.T) {
+	apiClient := &client.MockKafkaAPIClient{}
+	groups := &client.MockSubscriptionLister{}
+	apiClient.On("ListTopicDetails").Return(map[string]client.TopicDetail{"consumed": {}}, nil)
+	groups.On("ListSubscribedTopics").Return(map[string][]string{"consumed": {"group-1"}}, nil)
+	groups.On("ListCommittedTopics").Return(map[string][]string{}, nil)
+	topicCli, err := NewTopic(apiClient, WithBrokerAPIStaleTopics(&client.MockMessageReader{}, groups))
+	require.NoError(t, err)
+
+	topics, err := topicCli.ListLastWrittenTopics(time.Now().Unix(), "/var/log/kafka")
+
+	require.NoError(t, err)
+	assert.Empty(t, topics)
+	groups.AssertExpectations(t)
+}
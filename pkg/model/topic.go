@@ -1,13 +1,21 @@
 package model
 
 import (
+	"sync"
+
 	"github.com/gojek/kat/logger"
 	"github.com/gojek/kat/pkg/client"
 )
 
+const (
+	describeConfigsBatchSize   = 100
+	describeConfigsConcurrency = 5
+)
+
 type Topic struct {
 	apiClient client.KafkaAPIClient
-	sshClient client.KafkaSSHClient
+	// staleTopicLister lists the topics not written to since a time, by sshing into the brokers or with the broker api
+	staleTopicLister client.StaleTopicLister
 }
 
 func NewTopic(apiClient client.KafkaAPIClient, opts ...TopicOpts) (*Topic, error) {
@@ -36,7 +44,16 @@ func WithSSHClient(user, port, keyfile string) TopicOpts {
 			logger.Errorf("Error while creating kafka remote client - %v\n", err)
 			return err
 		}
-		t.sshClient = kafkaSSHClient
+		t.staleTopicLister = kafkaSSHClient
+		return nil
+	}
+}
+
+// WithBrokerAPIStaleTopics finds the stale topics with the broker api, from the timestamps of the last messages
+// and the consumer group assignments and committed offsets, instead of sshing into the brokers
+func WithBrokerAPIStaleTopics(reader client.MessageReader, groups client.SubscriptionLister) TopicOpts {
+	return func(t *Topic) error {
+		t.staleTopicLister = NewStaleTopicFinder(t.apiClient, reader, groups)
 		return nil
 	}
 }
@@ -54,7 +71,7 @@ func (t *Topic) List() (map[string]client.TopicDetail, error) {
 }
 
 func (t *Topic) ListLastWrittenTopics(lastWrittenEpoch int64, dataDir string) ([]string, error) {
-	return t.sshClient.ListTopics(client.ListTopicsRequest{
+	return t.staleTopicLister.ListTopics(client.ListTopicsRequest{
 		LastWritten: lastWrittenEpoch,
 		DataDir:     dataDir,
 	})
@@ -74,6 +91,16 @@ func (t *Topic) ListOnly(regex string, include bool) ([]string, error) {
 }
 
 func (t *Topic) ListTopicWithSizeLessThanOrEqualTo(size int64) ([]string, error) {
+	topicSizes, err := t.TopicSizes()
+	if err != nil {
+		return nil, err
+	}
+	emptyTopics := filterByTopicSize(topicSizes, size)
+	return emptyTopics, nil
+}
+
+// TopicSizes returns the size of the logs of all the replicas of each topic on the brokers
+func (t *Topic) TopicSizes() (map[string]int64, error) {
 	brokerMap := t.apiClient.ListBrokers()
 	brokerIDs := make([]int32, 0, len(brokerMap))
 	for brokerID := range brokerMap {
@@ -87,22 +114,87 @@ func (t *Topic) ListTopicWithSizeLessThanOrEqualTo(size int64) ([]string, error)
 	if err != nil {
 		return nil, err
 	}
-	emptyTopics := filterByTopicSize(topicWiseMap, size)
-	return emptyTopics, nil
+	topicSizes := make(map[string]int64, len(topicWiseMap))
+	for topic, partitionMetaDataSlice := range topicWiseMap {
+		for _, partitionMetaData := range partitionMetaDataSlice {
+			topicSizes[topic] += partitionMetaData.Size
+		}
+	}
+	return topicSizes, nil
 }
 
 func (t *Topic) Describe(topics []string) ([]*client.TopicMetadata, error) {
 	return t.apiClient.DescribeTopicMetadata(topics)
 }
 
+func (t *Topic) DescribeBrokers() ([]client.Broker, error) {
+	return t.apiClient.DescribeBrokers()
+}
+
 func (t *Topic) GetConfig(topic string) ([]client.ConfigEntry, error) {
 	configResource := client.ConfigResource{Name: topic, Type: t.apiClient.GetTopicResourceType()}
 	return t.apiClient.GetConfig(configResource)
 }
 
+// GetConfigs describes the configs of the topics in batches, with a bounded number of requests in flight
+func (t *Topic) GetConfigs(topics []string) (map[string][]client.ConfigEntry, error) {
+	var batches [][]client.ConfigResource
+	for i := 0; i < len(topics); i += describeConfigsBatchSize {
+		end := i + describeConfigsBatchSize
+		if end > len(topics) {
+			end = len(topics)
+		}
+		var batch []client.ConfigResource
+		for _, topic := range topics[i:end] {
+			batch = append(batch, client.ConfigResource{Name: topic, Type: t.apiClient.GetTopicResourceType()})
+		}
+		batches = append(batches, batch)
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var firstErr error
+	configs := make(map[string][]client.ConfigEntry, len(topics))
+	semaphore := make(chan struct{}, describeConfigsConcurrency)
+	for _, batch := range batches {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(batch []client.ConfigResource) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			batchConfigs, err := t.apiClient.GetConfigs(batch)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			for topic, entries := range batchConfigs {
+				configs[topic] = entries
+			}
+		}(batch)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return configs, nil
+}
+
 func (t *Topic) UpdateConfig(topics []string, configMap map[string]*string, validateOnly bool) error {
+	entries := make(map[string]client.IncrementalConfigEntry, len(configMap))
+	for key, value := range configMap {
+		entries[key] = client.IncrementalConfigEntry{Operation: client.ConfigOperationSet, Value: value}
+	}
+	return t.IncrementalUpdateConfig(topics, entries, validateOnly)
+}
+
+func (t *Topic) IncrementalUpdateConfig(topics []string, entries map[string]client.IncrementalConfigEntry, validateOnly bool) error {
 	for _, topicName := range topics {
-		err := t.apiClient.UpdateConfig(t.apiClient.GetTopicResourceType(), topicName, configMap, validateOnly)
+		err := t.apiClient.UpdateConfig(t.apiClient.GetTopicResourceType(), topicName, entries, validateOnly)
 		if err != nil {
 			logger.Errorf("Err while updating config for topic - %v: %v\n", topicName, err)
 			return err
@@ -116,13 +208,9 @@ func (t *Topic) Delete(topics []string) error {
 	return t.apiClient.DeleteTopic(topics)
 }
 
-func filterByTopicSize(topicWiseMap map[string][]client.DescribeLogDirsResponsePartition, size int64) []string {
+func filterByTopicSize(topicSizes map[string]int64, size int64) []string {
 	sizeFilteredTopics := make([]string, 0)
-	for topic, partitionMetaDataSlice := range topicWiseMap {
-		total := int64(0)
-		for _, partitionMetaData := range partitionMetaDataSlice {
-			total += partitionMetaData.Size
-		}
+	for topic, total := range topicSizes {
 		if size >= total {
 			sizeFilteredTopics = append(sizeFilteredTopics, topic)
 		}
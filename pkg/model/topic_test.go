@@ -2,6 +2,7 @@ package model
 
 import (
 	"errors"
+	"fmt"
 	"strconv"
 	"strings"
 	"testing"
@@ -16,7 +17,7 @@ import (
 
 func withMockSSHClient(m *client.MockSSHClient) TopicOpts {
 	return func(t *Topic) error {
-		t.sshClient = m
+		t.staleTopicLister = m
 		return nil
 	}
 }
@@ -92,10 +93,14 @@ func TestTopic_UpdateConfigSuccess(t *testing.T) {
 	topicCli, err := NewTopic(kafkaClient)
 
 	topics := []string{"topic1"}
-	entries := map[string]*string{}
+	value := "val1"
+	entries := map[string]*string{"key1": &value}
+	expectedEntries := map[string]client.IncrementalConfigEntry{
+		"key1": {Operation: client.ConfigOperationSet, Value: &value},
+	}
 	validateOnly := false
 	kafkaClient.On("GetTopicResourceType").Return(int(sarama.TopicResource))
-	kafkaClient.On("UpdateConfig", int(sarama.TopicResource), topics[0], entries, validateOnly).Return(nil)
+	kafkaClient.On("UpdateConfig", int(sarama.TopicResource), topics[0], expectedEntries, validateOnly).Return(nil)
 
 	err = topicCli.UpdateConfig(topics, entries, validateOnly)
 	assert.NoError(t, err)
@@ -111,7 +116,7 @@ func TestTopic_UpdateConfigFailure(t *testing.T) {
 	entries := map[string]*string{}
 	validateOnly := false
 	kafkaClient.On("GetTopicResourceType").Return(int(sarama.TopicResource))
-	kafkaClient.On("UpdateConfig", int(sarama.TopicResource), topics[0], entries, validateOnly).Return(expectedErr)
+	kafkaClient.On("UpdateConfig", int(sarama.TopicResource), topics[0], map[string]client.IncrementalConfigEntry{}, validateOnly).Return(expectedErr)
 
 	err = topicCli.UpdateConfig(topics, entries, validateOnly)
 	assert.Error(t, err)
@@ -119,6 +124,26 @@ func TestTopic_UpdateConfigFailure(t *testing.T) {
 	kafkaClient.AssertExpectations(t)
 }
 
+func TestTopic_IncrementalUpdateConfigSuccess(t *testing.T) {
+	kafkaClient := &client.MockKafkaAPIClient{}
+	topicCli, err := NewTopic(kafkaClient)
+
+	topics := []string{"topic1", "topic2"}
+	value := "0:1,1:2"
+	entries := map[string]client.IncrementalConfigEntry{
+		"follower.replication.throttled.replicas": {Operation: client.ConfigOperationAppend, Value: &value},
+		"retention.ms": {Operation: client.ConfigOperationDelete},
+	}
+	validateOnly := false
+	kafkaClient.On("GetTopicResourceType").Return(int(sarama.TopicResource))
+	kafkaClient.On("UpdateConfig", int(sarama.TopicResource), topics[0], entries, validateOnly).Return(nil)
+	kafkaClient.On("UpdateConfig", int(sarama.TopicResource), topics[1], entries, validateOnly).Return(nil)
+
+	err = topicCli.IncrementalUpdateConfig(topics, entries, validateOnly)
+	assert.NoError(t, err)
+	kafkaClient.AssertExpectations(t)
+}
+
 func TestTopic_ShowConfigSuccess(t *testing.T) {
 	kafkaClient := &client.MockKafkaAPIClient{}
 	topicCli, err := NewTopic(kafkaClient)
@@ -172,6 +197,55 @@ func TestTopic_ShowConfigFailure(t *testing.T) {
 	kafkaClient.AssertExpectations(t)
 }
 
+func TestTopic_GetConfigsInBatches(t *testing.T) {
+	kafkaClient := &client.MockKafkaAPIClient{}
+	topicCli, err := NewTopic(kafkaClient)
+	require.NoError(t, err)
+
+	kafkaClient.On("GetTopicResourceType").Return(int(sarama.TopicResource))
+	var topics []string
+	var firstBatch, secondBatch []client.ConfigResource
+	expectedConfigs := make(map[string][]client.ConfigEntry)
+	for i := 0; i < describeConfigsBatchSize+1; i++ {
+		topic := fmt.Sprintf("topic%d", i)
+		topics = append(topics, topic)
+		resource := client.ConfigResource{Type: int(sarama.TopicResource), Name: topic}
+		if i < describeConfigsBatchSize {
+			firstBatch = append(firstBatch, resource)
+		} else {
+			secondBatch = append(secondBatch, resource)
+		}
+		expectedConfigs[topic] = []client.ConfigEntry{{Name: "retention.ms", Value: "1000"}}
+	}
+	firstBatchConfigs := make(map[string][]client.ConfigEntry)
+	for _, resource := range firstBatch {
+		firstBatchConfigs[resource.Name] = expectedConfigs[resource.Name]
+	}
+	kafkaClient.On("GetConfigs", firstBatch).Return(firstBatchConfigs, nil).Once()
+	kafkaClient.On("GetConfigs", secondBatch).Return(map[string][]client.ConfigEntry{
+		secondBatch[0].Name: expectedConfigs[secondBatch[0].Name]}, nil).Once()
+
+	configs, err := topicCli.GetConfigs(topics)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedConfigs, configs)
+	kafkaClient.AssertExpectations(t)
+}
+
+func TestTopic_GetConfigsFailure(t *testing.T) {
+	kafkaClient := &client.MockKafkaAPIClient{}
+	topicCli, err := NewTopic(kafkaClient)
+	require.NoError(t, err)
+	expectedErr := errors.New("error")
+
+	kafkaClient.On("GetTopicResourceType").Return(int(sarama.TopicResource))
+	kafkaClient.On("GetConfigs", []client.ConfigResource{{Type: int(sarama.TopicResource), Name: "topic1"}}).
+		Return(map[string][]client.ConfigEntry{}, expectedErr)
+
+	_, err = topicCli.GetConfigs([]string{"topic1"})
+	assert.Equal(t, expectedErr, err)
+	kafkaClient.AssertExpectations(t)
+}
+
 func TestTopic_DeleteSuccess(t *testing.T) {
 	kafkaClient := &client.MockKafkaAPIClient{}
 	topicCli, _ := NewTopic(kafkaClient)
@@ -0,0 +1,354 @@
+package model
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+)
+
+// TopicBackupFormatVersion is the version of the backup files written, restoring a backup of any other version fails
+const TopicBackupFormatVersion = 1
+
+const (
+	topicBackupSuffix = ".json"
+	dataBackupSuffix  = ".messages.jsonl"
+)
+
+// TopicBackup is the state of a topic saved before deleting it, to be able to recreate the topic
+type TopicBackup struct {
+	FormatVersion     int               `json:"formatVersion"`
+	Topic             string            `json:"topic"`
+	CreatedAt         time.Time         `json:"createdAt"`
+	NumPartitions     int32             `json:"numPartitions"`
+	ReplicationFactor int16             `json:"replicationFactor"`
+	ReplicaAssignment map[int32][]int32 `json:"replicaAssignment"`
+	// Configs are the configs set at the topic level
+	Configs map[string]string `json:"configs"`
+	// ACLs are the ACLs bound to the topic by its literal name
+	ACLs []client.ACL `json:"acls"`
+	// ConsumerGroupOffsets are the committed offsets per consumer group and partition
+	ConsumerGroupOffsets map[string]map[int32]int64 `json:"consumerGroupOffsets"`
+	// DataFile is the name of the file with the messages of the topic, in the backup directory. It is empty when the data is not exported.
+	DataFile string `json:"dataFile,omitempty"`
+	Messages int    `json:"messages"`
+}
+
+// backupMessage is a line of the data file, the key, the value and the headers are base64 encoded
+type backupMessage struct {
+	Partition int32          `json:"partition"`
+	Offset    int64          `json:"offset"`
+	Timestamp time.Time      `json:"timestamp"`
+	Key       []byte         `json:"key"`
+	Value     []byte         `json:"value"`
+	Headers   []backupHeader `json:"headers,omitempty"`
+}
+
+type backupHeader struct {
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+}
+
+type topicConfigLister interface {
+	GetConfigs(topics []string) (map[string][]client.ConfigEntry, error)
+}
+
+// TopicBackupWriter writes the backups of topics to a directory, one file per topic
+type TopicBackupWriter struct {
+	lister   client.Lister
+	configs  topicConfigLister
+	acls     client.ACLLister
+	groups   client.SubscriptionLister
+	consumer *TopicConsumer
+}
+
+func NewTopicBackupWriter(lister client.Lister, configs topicConfigLister, acls client.ACLLister, groups client.SubscriptionLister,
+	reader client.MessageReader) *TopicBackupWriter {
+	return &TopicBackupWriter{lister: lister, configs: configs, acls: acls, groups: groups, consumer: NewTopicConsumer(reader)}
+}
+
+// Backup writes the backups of the topics to the directory, and exports the messages of the topics when withData is set.
+// It stops at the first topic that cannot be backed up.
+func (w *TopicBackupWriter) Backup(ctx context.Context, topics []string, dir string, withData bool) ([]*TopicBackup, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("err while creating the backup directory %v - %v", dir, err)
+	}
+	backups, err := w.newBackups(topics)
+	if err != nil {
+		return nil, err
+	}
+	for _, backup := range backups {
+		if withData {
+			if err = w.exportData(ctx, backup, dir); err != nil {
+				return nil, fmt.Errorf("err while exporting the messages of topic %v - %v", backup.Topic, err)
+			}
+		}
+		if err = writeTopicBackup(backup, dir); err != nil {
+			return nil, err
+		}
+		logger.Infof("Backed up topic %v to %v\n", backup.Topic, TopicBackupFile(dir, backup.Topic))
+	}
+	return backups, nil
+}
+
+func (w *TopicBackupWriter) newBackups(topics []string) ([]*TopicBackup, error) {
+	details, err := w.lister.List()
+	if err != nil {
+		return nil, fmt.Errorf("err while listing topics - %v", err)
+	}
+	configs, err := w.configs.GetConfigs(topics)
+	if err != nil {
+		return nil, fmt.Errorf("err while fetching topic configs - %v", err)
+	}
+	acls, err := w.acls.ListACLs()
+	if client.IsSecurityDisabled(err) {
+		logger.Infof("The cluster has no authorizer, the topics are backed up without acls\n")
+		acls, err = nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("err while listing acls - %v", err)
+	}
+	offsets, err := w.groups.ListCommittedOffsets()
+	if err != nil {
+		return nil, fmt.Errorf("err while fetching consumer group offsets - %v", err)
+	}
+
+	backups := make([]*TopicBackup, 0, len(topics))
+	for _, topic := range topics {
+		detail, ok := details[topic]
+		if !ok {
+			return nil, fmt.Errorf("topic %v does not exist", topic)
+		}
+		backups = append(backups, &TopicBackup{
+			FormatVersion:        TopicBackupFormatVersion,
+			Topic:                topic,
+			CreatedAt:            time.Now().UTC(),
+			NumPartitions:        detail.NumPartitions,
+			ReplicationFactor:    detail.ReplicationFactor,
+			ReplicaAssignment:    detail.ReplicaAssignment,
+			Configs:              overriddenConfigs(topic, configs[topic]),
+			ACLs:                 topicACLs(topic, acls),
+			ConsumerGroupOffsets: offsets[topic],
+		})
+	}
+	return backups, nil
+}
+
+func overriddenConfigs(topic string, entries []client.ConfigEntry) map[string]string {
+	configs := make(map[string]string)
+	for _, entry := range entries {
+		if !entry.IsOverridden() {
+			continue
+		}
+		if entry.Sensitive {
+			logger.Warnf("Skipping sensitive config %v of topic %v, its value is not returned by the broker\n", entry.Name, topic)
+			continue
+		}
+		configs[entry.Name] = entry.Value
+	}
+	return configs
+}
+
+// topicACLs returns the ACLs bound to the literal name of the topic, the prefixed ACLs are not removed with the topic
+func topicACLs(topic string, acls []client.ACL) []client.ACL {
+	result := make([]client.ACL, 0)
+	for _, acl := range acls {
+		if acl.ResourceType == "Topic" && acl.PatternType == "Literal" && acl.ResourceName == topic {
+			result = append(result, acl)
+		}
+	}
+	return result
+}
+
+// exportData writes the messages of the topic up to the end offsets the partitions had when it started,
+// it fails when a partition stops short of its end offset so that the topic is not deleted with a partial backup
+func (w *TopicBackupWriter) exportData(ctx context.Context, backup *TopicBackup, dir string) error {
+	dataFile := backup.Topic + dataBackupSuffix
+	f, err := os.Create(filepath.Join(dir, dataFile))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	out := bufio.NewWriter(f)
+	encoder := json.NewEncoder(out)
+	count, err := w.consumer.Consume(ctx, ConsumeOptions{Topic: backup.Topic, From: OffsetSpecOldest},
+		func(msg *client.Message) error {
+			return encoder.Encode(toBackupMessage(msg))
+		})
+	if errors.Is(err, client.ErrReadIdle) {
+		return fmt.Errorf("a partition was not exported up to the end offset it had when the export started - %w", err)
+	}
+	if err != nil {
+		return err
+	}
+	if ctx.Err() != nil {
+		return errors.New("the export was interrupted")
+	}
+	if err = out.Flush(); err != nil {
+		return err
+	}
+	backup.DataFile = dataFile
+	backup.Messages = count
+	return f.Close()
+}
+
+func toBackupMessage(msg *client.Message) backupMessage {
+	backupMsg := backupMessage{Partition: msg.Partition, Offset: msg.Offset, Timestamp: msg.Timestamp, Key: msg.Key, Value: msg.Value}
+	for _, header := range msg.Headers {
+		backupMsg.Headers = append(backupMsg.Headers, backupHeader{Key: header.Key, Value: header.Value})
+	}
+	return backupMsg
+}
+
+func writeTopicBackup(backup *TopicBackup, dir string) error {
+	data, err := json.MarshalIndent(backup, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = ioutil.WriteFile(TopicBackupFile(dir, backup.Topic), data, 0644); err != nil {
+		return fmt.Errorf("err while writing the backup of topic %v - %v", backup.Topic, err)
+	}
+	return nil
+}
+
+// TopicBackupFile returns the name of the backup file of the topic in the directory
+func TopicBackupFile(dir, topic string) string {
+	return filepath.Join(dir, topic+topicBackupSuffix)
+}
+
+// ListTopicBackups returns the topics backed up in the directory, sorted by name
+func ListTopicBackups(dir string) ([]string, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var topics []string
+	for _, file := range files {
+		if !file.IsDir() && strings.HasSuffix(file.Name(), topicBackupSuffix) {
+			topics = append(topics, strings.TrimSuffix(file.Name(), topicBackupSuffix))
+		}
+	}
+	sort.Strings(topics)
+	return topics, nil
+}
+
+// LoadTopicBackup reads the backup of the topic from the directory
+func LoadTopicBackup(dir, topic string) (*TopicBackup, error) {
+	data, err := ioutil.ReadFile(TopicBackupFile(dir, topic))
+	if err != nil {
+		return nil, err
+	}
+	backup := &TopicBackup{}
+	if err = json.Unmarshal(data, backup); err != nil {
+		return nil, fmt.Errorf("err while parsing the backup of topic %v - %v", topic, err)
+	}
+	if backup.FormatVersion != TopicBackupFormatVersion {
+		return nil, fmt.Errorf("unsupported format version %d of the backup of topic %v, expected %d", backup.FormatVersion, topic,
+			TopicBackupFormatVersion)
+	}
+	return backup, nil
+}
+
+type RestoreOptions struct {
+	// IgnoreAssignment lets the cluster place the replicas, instead of using the replica assignment of the backup
+	IgnoreAssignment bool
+	// SkipData does not produce the exported messages
+	SkipData bool
+}
+
+// TopicRestorer recreates topics from their backups
+type TopicRestorer struct {
+	creator  client.Creator
+	acls     client.ACLCreator
+	producer *TopicProducer
+}
+
+func NewTopicRestorer(creator client.Creator, acls client.ACLCreator, writer client.MessageWriter) *TopicRestorer {
+	return &TopicRestorer{creator: creator, acls: acls, producer: NewTopicProducer(writer)}
+}
+
+// Restore creates the topic with its configs and ACLs, and produces the exported messages to their partitions with their timestamps.
+// It returns the number of messages produced. The consumer group offsets are not restored, as the offsets of the recreated topic
+// start from 0.
+func (r *TopicRestorer) Restore(ctx context.Context, backup *TopicBackup, dir string, opts RestoreOptions) (int, error) {
+	if err := r.creator.Create(backup.Topic, backup.topicDetail(opts.IgnoreAssignment), false); err != nil {
+		return 0, fmt.Errorf("err while creating topic %v - %v", backup.Topic, err)
+	}
+	if err := r.acls.CreateACLs(backup.ACLs); err != nil {
+		return 0, fmt.Errorf("err while creating the acls of topic %v - %v", backup.Topic, err)
+	}
+	if backup.DataFile == "" || opts.SkipData {
+		return 0, nil
+	}
+	return r.restoreData(ctx, backup, dir)
+}
+
+func (b *TopicBackup) topicDetail(ignoreAssignment bool) client.TopicDetail {
+	detail := client.TopicDetail{NumPartitions: b.NumPartitions, ReplicationFactor: b.ReplicationFactor, Config: map[string]*string{}}
+	for name, value := range b.Configs {
+		value := value
+		detail.Config[name] = &value
+	}
+	if !ignoreAssignment {
+		detail.ReplicaAssignment = b.ReplicaAssignment
+	}
+	return detail
+}
+
+func (r *TopicRestorer) restoreData(ctx context.Context, backup *TopicBackup, dir string) (int, error) {
+	f, err := os.Open(filepath.Join(dir, backup.DataFile))
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = f.Close() }()
+
+	summary, err := r.producer.Produce(ctx, &backupMessageSource{reader: bufio.NewReader(f)}, ProduceOptions{Topic: backup.Topic, Partition: -1})
+	if err != nil {
+		return summary.Produced, fmt.Errorf("err while reading the messages of topic %v - %v", backup.Topic, err)
+	}
+	if summary.Failed > 0 {
+		return summary.Produced, fmt.Errorf("%d messages of topic %v were not produced", summary.Failed, backup.Topic)
+	}
+	if summary.Produced < backup.Messages {
+		return summary.Produced, fmt.Errorf("%d of the %d messages of topic %v were produced", summary.Produced, backup.Messages, backup.Topic)
+	}
+	return summary.Produced, nil
+}
+
+// backupMessageSource reads the messages of a data file. The lines are not limited in length, unlike with a scanner.
+type backupMessageSource struct {
+	reader *bufio.Reader
+	line   int
+}
+
+func (s *backupMessageSource) Next() (*client.Message, error) {
+	data, err := s.reader.ReadBytes('\n')
+	if err == io.EOF && len(strings.TrimSpace(string(data))) == 0 {
+		return nil, io.EOF
+	}
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	s.line++
+	var backupMsg backupMessage
+	if err = json.Unmarshal(data, &backupMsg); err != nil {
+		return nil, fmt.Errorf("invalid message on line %d - %v", s.line, err)
+	}
+	msg := &client.Message{Partition: backupMsg.Partition, Timestamp: backupMsg.Timestamp, Key: backupMsg.Key, Value: backupMsg.Value}
+	for _, header := range backupMsg.Headers {
+		msg.Headers = append(msg.Headers, client.MessageHeader{Key: header.Key, Value: header.Value})
+	}
+	return msg, nil
+}
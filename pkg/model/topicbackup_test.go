@@ -0,0 +1,252 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var ordersACL = client.ACL{ResourceType: "Topic", ResourceName: "orders", PatternType: "Literal", Principal: "User:alice", Host: "*",
+	Operation: "Read", PermissionType: "Allow"}
+
+func setupBackupSources(withData bool) (*client.MockLister, *client.MockConfigurer, *client.MockACLLister, *client.MockSubscriptionLister,
+	*client.MockMessageReader) {
+	lister := &client.MockLister{}
+	lister.On("List").Return(map[string]client.TopicDetail{
+		"orders": {NumPartitions: 2, ReplicationFactor: 2, ReplicaAssignment: map[int32][]int32{0: {1, 2}, 1: {2, 3}}},
+		"other":  {NumPartitions: 1, ReplicationFactor: 1},
+	}, nil)
+	configurer := &client.MockConfigurer{}
+	configurer.On("GetConfigs", []string{"orders"}).Return(map[string][]client.ConfigEntry{"orders": {
+		{Name: "retention.ms", Value: "1000", Source: client.ConfigSourceTopic},
+		{Name: "cleanup.policy", Value: "delete", Source: client.ConfigSourceDefault, Default: true},
+	}}, nil)
+	aclLister := &client.MockACLLister{}
+	aclLister.On("ListACLs").Return([]client.ACL{
+		ordersACL,
+		{ResourceType: "Topic", ResourceName: "other", PatternType: "Literal", Principal: "User:bob", Host: "*", Operation: "Read", PermissionType: "Allow"},
+		{ResourceType: "Topic", ResourceName: "orders", PatternType: "Prefixed", Principal: "User:bob", Host: "*", Operation: "Read", PermissionType: "Allow"},
+	}, nil)
+	groups := &client.MockSubscriptionLister{}
+	groups.On("ListCommittedOffsets").Return(map[string]map[string]map[int32]int64{
+		"orders": {"group-1": {0: 1, 1: 1}},
+		"other":  {"group-2": {0: 5}},
+	}, nil)
+	reader := &client.MockMessageReader{}
+	if withData {
+		timestamp := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		reader.On("Partitions", "orders").Return([]int32{0, 1}, nil)
+		setupTopicOffsets(reader, "orders", 0, 0, 1)
+		setupTopicOffsets(reader, "orders", 1, 0, 1)
+		reader.On("ReadMessages", "orders", int32(0), int64(0), int64(1)).Return([]*client.Message{
+			{Topic: "orders", Partition: 0, Offset: 0, Key: []byte("key"), Value: []byte{0, 1, 2}, Timestamp: timestamp,
+				Headers: []client.MessageHeader{{Key: []byte("trace"), Value: []byte("1")}}},
+		}, nil)
+		reader.On("ReadMessages", "orders", int32(1), int64(0), int64(1)).Return([]*client.Message{
+			{Topic: "orders", Partition: 1, Offset: 0, Key: []byte("deleted"), Timestamp: timestamp},
+		}, nil)
+	}
+	return lister, configurer, aclLister, groups, reader
+}
+
+func TestTopicBackupWriter_Backup(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backup")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	lister, configurer, aclLister, groups, reader := setupBackupSources(false)
+	writer := NewTopicBackupWriter(lister, configurer, aclLister, groups, reader)
+
+	backups, err := writer.Backup(context.Background(), []string{"orders"}, dir, false)
+
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+	backup, err := LoadTopicBackup(dir, "orders")
+	require.NoError(t, err)
+	assert.Equal(t, TopicBackupFormatVersion, backup.FormatVersion)
+	assert.Equal(t, "orders", backup.Topic)
+	assert.Equal(t, int32(2), backup.NumPartitions)
+	assert.Equal(t, int16(2), backup.ReplicationFactor)
+	assert.Equal(t, map[int32][]int32{0: {1, 2}, 1: {2, 3}}, backup.ReplicaAssignment)
+	assert.Equal(t, map[string]string{"retention.ms": "1000"}, backup.Configs)
+	assert.Equal(t, []client.ACL{ordersACL}, backup.ACLs)
+	assert.Equal(t, map[string]map[int32]int64{"group-1": {0: 1, 1: 1}}, backup.ConsumerGroupOffsets)
+	assert.Empty(t, backup.DataFile)
+	reader.AssertNotCalled(t, "Partitions", mock.Anything)
+}
+
+func TestTopicBackupWriter_BackupFailsForMissingTopic(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backup")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	lister, configurer, aclLister, groups, reader := setupBackupSources(false)
+	configurer.On("GetConfigs", []string{"unknown"}).Return(map[string][]client.ConfigEntry{}, nil)
+	writer := NewTopicBackupWriter(lister, configurer, aclLister, groups, reader)
+
+	_, err = writer.Backup(context.Background(), []string{"unknown"}, dir, false)
+
+	assert.EqualError(t, err, "topic unknown does not exist")
+	topics, err := ListTopicBackups(dir)
+	require.NoError(t, err)
+	assert.Empty(t, topics)
+}
+
+func TestTopicBackupWriter_BackupFailsIfACLsCannotBeListed(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backup")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	lister, configurer, _, groups, reader := setupBackupSources(false)
+	aclLister := &client.MockACLLister{}
+	aclLister.On("ListACLs").Return([]client.ACL{}, errors.New("not authorized"))
+	writer := NewTopicBackupWriter(lister, configurer, aclLister, groups, reader)
+
+	_, err = writer.Backup(context.Background(), []string{"orders"}, dir, false)
+
+	assert.EqualError(t, err, "err while listing acls - not authorized")
+}
+
+func TestTopicBackupWriter_BackupWithoutAuthorizer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backup")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	lister, configurer, _, groups, reader := setupBackupSources(false)
+	aclLister := &client.MockACLLister{}
+	aclLister.On("ListACLs").Return([]client.ACL{}, sarama.ErrSecurityDisabled)
+	writer := NewTopicBackupWriter(lister, configurer, aclLister, groups, reader)
+
+	_, err = writer.Backup(context.Background(), []string{"orders"}, dir, false)
+
+	require.NoError(t, err)
+	backup, err := LoadTopicBackup(dir, "orders")
+	require.NoError(t, err)
+	assert.Equal(t, []client.ACL{}, backup.ACLs)
+}
+
+func TestTopicBackupWriter_BackupWithDataAndRestore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backup")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	lister, configurer, aclLister, groups, reader := setupBackupSources(true)
+	writer := NewTopicBackupWriter(lister, configurer, aclLister, groups, reader)
+
+	_, err = writer.Backup(context.Background(), []string{"orders"}, dir, true)
+	require.NoError(t, err)
+	backup, err := LoadTopicBackup(dir, "orders")
+	require.NoError(t, err)
+	assert.Equal(t, "orders.messages.jsonl", backup.DataFile)
+	assert.Equal(t, 2, backup.Messages)
+
+	retention := "1000"
+	timestamp := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	creator := &client.MockCreator{}
+	creator.On("Create", "orders", client.TopicDetail{NumPartitions: 2, ReplicationFactor: 2,
+		ReplicaAssignment: map[int32][]int32{0: {1, 2}, 1: {2, 3}}, Config: map[string]*string{"retention.ms": &retention}}, false).Return(nil)
+	aclCreator := &client.MockACLCreator{}
+	aclCreator.On("CreateACLs", []client.ACL{ordersACL}).Return(nil)
+	messageWriter := &client.MockMessageWriter{}
+	messageWriter.On("WriteMessages", mock.MatchedBy(func(messages []*client.Message) bool {
+		return len(messages) == 2
+	})).Return(nil)
+	restorer := NewTopicRestorer(creator, aclCreator, messageWriter)
+
+	restored, err := restorer.Restore(context.Background(), backup, dir, RestoreOptions{})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, restored)
+	messages := messageWriter.Calls[0].Arguments.Get(0).([]*client.Message)
+	assert.ElementsMatch(t, []*client.Message{
+		{Topic: "orders", Partition: 0, Key: []byte("key"), Value: []byte{0, 1, 2}, Timestamp: timestamp,
+			Headers: []client.MessageHeader{{Key: []byte("trace"), Value: []byte("1")}}},
+		{Topic: "orders", Partition: 1, Key: []byte("deleted"), Timestamp: timestamp},
+	}, messages)
+	creator.AssertExpectations(t)
+	aclCreator.AssertExpectations(t)
+}
+
+func TestTopicBackupWriter_BackupFailsWhenAPartitionIsNotExportedToTheEnd(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backup")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	lister, configurer, aclLister, groups, _ := setupBackupSources(false)
+	reader := &client.MockMessageReader{}
+	reader.On("Partitions", "orders").Return([]int32{0}, nil)
+	setupTopicOffsets(reader, "orders", 0, 0, 2)
+	reader.On("ReadMessages", "orders", int32(0), int64(0), int64(2)).Return([]*client.Message{
+		{Topic: "orders", Partition: 0, Offset: 0},
+	}, fmt.Errorf("stopped at offset 1 of 2 - %w", client.ErrReadIdle))
+	writer := NewTopicBackupWriter(lister, configurer, aclLister, groups, reader)
+
+	_, err = writer.Backup(context.Background(), []string{"orders"}, dir, true)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not exported up to the end offset")
+	_, err = os.Stat(TopicBackupFile(dir, "orders"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestTopicRestorer_RestoreIgnoringAssignmentAndData(t *testing.T) {
+	backup := &TopicBackup{FormatVersion: TopicBackupFormatVersion, Topic: "orders", NumPartitions: 2, ReplicationFactor: 2,
+		ReplicaAssignment: map[int32][]int32{0: {1, 2}, 1: {2, 3}}, DataFile: "orders.messages.jsonl", Messages: 2}
+	creator := &client.MockCreator{}
+	creator.On("Create", "orders", client.TopicDetail{NumPartitions: 2, ReplicationFactor: 2, Config: map[string]*string{}}, false).Return(nil)
+	aclCreator := &client.MockACLCreator{}
+	aclCreator.On("CreateACLs", []client.ACL(nil)).Return(nil)
+	messageWriter := &client.MockMessageWriter{}
+	restorer := NewTopicRestorer(creator, aclCreator, messageWriter)
+
+	restored, err := restorer.Restore(context.Background(), backup, "missing", RestoreOptions{IgnoreAssignment: true, SkipData: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, restored)
+	creator.AssertExpectations(t)
+	messageWriter.AssertNotCalled(t, "WriteMessages", mock.Anything)
+}
+
+func TestTopicRestorer_RestoreFailsIfTopicCannotBeCreated(t *testing.T) {
+	backup := &TopicBackup{FormatVersion: TopicBackupFormatVersion, Topic: "orders", NumPartitions: 1, ReplicationFactor: 1,
+		ACLs: []client.ACL{ordersACL}}
+	creator := &client.MockCreator{}
+	creator.On("Create", "orders", mock.Anything, false).Return(errors.New("topic exists"))
+	aclCreator := &client.MockACLCreator{}
+	restorer := NewTopicRestorer(creator, aclCreator, &client.MockMessageWriter{})
+
+	_, err := restorer.Restore(context.Background(), backup, "", RestoreOptions{})
+
+	assert.EqualError(t, err, "err while creating topic orders - topic exists")
+	aclCreator.AssertNotCalled(t, "CreateACLs", mock.Anything)
+}
+
+func TestLoadTopicBackup_FailsForUnsupportedVersion(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backup")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "orders.json"), []byte(`{"formatVersion": 2, "topic": "orders"}`), 0644))
+
+	_, err = LoadTopicBackup(dir, "orders")
+
+	assert.EqualError(t, err, "unsupported format version 2 of the backup of topic orders, expected 1")
+}
+
+func TestListTopicBackups_SkipsDataFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backup")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	for _, name := range []string{"payments.json", "orders.json", "orders.messages.jsonl", "notes.txt"} {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte("{}"), 0644))
+	}
+
+	topics, err := ListTopicBackups(dir)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"orders", "payments"}, topics)
+}
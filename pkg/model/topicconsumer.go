@@ -0,0 +1,135 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/gojek/kat/pkg/client"
+)
+
+type ConsumeOptions struct {
+	Topic string
+	// Partitions to read from, all the partitions of the topic when empty
+	Partitions []int32
+	From       OffsetSpec
+	// To is the offset or time to stop reading at, the newest offset of the partitions when nil
+	To *OffsetSpec
+	// Filter skips the messages it returns false for, it is called concurrently for the partitions
+	Filter func(*client.Message) bool
+	// MaxMessages is the number of messages after which the consumer stops, 0 for no limit
+	MaxMessages int
+	// Follow keeps reading the new messages, instead of stopping at the newest offset of the partitions
+	Follow bool
+}
+
+type TopicConsumer struct {
+	reader client.MessageReader
+}
+
+func NewTopicConsumer(reader client.MessageReader) *TopicConsumer {
+	return &TopicConsumer{reader: reader}
+}
+
+// Consume reads the partitions in parallel, and returns the number of messages passed to the handler.
+// Follow is ignored when To is given.
+// The handler is not called concurrently. Reading stops without an error when the context is cancelled.
+func (c *TopicConsumer) Consume(ctx context.Context, opts ConsumeOptions, handler func(*client.Message) error) (int, error) {
+	partitions := opts.Partitions
+	if len(partitions) == 0 {
+		var err error
+		if partitions, err = c.reader.Partitions(opts.Topic); err != nil {
+			return 0, fmt.Errorf("err while fetching partitions of topic %v - %v", opts.Topic, err)
+		}
+	}
+
+	ranges := make([]partitionRange, 0, len(partitions))
+	for _, partition := range partitions {
+		r, err := c.partitionRange(opts, partition)
+		if err != nil {
+			return 0, fmt.Errorf("err while resolving offsets of partition %d - %v", partition, err)
+		}
+		if r.end == client.OffsetNewest || r.start < r.end {
+			ranges = append(ranges, r)
+		}
+	}
+	return c.readPartitions(ctx, opts, ranges, handler)
+}
+
+func (c *TopicConsumer) partitionRange(opts ConsumeOptions, partition int32) (partitionRange, error) {
+	r := partitionRange{partition: partition, end: client.OffsetNewest}
+	var err error
+	if r.start, err = opts.From.Resolve(c.reader, opts.Topic, partition); err != nil {
+		return r, err
+	}
+	if opts.Follow && opts.To == nil {
+		return r, nil
+	}
+	if r.end, err = c.reader.GetOffset(opts.Topic, partition, client.OffsetNewest); err != nil || opts.To == nil {
+		return r, err
+	}
+	to, err := opts.To.Resolve(c.reader, opts.Topic, partition)
+	if to < r.end {
+		r.end = to
+	}
+	return r, err
+}
+
+func (c *TopicConsumer) readPartitions(ctx context.Context, opts ConsumeOptions, ranges []partitionRange,
+	handler func(*client.Message) error) (int, error) {
+	ctx, cancelFunc := context.WithCancel(ctx)
+	defer cancelFunc()
+
+	counter := &messageCounter{opts: opts, handler: handler, stop: cancelFunc}
+	var errMu sync.Mutex
+	var firstErr error
+	var wg sync.WaitGroup
+	for _, r := range ranges {
+		wg.Add(1)
+		go func(r partitionRange) {
+			defer wg.Done()
+			err := c.reader.ReadMessages(ctx, opts.Topic, r.partition, r.start, r.end, counter.handle)
+			if err != nil && !errors.Is(err, context.Canceled) {
+				errMu.Lock()
+				if firstErr == nil {
+					firstErr = fmt.Errorf("err while reading partition %d - %w", r.partition, err)
+				}
+				errMu.Unlock()
+				cancelFunc()
+			}
+		}(r)
+	}
+	wg.Wait()
+	return counter.count, firstErr
+}
+
+// messageCounter passes the messages matching the filter to the handler one at a time,
+// and stops the reading once the maximum number of messages are handled
+type messageCounter struct {
+	mu      sync.Mutex
+	count   int
+	opts    ConsumeOptions
+	handler func(*client.Message) error
+	stop    func()
+}
+
+func (m *messageCounter) handle(msg *client.Message) error {
+	if m.opts.Filter != nil && !m.opts.Filter(msg) {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.opts.MaxMessages > 0 && m.count >= m.opts.MaxMessages {
+		return client.ErrStopReading
+	}
+	if err := m.handler(msg); err != nil {
+		return err
+	}
+	m.count++
+	if m.opts.MaxMessages > 0 && m.count >= m.opts.MaxMessages {
+		m.stop()
+		return client.ErrStopReading
+	}
+	return nil
+}
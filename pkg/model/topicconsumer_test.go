@@ -0,0 +1,93 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopicConsumer_ConsumeUntilNewestOffsets(t *testing.T) {
+	reader := &client.MockMessageReader{}
+	reader.On("Partitions", "source").Return([]int32{0, 1}, nil)
+	setupOffsets(reader, 0, 0, 2)
+	setupOffsets(reader, 1, 4, 4)
+	reader.On("ReadMessages", "source", int32(0), int64(0), int64(2)).Return(copyMessages("source", 0, 0, 1), nil)
+	consumer := NewTopicConsumer(reader)
+
+	var offsets []int64
+	count, err := consumer.Consume(context.Background(), ConsumeOptions{Topic: "source", From: OffsetSpecOldest},
+		func(msg *client.Message) error {
+			offsets = append(offsets, msg.Offset)
+			return nil
+		})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, []int64{0, 1}, offsets)
+	reader.AssertExpectations(t)
+}
+
+func TestTopicConsumer_ConsumeStopsAtMaxMessages(t *testing.T) {
+	reader := &client.MockMessageReader{}
+	reader.On("GetOffset", "source", int32(1), int64(client.OffsetNewest)).Return(int64(10), nil)
+	reader.On("ReadMessages", "source", int32(1), int64(5), int64(client.OffsetNewest)).Return(copyMessages("source", 1, 5, 6, 7), nil)
+	consumer := NewTopicConsumer(reader)
+
+	count, err := consumer.Consume(context.Background(),
+		ConsumeOptions{Topic: "source", Partitions: []int32{1}, From: OffsetSpec{offset: 5}, MaxMessages: 2, Follow: true},
+		func(msg *client.Message) error { return nil })
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	reader.AssertNotCalled(t, "Partitions", mock.Anything)
+}
+
+func TestTopicConsumer_ConsumeHandlerFailure(t *testing.T) {
+	reader := &client.MockMessageReader{}
+	reader.On("Partitions", "source").Return([]int32{0}, nil)
+	setupOffsets(reader, 0, 0, 2)
+	reader.On("ReadMessages", "source", int32(0), int64(0), int64(2)).Return(copyMessages("source", 0, 0, 1), nil)
+	consumer := NewTopicConsumer(reader)
+
+	count, err := consumer.Consume(context.Background(), ConsumeOptions{Topic: "source", From: OffsetSpecOldest},
+		func(msg *client.Message) error { return errors.New("error") })
+
+	assert.EqualError(t, err, "err while reading partition 0 - error")
+	assert.Equal(t, 0, count)
+}
+
+func TestTopicConsumer_ConsumePartitionsFailure(t *testing.T) {
+	reader := &client.MockMessageReader{}
+	reader.On("Partitions", "source").Return([]int32{}, errors.New("error"))
+
+	_, err := NewTopicConsumer(reader).Consume(context.Background(), ConsumeOptions{Topic: "source", From: OffsetSpecOldest},
+		func(msg *client.Message) error { return nil })
+
+	assert.EqualError(t, err, "err while fetching partitions of topic source - error")
+}
+
+func TestTopicConsumer_ConsumeFilteredUntilTo(t *testing.T) {
+	reader := &client.MockMessageReader{}
+	reader.On("Partitions", "source").Return([]int32{0}, nil)
+	setupOffsets(reader, 0, 0, 10)
+	reader.On("ReadMessages", "source", int32(0), int64(0), int64(3)).Return(copyMessages("source", 0, 0, 1, 2), nil)
+	to := OffsetSpec{offset: 3}
+	consumer := NewTopicConsumer(reader)
+
+	var offsets []int64
+	count, err := consumer.Consume(context.Background(), ConsumeOptions{Topic: "source", From: OffsetSpecOldest, To: &to,
+		Filter: func(msg *client.Message) bool { return msg.Offset != 1 }},
+		func(msg *client.Message) error {
+			offsets = append(offsets, msg.Offset)
+			return nil
+		})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, []int64{0, 2}, offsets)
+}
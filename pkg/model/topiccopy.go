@@ -0,0 +1,330 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+	"github.com/gojek/kat/pkg/io"
+)
+
+const (
+	copyBatchSize   = 500
+	copyConcurrency = 8
+)
+
+type CopyOptions struct {
+	SourceTopic      string
+	DestinationTopic string
+	From             OffsetSpec
+	To               OffsetSpec
+	// KeepPartitions writes the messages to the same partition of the destination topic,
+	// instead of the partition chosen by the partitioner of the writer
+	KeepPartitions bool
+	// Rate is the maximum number of messages copied per second, 0 for no limit
+	Rate int
+	// CheckpointFile has the offsets copied so far, the copy is resumed from it when present
+	CheckpointFile   string
+	ProgressInterval time.Duration
+}
+
+// copyCheckpoint has the next offset to be copied, per partition
+type copyCheckpoint struct {
+	SourceTopic      string          `json:"sourceTopic"`
+	DestinationTopic string          `json:"destinationTopic"`
+	Offsets          map[int32]int64 `json:"offsets"`
+}
+
+type partitionRange struct {
+	partition int32
+	start     int64
+	end       int64
+}
+
+type copyProgress struct {
+	mu         sync.Mutex
+	copied     int64
+	total      int64
+	startTime  time.Time
+	checkpoint copyCheckpoint
+}
+
+type TopicCopy struct {
+	reader client.MessageReader
+	writer client.MessageWriter
+	file
+	batchSize int
+}
+
+func NewTopicCopy(reader client.MessageReader, writer client.MessageWriter) *TopicCopy {
+	return &TopicCopy{reader: reader, writer: writer, file: &io.File{}, batchSize: copyBatchSize}
+}
+
+// Copy copies the messages with their keys, headers and timestamps, and returns the number of messages copied.
+// The partitions are copied in parallel, and the checkpoint file is updated after every batch.
+func (c *TopicCopy) Copy(ctx context.Context, opts CopyOptions) (int64, error) {
+	ranges, err := c.partitionRanges(opts)
+	if err != nil {
+		return 0, err
+	}
+
+	progress := &copyProgress{startTime: time.Now(), checkpoint: copyCheckpoint{
+		SourceTopic:      opts.SourceTopic,
+		DestinationTopic: opts.DestinationTopic,
+		Offsets:          make(map[int32]int64, len(ranges)),
+	}}
+	for _, r := range ranges {
+		progress.checkpoint.Offsets[r.partition] = r.start
+		if r.end > r.start {
+			progress.total += r.end - r.start
+		}
+	}
+	logger.Infof("Copying %d messages from %d partitions of topic %v to topic %v\n", progress.total, len(ranges),
+		opts.SourceTopic, opts.DestinationTopic)
+
+	stopReporting := reportProgress(progress, opts.ProgressInterval)
+	defer stopReporting()
+	var limiter <-chan time.Time
+	if opts.Rate > 0 {
+		ticker := time.NewTicker(time.Second / time.Duration(opts.Rate))
+		defer ticker.Stop()
+		limiter = ticker.C
+	}
+
+	if err = c.copyPartitions(ctx, opts, ranges, limiter, progress); err != nil {
+		return progress.copied, err
+	}
+	if opts.CheckpointFile != "" {
+		if removeErr := c.Remove(opts.CheckpointFile); removeErr != nil && !os.IsNotExist(removeErr) {
+			logger.Errorf("Error while removing the checkpoint file %v - %v\n", opts.CheckpointFile, removeErr)
+		}
+	}
+	return progress.copied, nil
+}
+
+func (c *TopicCopy) partitionRanges(opts CopyOptions) ([]partitionRange, error) {
+	partitions, err := c.reader.Partitions(opts.SourceTopic)
+	if err != nil {
+		return nil, fmt.Errorf("err while fetching partitions of topic %v - %v", opts.SourceTopic, err)
+	}
+	if opts.KeepPartitions {
+		destinationPartitions, partitionsErr := c.writer.Partitions(opts.DestinationTopic)
+		if partitionsErr != nil {
+			return nil, fmt.Errorf("err while fetching partitions of topic %v - %v", opts.DestinationTopic, partitionsErr)
+		}
+		if len(destinationPartitions) < len(partitions) {
+			return nil, fmt.Errorf("destination topic %v has %d partitions, fewer than the %d partitions of source topic %v",
+				opts.DestinationTopic, len(destinationPartitions), len(partitions), opts.SourceTopic)
+		}
+	}
+	checkpoint, err := c.loadCheckpoint(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ranges := make([]partitionRange, 0, len(partitions))
+	for _, partition := range partitions {
+		r, rangeErr := c.partitionRange(opts, partition, checkpoint)
+		if rangeErr != nil {
+			return nil, fmt.Errorf("err while resolving offsets of partition %d - %v", partition, rangeErr)
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges, nil
+}
+
+func (c *TopicCopy) partitionRange(opts CopyOptions, partition int32, checkpoint map[int32]int64) (partitionRange, error) {
+	r := partitionRange{partition: partition}
+	var err error
+	if start, ok := checkpoint[partition]; ok {
+		r.start = start
+	} else if r.start, err = opts.From.Resolve(c.reader, opts.SourceTopic, partition); err != nil {
+		return r, err
+	}
+	if r.end, err = opts.To.Resolve(c.reader, opts.SourceTopic, partition); err != nil {
+		return r, err
+	}
+	newest, err := c.reader.GetOffset(opts.SourceTopic, partition, client.OffsetNewest)
+	if err != nil {
+		return r, err
+	}
+	if r.end > newest {
+		r.end = newest
+	}
+	return r, nil
+}
+
+func (c *TopicCopy) copyPartitions(ctx context.Context, opts CopyOptions, ranges []partitionRange, limiter <-chan time.Time,
+	progress *copyProgress) error {
+	ctx, cancelFunc := context.WithCancel(ctx)
+	defer cancelFunc()
+
+	var wg sync.WaitGroup
+	var errMu sync.Mutex
+	var firstErr error
+	semaphore := make(chan struct{}, copyConcurrency)
+	for _, r := range ranges {
+		if r.start >= r.end {
+			continue
+		}
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(r partitionRange) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			if err := c.copyPartition(ctx, opts, r, limiter, progress); err != nil {
+				errMu.Lock()
+				if firstErr == nil {
+					firstErr = fmt.Errorf("err while copying partition %d - %w", r.partition, err)
+				}
+				errMu.Unlock()
+				cancelFunc()
+			}
+		}(r)
+	}
+	wg.Wait()
+	return firstErr
+}
+
+func (c *TopicCopy) copyPartition(ctx context.Context, opts CopyOptions, r partitionRange, limiter <-chan time.Time,
+	progress *copyProgress) error {
+	batch := make([]*client.Message, 0, c.batchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := c.writer.WriteMessages(batch); err != nil {
+			return err
+		}
+		nextOffset := batch[len(batch)-1].Offset + 1
+		if err := c.progressed(opts, progress, r.partition, nextOffset, len(batch)); err != nil {
+			return err
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	err := c.reader.ReadMessages(ctx, opts.SourceTopic, r.partition, r.start, r.end, func(msg *client.Message) error {
+		if err := waitForRate(ctx, limiter); err != nil {
+			return err
+		}
+		batch = append(batch, destinationMessage(msg, opts))
+		if len(batch) >= c.batchSize {
+			return flush()
+		}
+		return nil
+	})
+	return endPartitionCopy(ctx, err, flush)
+}
+
+// endPartitionCopy writes the messages read before an interrupt or a slow source, so that the checkpoint is as recent
+// as possible
+func endPartitionCopy(ctx context.Context, readErr error, flush func() error) error {
+	idle := errors.Is(readErr, client.ErrReadIdle)
+	if readErr != nil && ctx.Err() == nil && !idle {
+		return readErr
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+	if idle {
+		return fmt.Errorf("%w. Run the copy again with the checkpoint file to resume it", readErr)
+	}
+	return readErr
+}
+
+// waitForRate waits for the next tick of the rate limiter, when there is one
+func waitForRate(ctx context.Context, limiter <-chan time.Time) error {
+	if limiter == nil {
+		return nil
+	}
+	select {
+	case <-limiter:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func destinationMessage(msg *client.Message, opts CopyOptions) *client.Message {
+	destination := *msg
+	destination.Topic = opts.DestinationTopic
+	if !opts.KeepPartitions {
+		destination.Partition = 0
+	}
+	return &destination
+}
+
+// progressed records the messages copied for the partition, and saves the checkpoint
+func (c *TopicCopy) progressed(opts CopyOptions, progress *copyProgress, partition int32, nextOffset int64, count int) error {
+	progress.mu.Lock()
+	defer progress.mu.Unlock()
+	progress.copied += int64(count)
+	progress.checkpoint.Offsets[partition] = nextOffset
+	if opts.CheckpointFile == "" {
+		return nil
+	}
+	data, err := json.Marshal(progress.checkpoint)
+	if err != nil {
+		return err
+	}
+	if writeErr := c.Write(opts.CheckpointFile, string(data)); writeErr != nil {
+		return fmt.Errorf("err while writing the checkpoint file %v - %v", opts.CheckpointFile, writeErr)
+	}
+	return nil
+}
+
+func (c *TopicCopy) loadCheckpoint(opts CopyOptions) (map[int32]int64, error) {
+	if opts.CheckpointFile == "" {
+		return nil, nil
+	}
+	data, err := c.Read(opts.CheckpointFile)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("err while reading the checkpoint file %v - %v", opts.CheckpointFile, err)
+	}
+
+	var checkpoint copyCheckpoint
+	if err = json.Unmarshal(data, &checkpoint); err != nil {
+		return nil, fmt.Errorf("err while parsing the checkpoint file %v - %v", opts.CheckpointFile, err)
+	}
+	if checkpoint.SourceTopic != opts.SourceTopic || checkpoint.DestinationTopic != opts.DestinationTopic {
+		return nil, fmt.Errorf("checkpoint file %v is for copying topic %v to %v", opts.CheckpointFile,
+			checkpoint.SourceTopic, checkpoint.DestinationTopic)
+	}
+	logger.Infof("Resuming the copy from the checkpoint file %v\n", opts.CheckpointFile)
+	return checkpoint.Offsets, nil
+}
+
+// reportProgress logs the progress at every interval, until the returned function is called
+func reportProgress(progress *copyProgress, interval time.Duration) func() {
+	if interval <= 0 {
+		return func() {}
+	}
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				progress.mu.Lock()
+				copied, total := progress.copied, progress.total
+				progress.mu.Unlock()
+				rate := float64(copied) / time.Since(progress.startTime).Seconds()
+				logger.Infof("Copied %d of %d messages, %.0f messages/s\n", copied, total, rate)
+			}
+		}
+	}()
+	return func() { close(done) }
+}
@@ -0,0 +1,188 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func copyMessages(topic string, partition int32, offsets ...int64) []*client.Message {
+	var messages []*client.Message
+	for _, offset := range offsets {
+		messages = append(messages, &client.Message{Topic: topic, Partition: partition, Offset: offset,
+			Key: []byte("key"), Value: []byte("value"), Headers: []client.MessageHeader{{Key: []byte("h"), Value: []byte("v")}}})
+	}
+	return messages
+}
+
+func setupOffsets(reader *client.MockMessageReader, partition int32, oldest, newest int64) {
+	reader.On("GetOffset", "source", partition, int64(client.OffsetOldest)).Return(oldest, nil)
+	reader.On("GetOffset", "source", partition, int64(client.OffsetNewest)).Return(newest, nil)
+}
+
+func TestTopicCopy_CopyKeepPartitions(t *testing.T) {
+	reader := &client.MockMessageReader{}
+	writer := &client.MockMessageWriter{}
+	topicCopy := &TopicCopy{reader: reader, writer: writer, file: &MockFile{}, batchSize: 2}
+	reader.On("Partitions", "source").Return([]int32{0, 1}, nil)
+	writer.On("Partitions", "destination").Return([]int32{0, 1}, nil)
+	setupOffsets(reader, 0, 0, 3)
+	setupOffsets(reader, 1, 5, 5)
+	reader.On("ReadMessages", "source", int32(0), int64(0), int64(3)).Return(copyMessages("source", 0, 0, 1, 2), nil)
+	writer.On("WriteMessages", copyMessages("destination", 0, 0, 1)).Return(nil)
+	writer.On("WriteMessages", copyMessages("destination", 0, 2)).Return(nil)
+
+	copied, err := topicCopy.Copy(context.Background(), CopyOptions{SourceTopic: "source", DestinationTopic: "destination",
+		From: OffsetSpecOldest, To: OffsetSpecNewest, KeepPartitions: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), copied)
+	reader.AssertExpectations(t)
+	writer.AssertExpectations(t)
+}
+
+func TestTopicCopy_CopyFailsWhenDestinationHasFewerPartitions(t *testing.T) {
+	reader := &client.MockMessageReader{}
+	writer := &client.MockMessageWriter{}
+	topicCopy := &TopicCopy{reader: reader, writer: writer, file: &MockFile{}, batchSize: 2}
+	reader.On("Partitions", "source").Return([]int32{0, 1}, nil)
+	writer.On("Partitions", "destination").Return([]int32{0}, nil)
+
+	_, err := topicCopy.Copy(context.Background(), CopyOptions{SourceTopic: "source", DestinationTopic: "destination",
+		From: OffsetSpecOldest, To: OffsetSpecNewest, KeepPartitions: true})
+
+	assert.EqualError(t, err, "destination topic destination has 1 partitions, fewer than the 2 partitions of source topic source")
+	reader.AssertNotCalled(t, "ReadMessages", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTopicCopy_CopyResumesFromCheckpoint(t *testing.T) {
+	reader := &client.MockMessageReader{}
+	writer := &client.MockMessageWriter{}
+	file := &MockFile{}
+	topicCopy := &TopicCopy{reader: reader, writer: writer, file: file, batchSize: 10}
+	reader.On("Partitions", "source").Return([]int32{0}, nil)
+	reader.On("GetOffset", "source", int32(0), int64(client.OffsetNewest)).Return(int64(4), nil)
+	file.On("Read", "checkpoint.json").Return([]byte(`{"sourceTopic":"source","destinationTopic":"destination","offsets":{"0":2}}`), nil)
+	reader.On("ReadMessages", "source", int32(0), int64(2), int64(4)).Return(copyMessages("source", 0, 2, 3), nil)
+	expected := copyMessages("destination", 0, 2, 3)
+	for _, msg := range expected {
+		msg.Partition = 0
+	}
+	writer.On("WriteMessages", expected).Return(nil)
+	file.On("Write", "checkpoint.json", `{"sourceTopic":"source","destinationTopic":"destination","offsets":{"0":4}}`).Return(nil)
+	file.On("Remove", "checkpoint.json").Return(nil)
+
+	copied, err := topicCopy.Copy(context.Background(), CopyOptions{SourceTopic: "source", DestinationTopic: "destination",
+		From: OffsetSpecOldest, To: OffsetSpecNewest, CheckpointFile: "checkpoint.json"})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), copied)
+	reader.AssertExpectations(t)
+	writer.AssertExpectations(t)
+	file.AssertExpectations(t)
+}
+
+func TestTopicCopy_CopyKeepsCheckpointWhenEndOffsetIsNotReached(t *testing.T) {
+	reader := &client.MockMessageReader{}
+	writer := &client.MockMessageWriter{}
+	file := &MockFile{}
+	topicCopy := &TopicCopy{reader: reader, writer: writer, file: file, batchSize: 10}
+	reader.On("Partitions", "source").Return([]int32{0}, nil)
+	setupOffsets(reader, 0, 0, 4)
+	file.On("Read", "checkpoint.json").Return([]byte{}, os.ErrNotExist)
+	// the read stops on the idle timeout after 2 of the 4 messages
+	reader.On("ReadMessages", "source", int32(0), int64(0), int64(4)).Return(copyMessages("source", 0, 0, 1),
+		fmt.Errorf("stopped at offset 2 of 4 - %w", client.ErrReadIdle))
+	writer.On("WriteMessages", mock.Anything).Return(nil)
+	file.On("Write", "checkpoint.json", `{"sourceTopic":"source","destinationTopic":"destination","offsets":{"0":2}}`).Return(nil)
+
+	copied, err := topicCopy.Copy(context.Background(), CopyOptions{SourceTopic: "source", DestinationTopic: "destination",
+		From: OffsetSpecOldest, To: OffsetSpecNewest, CheckpointFile: "checkpoint.json"})
+
+	assert.EqualError(t, err, "err while copying partition 0 - stopped at offset 2 of 4 - no messages were received for a while. "+
+		"Run the copy again with the checkpoint file to resume it")
+	assert.Equal(t, int64(2), copied)
+	file.AssertExpectations(t)
+	file.AssertNotCalled(t, "Remove", mock.Anything)
+}
+
+func TestTopicCopy_CopyCompletesWhenTheEndOffsetsAreTransactionMarkers(t *testing.T) {
+	reader := &client.MockMessageReader{}
+	writer := &client.MockMessageWriter{}
+	file := &MockFile{}
+	topicCopy := &TopicCopy{reader: reader, writer: writer, file: file, batchSize: 10}
+	reader.On("Partitions", "source").Return([]int32{0}, nil)
+	setupOffsets(reader, 0, 0, 4)
+	file.On("Read", "checkpoint.json").Return([]byte{}, os.ErrNotExist)
+	// offsets 2 and 3 are commit markers, which are not delivered as messages
+	reader.On("ReadMessages", "source", int32(0), int64(0), int64(4)).Return(copyMessages("source", 0, 0, 1), nil)
+	writer.On("WriteMessages", mock.Anything).Return(nil)
+	file.On("Write", "checkpoint.json", `{"sourceTopic":"source","destinationTopic":"destination","offsets":{"0":2}}`).Return(nil)
+	file.On("Remove", "checkpoint.json").Return(nil)
+
+	copied, err := topicCopy.Copy(context.Background(), CopyOptions{SourceTopic: "source", DestinationTopic: "destination",
+		From: OffsetSpecOldest, To: OffsetSpecNewest, CheckpointFile: "checkpoint.json"})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), copied)
+	file.AssertExpectations(t)
+}
+
+func TestTopicCopy_CopyStartsAfreshWithoutCheckpoint(t *testing.T) {
+	reader := &client.MockMessageReader{}
+	writer := &client.MockMessageWriter{}
+	file := &MockFile{}
+	topicCopy := &TopicCopy{reader: reader, writer: writer, file: file, batchSize: 10}
+	reader.On("Partitions", "source").Return([]int32{0}, nil)
+	setupOffsets(reader, 0, 0, 0)
+	file.On("Read", "checkpoint.json").Return([]byte{}, os.ErrNotExist)
+	file.On("Remove", "checkpoint.json").Return(os.ErrNotExist)
+
+	copied, err := topicCopy.Copy(context.Background(), CopyOptions{SourceTopic: "source", DestinationTopic: "destination",
+		From: OffsetSpecOldest, To: OffsetSpecNewest, CheckpointFile: "checkpoint.json"})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), copied)
+	file.AssertExpectations(t)
+}
+
+func TestTopicCopy_CopyRejectsCheckpointOfAnotherTopic(t *testing.T) {
+	reader := &client.MockMessageReader{}
+	file := &MockFile{}
+	topicCopy := &TopicCopy{reader: reader, writer: &client.MockMessageWriter{}, file: file, batchSize: 10}
+	reader.On("Partitions", "source").Return([]int32{0}, nil)
+	file.On("Read", "checkpoint.json").Return([]byte(`{"sourceTopic":"other","destinationTopic":"destination","offsets":{}}`), nil)
+
+	_, err := topicCopy.Copy(context.Background(), CopyOptions{SourceTopic: "source", DestinationTopic: "destination",
+		From: OffsetSpecOldest, To: OffsetSpecNewest, CheckpointFile: "checkpoint.json"})
+
+	assert.EqualError(t, err, "checkpoint file checkpoint.json is for copying topic other to destination")
+}
+
+func TestTopicCopy_CopyWriteFailure(t *testing.T) {
+	reader := &client.MockMessageReader{}
+	writer := &client.MockMessageWriter{}
+	file := &MockFile{}
+	topicCopy := &TopicCopy{reader: reader, writer: writer, file: file, batchSize: 1}
+	reader.On("Partitions", "source").Return([]int32{0}, nil)
+	setupOffsets(reader, 0, 0, 2)
+	file.On("Read", "checkpoint.json").Return([]byte{}, os.ErrNotExist)
+	reader.On("ReadMessages", "source", int32(0), int64(0), int64(2)).Return(copyMessages("source", 0, 0, 1), nil)
+	writer.On("WriteMessages", mock.Anything).Return(errors.New("error"))
+
+	copied, err := topicCopy.Copy(context.Background(), CopyOptions{SourceTopic: "source", DestinationTopic: "destination",
+		From: OffsetSpecOldest, To: OffsetSpecNewest, CheckpointFile: "checkpoint.json"})
+
+	assert.EqualError(t, err, "err while copying partition 0 - error")
+	assert.Equal(t, int64(0), copied)
+	writer.AssertNumberOfCalls(t, "WriteMessages", 1)
+	file.AssertNotCalled(t, "Write", mock.Anything, mock.Anything)
+	file.AssertNotCalled(t, "Remove", mock.Anything)
+}
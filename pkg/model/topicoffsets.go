@@ -0,0 +1,230 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gojek/kat/pkg/client"
+)
+
+const defaultOffsetsConcurrency = 8
+
+// PartitionOffsets has the range of the offsets of a partition, the timestamp of its first message and the latest
+// timestamp of its messages. The timestamps are zero when the partition is empty, or when they could not be read.
+type PartitionOffsets struct {
+	Topic          string
+	Partition      int32
+	OldestOffset   int64
+	NewestOffset   int64
+	FirstTimestamp time.Time
+	LastTimestamp  time.Time
+}
+
+// Messages returns the approximate number of messages in the partition. It is higher than the actual number
+// for compacted topics and for topics with transactions.
+func (p PartitionOffsets) Messages() int64 {
+	return p.NewestOffset - p.OldestOffset
+}
+
+type TopicOffsets struct {
+	Topic      string
+	Partitions []PartitionOffsets
+}
+
+// Messages returns the approximate number of messages in all the partitions
+func (t TopicOffsets) Messages() int64 {
+	var messages int64
+	for _, partition := range t.Partitions {
+		messages += partition.Messages()
+	}
+	return messages
+}
+
+// FirstTimestamp returns the earliest timestamp of the first messages of the partitions
+func (t TopicOffsets) FirstTimestamp() time.Time {
+	var first time.Time
+	for _, partition := range t.Partitions {
+		if !partition.FirstTimestamp.IsZero() && (first.IsZero() || partition.FirstTimestamp.Before(first)) {
+			first = partition.FirstTimestamp
+		}
+	}
+	return first
+}
+
+// LastTimestamp returns the latest timestamp of the last messages of the partitions
+func (t TopicOffsets) LastTimestamp() time.Time {
+	var last time.Time
+	for _, partition := range t.Partitions {
+		if partition.LastTimestamp.After(last) {
+			last = partition.LastTimestamp
+		}
+	}
+	return last
+}
+
+type OffsetsReader struct {
+	reader      client.MessageReader
+	concurrency int
+	// lastOnly skips reading the first messages, when only the last timestamps are needed
+	lastOnly bool
+}
+
+// NewOffsetsReader creates a reader that reads the offsets of the partitions in parallel, concurrency at a time
+func NewOffsetsReader(reader client.MessageReader, concurrency int) *OffsetsReader {
+	if concurrency <= 0 {
+		concurrency = defaultOffsetsConcurrency
+	}
+	return &OffsetsReader{reader: reader, concurrency: concurrency}
+}
+
+// Offsets returns the offsets of all the partitions of the topics, sorted by topic and partition
+func (o *OffsetsReader) Offsets(ctx context.Context, topics []string) ([]TopicOffsets, error) {
+	var partitions []PartitionOffsets
+	for _, topic := range topics {
+		topicPartitions, err := o.reader.Partitions(topic)
+		if err != nil {
+			return nil, fmt.Errorf("err while fetching partitions of topic %v - %v", topic, err)
+		}
+		for _, partition := range topicPartitions {
+			partitions = append(partitions, PartitionOffsets{Topic: topic, Partition: partition})
+		}
+	}
+	if err := o.readPartitionOffsets(ctx, partitions); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(partitions, func(i, j int) bool {
+		if partitions[i].Topic != partitions[j].Topic {
+			return partitions[i].Topic < partitions[j].Topic
+		}
+		return partitions[i].Partition < partitions[j].Partition
+	})
+	var result []TopicOffsets
+	for _, partition := range partitions {
+		if len(result) == 0 || result[len(result)-1].Topic != partition.Topic {
+			result = append(result, TopicOffsets{Topic: partition.Topic})
+		}
+		result[len(result)-1].Partitions = append(result[len(result)-1].Partitions, partition)
+	}
+	return result, nil
+}
+
+// readPartitionOffsets fills in the offsets and timestamps of the partitions, stopping at the first error
+func (o *OffsetsReader) readPartitionOffsets(ctx context.Context, partitions []PartitionOffsets) error {
+	ctx, cancelFunc := context.WithCancel(ctx)
+	defer cancelFunc()
+
+	var wg sync.WaitGroup
+	var errMu sync.Mutex
+	var firstErr error
+	semaphore := make(chan struct{}, o.concurrency)
+	for i := range partitions {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(partition *PartitionOffsets) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			if err := o.readOffsets(ctx, partition); err != nil {
+				errMu.Lock()
+				if firstErr == nil {
+					firstErr = fmt.Errorf("err while reading offsets of partition %d of topic %v - %w", partition.Partition, partition.Topic, err)
+				}
+				errMu.Unlock()
+				cancelFunc()
+			}
+		}(&partitions[i])
+	}
+	wg.Wait()
+	return firstErr
+}
+
+func (o *OffsetsReader) readOffsets(ctx context.Context, partition *PartitionOffsets) error {
+	var err error
+	if partition.OldestOffset, err = o.reader.GetOffset(partition.Topic, partition.Partition, client.OffsetOldest); err != nil {
+		return err
+	}
+	if partition.NewestOffset, err = o.reader.GetOffset(partition.Topic, partition.Partition, client.OffsetNewest); err != nil {
+		return err
+	}
+	if partition.Messages() <= 0 {
+		return nil
+	}
+	if !o.lastOnly {
+		if partition.FirstTimestamp, err = o.firstTimestamp(ctx, partition); err != nil {
+			return err
+		}
+	}
+	partition.LastTimestamp, err = o.lastTimestamp(ctx, partition)
+	return err
+}
+
+// firstTimestamp returns the timestamp of the oldest message of the partition
+func (o *OffsetsReader) firstTimestamp(ctx context.Context, partition *PartitionOffsets) (time.Time, error) {
+	var timestamp time.Time
+	err := o.reader.ReadMessages(ctx, partition.Topic, partition.Partition, partition.OldestOffset, partition.NewestOffset,
+		func(msg *client.Message) error {
+			timestamp = msg.Timestamp
+			return client.ErrStopReading
+		})
+	return timestamp, err
+}
+
+// lastTimestamp returns the latest timestamp of the messages of the partition. It is searched with the offsets by
+// timestamp instead of reading the newest message, which is never received when it is a transaction marker.
+func (o *OffsetsReader) lastTimestamp(ctx context.Context, partition *PartitionOffsets) (time.Time, error) {
+	var low int64
+	if !partition.FirstTimestamp.IsZero() {
+		low = toMillis(partition.FirstTimestamp)
+	}
+	high, err := o.timestampAfter(ctx, partition, low)
+	if err != nil || high == low {
+		return time.Time{}, err
+	}
+	// there are messages at or after the low timestamp and none at or after the high one
+	for high-low > 1 {
+		middle := low + (high-low)/2
+		found, err := o.hasMessagesSince(ctx, partition, middle)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if found {
+			low = middle
+		} else {
+			high = middle
+		}
+	}
+	return time.Unix(0, low*int64(time.Millisecond)), nil
+}
+
+// timestampAfter returns a timestamp, starting from the given one, with no messages at or after it
+func (o *OffsetsReader) timestampAfter(ctx context.Context, partition *PartitionOffsets, timestamp int64) (int64, error) {
+	// the first step reaches the current time, where the search usually ends
+	step := toMillis(time.Now()) - timestamp + 1
+	if hour := int64(time.Hour / time.Millisecond); step < hour {
+		step = hour
+	}
+	for {
+		found, err := o.hasMessagesSince(ctx, partition, timestamp)
+		if err != nil || !found {
+			return timestamp, err
+		}
+		timestamp += step
+		step *= 2
+	}
+}
+
+// hasMessagesSince returns whether the partition has messages with a timestamp at or after the one in milliseconds
+func (o *OffsetsReader) hasMessagesSince(ctx context.Context, partition *PartitionOffsets, timestamp int64) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	offset, err := o.reader.GetOffset(partition.Topic, partition.Partition, timestamp)
+	return offset >= 0, err
+}
+
+func toMillis(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
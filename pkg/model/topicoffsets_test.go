@@ -0,0 +1,84 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// setupLastTimestamp sets up the offsets by timestamp of the partition, with no messages after the last timestamp.
+// A zero last timestamp sets up a partition without timestamps.
+func setupLastTimestamp(reader *client.MockMessageReader, topic string, partition int32, last time.Time) {
+	lastMs := int64(-1)
+	if !last.IsZero() {
+		lastMs = toMillis(last)
+	}
+	reader.On("GetOffset", topic, partition, mock.MatchedBy(func(timestamp int64) bool {
+		return timestamp >= 0 && timestamp <= lastMs
+	})).Return(int64(0), nil)
+	reader.On("GetOffset", topic, partition, mock.MatchedBy(func(timestamp int64) bool {
+		return timestamp >= 0 && timestamp > lastMs
+	})).Return(int64(-1), nil)
+}
+
+func TestOffsetsReader_Offsets(t *testing.T) {
+	reader := &client.MockMessageReader{}
+	first := time.Date(2021, 3, 4, 5, 6, 7, 0, time.Local)
+	reader.On("Partitions", "orders").Return([]int32{1, 0}, nil)
+	reader.On("Partitions", "payments").Return([]int32{0}, nil)
+	setupTopicOffsets(reader, "orders", 0, 10, 20)
+	setupTopicOffsets(reader, "orders", 1, 5, 5)
+	setupTopicOffsets(reader, "payments", 0, 0, 3)
+	reader.On("ReadMessages", "orders", int32(0), int64(10), int64(20)).Return([]*client.Message{{Offset: 10, Timestamp: first}}, nil)
+	setupLastTimestamp(reader, "orders", 0, first.Add(time.Hour))
+	reader.On("ReadMessages", "payments", int32(0), int64(0), int64(3)).Return([]*client.Message{{Offset: 0, Timestamp: first.Add(time.Minute)}}, nil)
+	setupLastTimestamp(reader, "payments", 0, first.Add(time.Minute))
+
+	offsets, err := NewOffsetsReader(reader, 2).Offsets(context.Background(), []string{"payments", "orders"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []TopicOffsets{
+		{Topic: "orders", Partitions: []PartitionOffsets{
+			{Topic: "orders", Partition: 0, OldestOffset: 10, NewestOffset: 20, FirstTimestamp: first, LastTimestamp: first.Add(time.Hour)},
+			{Topic: "orders", Partition: 1, OldestOffset: 5, NewestOffset: 5},
+		}},
+		{Topic: "payments", Partitions: []PartitionOffsets{
+			{Topic: "payments", Partition: 0, OldestOffset: 0, NewestOffset: 3, FirstTimestamp: first.Add(time.Minute),
+				LastTimestamp: first.Add(time.Minute)},
+		}},
+	}, offsets)
+	assert.Equal(t, int64(10), offsets[0].Messages())
+	assert.Equal(t, first, offsets[0].FirstTimestamp())
+	assert.Equal(t, first.Add(time.Hour), offsets[0].LastTimestamp())
+	reader.AssertNotCalled(t, "ReadMessages", "orders", int32(1), mock.Anything, mock.Anything)
+	reader.AssertNumberOfCalls(t, "ReadMessages", 2)
+}
+
+func TestOffsetsReader_OffsetsWithoutTimestamps(t *testing.T) {
+	reader := &client.MockMessageReader{}
+	reader.On("Partitions", "orders").Return([]int32{0}, nil)
+	setupTopicOffsets(reader, "orders", 0, 0, 2)
+	reader.On("ReadMessages", "orders", int32(0), int64(0), int64(2)).Return([]*client.Message{}, nil)
+	setupLastTimestamp(reader, "orders", 0, time.Time{})
+
+	offsets, err := NewOffsetsReader(reader, 1).Offsets(context.Background(), []string{"orders"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []PartitionOffsets{{Topic: "orders", Partition: 0, OldestOffset: 0, NewestOffset: 2}}, offsets[0].Partitions)
+}
+
+func TestOffsetsReader_OffsetsFailure(t *testing.T) {
+	reader := &client.MockMessageReader{}
+	reader.On("Partitions", "orders").Return([]int32{0}, nil)
+	reader.On("GetOffset", "orders", int32(0), client.OffsetOldest).Return(int64(0), errors.New("error"))
+
+	_, err := NewOffsetsReader(reader, 0).Offsets(context.Background(), []string{"orders"})
+
+	assert.EqualError(t, err, "err while reading offsets of partition 0 of topic orders - error")
+}
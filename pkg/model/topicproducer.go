@@ -0,0 +1,115 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+)
+
+const produceBatchSize = 500
+
+// MessageSource returns the messages to be produced, and io.EOF after the last message
+type MessageSource interface {
+	Next() (*client.Message, error)
+}
+
+type ProduceOptions struct {
+	Topic string
+	// Partition overrides the partition of all the messages when it is not negative
+	Partition int32
+	// Headers are added to all the messages
+	Headers []client.MessageHeader
+}
+
+type ProduceSummary struct {
+	Produced int
+	Failed   int
+	Bytes    int64
+	Duration time.Duration
+}
+
+func (s ProduceSummary) MessagesPerSecond() float64 {
+	if s.Duration <= 0 {
+		return 0
+	}
+	return float64(s.Produced) / s.Duration.Seconds()
+}
+
+type TopicProducer struct {
+	writer    client.MessageWriter
+	batchSize int
+}
+
+func NewTopicProducer(writer client.MessageWriter) *TopicProducer {
+	return &TopicProducer{writer: writer, batchSize: produceBatchSize}
+}
+
+// Produce writes the messages of the source in batches, until the source ends or the context is cancelled.
+// The batches that fail to be written are counted in the summary, and an error is returned only when the source fails.
+func (p *TopicProducer) Produce(ctx context.Context, source MessageSource, opts ProduceOptions) (ProduceSummary, error) {
+	startTime := time.Now()
+	var summary ProduceSummary
+	batch := make([]*client.Message, 0, p.batchSize)
+	var batchBytes int64
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		failed := p.write(batch)
+		summary.Produced += len(batch) - failed
+		summary.Failed += failed
+		if failed == 0 {
+			summary.Bytes += batchBytes
+		}
+		batch, batchBytes = batch[:0], 0
+	}
+
+	for ctx.Err() == nil {
+		msg, err := source.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			flush()
+			summary.Duration = time.Since(startTime)
+			return summary, err
+		}
+		batch = append(batch, p.prepare(msg, opts))
+		batchBytes += int64(len(msg.Key) + len(msg.Value))
+		if len(batch) >= p.batchSize {
+			flush()
+		}
+	}
+	flush()
+	summary.Duration = time.Since(startTime)
+	return summary, nil
+}
+
+func (p *TopicProducer) prepare(msg *client.Message, opts ProduceOptions) *client.Message {
+	msg.Topic = opts.Topic
+	if opts.Partition >= 0 {
+		msg.Partition = opts.Partition
+	} else if msg.Partition < 0 {
+		msg.Partition = 0
+	}
+	msg.Headers = append(msg.Headers, opts.Headers...)
+	return msg
+}
+
+// write writes the batch and returns the number of messages that failed
+func (p *TopicProducer) write(batch []*client.Message) int {
+	err := p.writer.WriteMessages(batch)
+	if err == nil {
+		return 0
+	}
+	logger.Errorf("Error while producing to topic %v - %v\n", batch[0].Topic, err)
+	var writeErr *client.WriteError
+	if errors.As(err, &writeErr) {
+		return writeErr.Failed
+	}
+	return len(batch)
+}
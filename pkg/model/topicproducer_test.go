@@ -0,0 +1,98 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type sliceSource struct {
+	messages []*client.Message
+	err      error
+}
+
+func (s *sliceSource) Next() (*client.Message, error) {
+	if len(s.messages) == 0 {
+		if s.err != nil {
+			return nil, s.err
+		}
+		return nil, io.EOF
+	}
+	msg := s.messages[0]
+	s.messages = s.messages[1:]
+	return msg, nil
+}
+
+func TestTopicProducer_ProduceInBatches(t *testing.T) {
+	writer := &client.MockMessageWriter{}
+	producer := &TopicProducer{writer: writer, batchSize: 2}
+	source := &sliceSource{messages: []*client.Message{
+		{Key: []byte("k1"), Value: []byte("v1"), Partition: -1},
+		{Value: []byte("v2"), Partition: 3},
+		{Value: []byte("v3"), Partition: -1},
+	}}
+	header := client.MessageHeader{Key: []byte("h"), Value: []byte("v")}
+	writer.On("WriteMessages", []*client.Message{
+		{Topic: "topic", Key: []byte("k1"), Value: []byte("v1"), Partition: 0, Headers: []client.MessageHeader{header}},
+		{Topic: "topic", Value: []byte("v2"), Partition: 3, Headers: []client.MessageHeader{header}},
+	}).Return(nil)
+	writer.On("WriteMessages", []*client.Message{
+		{Topic: "topic", Value: []byte("v3"), Partition: 0, Headers: []client.MessageHeader{header}},
+	}).Return(nil)
+
+	summary, err := producer.Produce(context.Background(), source, ProduceOptions{Topic: "topic", Partition: -1,
+		Headers: []client.MessageHeader{header}})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, summary.Produced)
+	assert.Equal(t, 0, summary.Failed)
+	assert.Equal(t, int64(8), summary.Bytes)
+	writer.AssertExpectations(t)
+}
+
+func TestTopicProducer_ProduceToPartition(t *testing.T) {
+	writer := &client.MockMessageWriter{}
+	producer := NewTopicProducer(writer)
+	source := &sliceSource{messages: []*client.Message{{Value: []byte("v1"), Partition: 3}}}
+	writer.On("WriteMessages", []*client.Message{{Topic: "topic", Value: []byte("v1"), Partition: 1}}).Return(nil)
+
+	summary, err := producer.Produce(context.Background(), source, ProduceOptions{Topic: "topic", Partition: 1})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, summary.Produced)
+	writer.AssertExpectations(t)
+}
+
+func TestTopicProducer_ProduceCountsFailures(t *testing.T) {
+	writer := &client.MockMessageWriter{}
+	producer := &TopicProducer{writer: writer, batchSize: 2}
+	source := &sliceSource{messages: []*client.Message{{Value: []byte("1")}, {Value: []byte("2")}, {Value: []byte("3")}}}
+	writer.On("WriteMessages", mock.MatchedBy(func(batch []*client.Message) bool { return len(batch) == 2 })).
+		Return(&client.WriteError{Failed: 1, Total: 2, Err: errors.New("error")})
+	writer.On("WriteMessages", mock.MatchedBy(func(batch []*client.Message) bool { return len(batch) == 1 })).
+		Return(errors.New("error"))
+
+	summary, err := producer.Produce(context.Background(), source, ProduceOptions{Topic: "topic", Partition: -1})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, summary.Produced)
+	assert.Equal(t, 2, summary.Failed)
+}
+
+func TestTopicProducer_ProduceSourceFailure(t *testing.T) {
+	writer := &client.MockMessageWriter{}
+	producer := NewTopicProducer(writer)
+	source := &sliceSource{messages: []*client.Message{{Value: []byte("1")}}, err: errors.New("error")}
+	writer.On("WriteMessages", mock.Anything).Return(nil)
+
+	summary, err := producer.Produce(context.Background(), source, ProduceOptions{Topic: "topic", Partition: -1})
+
+	assert.EqualError(t, err, "error")
+	assert.Equal(t, 1, summary.Produced)
+}
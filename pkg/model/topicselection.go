@@ -0,0 +1,210 @@
+package model
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gojek/kat/logger"
+	"github.com/gojek/kat/pkg/client"
+)
+
+const topicColumn = "topic"
+
+// TopicSelection selects topics by regexes, replication factor and size. The criteria that are not set select all the topics.
+type TopicSelection struct {
+	// Include selects the topics matching the regex
+	Include string
+	// Exclude drops the topics matching the regex
+	Exclude           string
+	ReplicationFactor int
+	// MaxSize selects the topics with size less than or equal to it in bytes, -1 for any size
+	MaxSize int64
+}
+
+// Select returns the topics of the cluster satisfying all the criteria, sorted by name
+func (s TopicSelection) Select(lister client.Lister) ([]string, error) {
+	return s.selectTopics(lister, nil, false)
+}
+
+// SelectFrom returns the given topics satisfying all the criteria, sorted by name.
+// The topics that are not present on the cluster are skipped.
+func (s TopicSelection) SelectFrom(lister client.Lister, topics []string) ([]string, error) {
+	return s.selectTopics(lister, topics, true)
+}
+
+func (s TopicSelection) selectTopics(lister client.Lister, candidates []string, restricted bool) ([]string, error) {
+	topicDetails, err := lister.List()
+	if err != nil {
+		return nil, fmt.Errorf("err while listing topics - %v", err)
+	}
+	if !restricted {
+		candidates = make([]string, 0, len(topicDetails))
+		for topic := range topicDetails {
+			candidates = append(candidates, topic)
+		}
+	}
+
+	topics := s.filterByReplicationFactor(topicDetails, candidates)
+	if topics, err = s.filterByRegex(topics); err != nil {
+		return nil, err
+	}
+	if s.MaxSize >= 0 {
+		smallTopics, sizeErr := lister.ListTopicWithSizeLessThanOrEqualTo(s.MaxSize)
+		if sizeErr != nil {
+			return nil, fmt.Errorf("err while fetching topic sizes - %v", sizeErr)
+		}
+		topics = Intersect(topics, smallTopics)
+	}
+	sort.Strings(topics)
+	return topics, nil
+}
+
+func (s TopicSelection) filterByReplicationFactor(topicDetails map[string]client.TopicDetail, candidates []string) []string {
+	topics := make([]string, 0, len(candidates))
+	for _, topic := range candidates {
+		detail, ok := topicDetails[topic]
+		if !ok {
+			logger.Warnf("Skipping topic %v, it is not present on the cluster\n", topic)
+			continue
+		}
+		if s.ReplicationFactor == 0 || int(detail.ReplicationFactor) == s.ReplicationFactor {
+			topics = append(topics, topic)
+		}
+	}
+	return topics
+}
+
+func (s TopicSelection) filterByRegex(topics []string) ([]string, error) {
+	var err error
+	if s.Include != "" {
+		if topics, err = (ListUtil{List: topics}).Filter(s.Include, true); err != nil {
+			return nil, err
+		}
+	}
+	if s.Exclude != "" {
+		if topics, err = (ListUtil{List: topics}).Filter(s.Exclude, false); err != nil {
+			return nil, err
+		}
+	}
+	return topics, nil
+}
+
+// Intersect returns the elements of the list that are present in the other list, in the order of the list
+func Intersect(list, other []string) []string {
+	otherSet := toSet(other)
+	result := make([]string, 0, len(list))
+	for _, element := range list {
+		if otherSet[element] {
+			result = append(result, element)
+		}
+	}
+	return result
+}
+
+// IsInternalTopic returns true for the topics used by kafka and the schema registry, like __consumer_offsets and _schemas
+func IsInternalTopic(topic string) bool {
+	return strings.HasPrefix(topic, "__") || topic == "_schemas"
+}
+
+// ParseTopicList parses a list of topics, given as a csv with a topic column like the output of topic usage,
+// a json array of objects with a topic field, or one topic per line. Blank lines and lines starting with # are skipped.
+func ParseTopicList(data []byte) ([]string, error) {
+	data = bytes.TrimSpace(data)
+	var topics []string
+	var err error
+	switch {
+	case len(data) == 0:
+		return []string{}, nil
+	case data[0] == '[':
+		topics, err = parseTopicListJSON(data)
+	case isTopicCSV(data):
+		topics, err = parseTopicListCSV(data)
+	default:
+		topics = parseTopicListLines(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return uniqueInOrder(topics), nil
+}
+
+func parseTopicListJSON(data []byte) ([]string, error) {
+	var rows []struct {
+		Topic string `json:"topic"`
+	}
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, fmt.Errorf("err while parsing the json topic list - %v", err)
+	}
+	topics := make([]string, 0, len(rows))
+	for i, row := range rows {
+		if row.Topic == "" {
+			return nil, fmt.Errorf("element %d of the json topic list has no topic", i)
+		}
+		topics = append(topics, row.Topic)
+	}
+	return topics, nil
+}
+
+// isTopicCSV returns true when the first line is a csv header with a topic column, in any case
+func isTopicCSV(data []byte) bool {
+	header := string(data)
+	if index := strings.IndexByte(header, '\n'); index >= 0 {
+		header = header[:index]
+	}
+	for _, column := range strings.Split(header, ",") {
+		if strings.EqualFold(strings.TrimSpace(column), topicColumn) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseTopicListCSV(data []byte) ([]string, error) {
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("err while parsing the csv topic list - %v", err)
+	}
+	column := -1
+	for i, name := range records[0] {
+		if strings.EqualFold(strings.TrimSpace(name), topicColumn) {
+			column = i
+		}
+	}
+	topics := make([]string, 0, len(records)-1)
+	for _, record := range records[1:] {
+		if topic := strings.TrimSpace(record[column]); topic != "" {
+			topics = append(topics, topic)
+		}
+	}
+	return topics, nil
+}
+
+func parseTopicListLines(data []byte) []string {
+	var topics []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		topics = append(topics, line)
+	}
+	return topics
+}
+
+func uniqueInOrder(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			result = append(result, value)
+		}
+	}
+	return result
+}
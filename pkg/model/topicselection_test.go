@@ -0,0 +1,116 @@
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func selectionTopicDetails() map[string]client.TopicDetail {
+	return map[string]client.TopicDetail{
+		"orders":         {NumPartitions: 3, ReplicationFactor: 3},
+		"orders-retry":   {NumPartitions: 1, ReplicationFactor: 3},
+		"orders-dlq":     {NumPartitions: 1, ReplicationFactor: 1},
+		"payments":       {NumPartitions: 3, ReplicationFactor: 3},
+		"payments-retry": {NumPartitions: 1, ReplicationFactor: 1},
+	}
+}
+
+func TestTopicSelection_Select_CombinesTheCriteria(t *testing.T) {
+	lister := &client.MockLister{}
+	lister.On("List").Return(selectionTopicDetails(), nil)
+	lister.On("ListTopicWithSizeLessThanOrEqualTo", int64(10)).Return([]string{"orders-retry", "orders-dlq", "payments"}, nil)
+	selection := TopicSelection{Include: "^orders", Exclude: "dlq$", ReplicationFactor: 3, MaxSize: 10}
+
+	topics, err := selection.Select(lister)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"orders-retry"}, topics)
+	lister.AssertExpectations(t)
+}
+
+func TestTopicSelection_Select_WithoutCriteriaSelectsAllTopics(t *testing.T) {
+	lister := &client.MockLister{}
+	lister.On("List").Return(selectionTopicDetails(), nil)
+
+	topics, err := TopicSelection{MaxSize: -1}.Select(lister)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"orders", "orders-dlq", "orders-retry", "payments", "payments-retry"}, topics)
+	lister.AssertNotCalled(t, "ListTopicWithSizeLessThanOrEqualTo", int64(-1))
+}
+
+func TestTopicSelection_SelectFrom_SkipsTopicsMissingOnTheCluster(t *testing.T) {
+	lister := &client.MockLister{}
+	lister.On("List").Return(selectionTopicDetails(), nil)
+
+	topics, err := TopicSelection{Exclude: "retry", MaxSize: -1}.SelectFrom(lister, []string{"payments", "payments-retry", "missing", "orders"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"orders", "payments"}, topics)
+}
+
+func TestTopicSelection_Select_ReturnsErrors(t *testing.T) {
+	lister := &client.MockLister{}
+	lister.On("List").Return(map[string]client.TopicDetail{}, errors.New("error")).Once()
+	_, err := TopicSelection{MaxSize: -1}.Select(lister)
+	assert.EqualError(t, err, "err while listing topics - error")
+
+	lister.On("List").Return(selectionTopicDetails(), nil)
+	_, err = TopicSelection{Include: "(", MaxSize: -1}.Select(lister)
+	assert.Error(t, err)
+
+	lister.On("ListTopicWithSizeLessThanOrEqualTo", int64(0)).Return([]string{}, errors.New("error"))
+	_, err = TopicSelection{MaxSize: 0}.Select(lister)
+	assert.EqualError(t, err, "err while fetching topic sizes - error")
+}
+
+func TestIsInternalTopic(t *testing.T) {
+	assert.True(t, IsInternalTopic("__consumer_offsets"))
+	assert.True(t, IsInternalTopic("__transaction_state"))
+	assert.True(t, IsInternalTopic("_schemas"))
+	assert.False(t, IsInternalTopic("_orders"))
+	assert.False(t, IsInternalTopic("orders"))
+}
+
+func TestParseTopicList_Lines(t *testing.T) {
+	topics, err := ParseTopicList([]byte("# unused topics\norders\n\n payments \norders\n"))
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"orders", "payments"}, topics)
+}
+
+func TestParseTopicList_Empty(t *testing.T) {
+	topics, err := ParseTopicList([]byte("\n"))
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{}, topics)
+}
+
+func TestParseTopicList_CSV(t *testing.T) {
+	topics, err := ParseTopicList([]byte("Usage,Topic,Consumer Groups\nunused,orders,\nwrite-only,payments,\"g1,g2\"\n"))
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"orders", "payments"}, topics)
+}
+
+func TestParseTopicList_JSON(t *testing.T) {
+	topics, err := ParseTopicList([]byte(`[{"topic": "orders", "usage": "unused"}, {"topic": "payments"}]`))
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"orders", "payments"}, topics)
+}
+
+func TestParseTopicList_ReturnsErrors(t *testing.T) {
+	_, err := ParseTopicList([]byte(`[{"topic": "orders"}, {"usage": "unused"}]`))
+	assert.EqualError(t, err, "element 1 of the json topic list has no topic")
+
+	_, err = ParseTopicList([]byte(`[{"topic": "orders"`))
+	assert.Error(t, err)
+
+	_, err = ParseTopicList([]byte("topic,usage\norders\n"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,104 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/gojek/kat/pkg/client"
+)
+
+// PartitionTruncation has the messages of a partition deleted by a truncation, the messages from
+// the oldest offset until the offset, excluding the message at it
+type PartitionTruncation struct {
+	Partition    int32
+	OldestOffset int64
+	Offset       int64
+}
+
+// Messages returns the number of messages deleted from the partition
+func (p PartitionTruncation) Messages() int64 {
+	if p.Offset <= p.OldestOffset {
+		return 0
+	}
+	return p.Offset - p.OldestOffset
+}
+
+type TopicTruncation struct {
+	reader  client.MessageReader
+	deleter client.MessageDeleter
+}
+
+func NewTopicTruncation(reader client.MessageReader, deleter client.MessageDeleter) *TopicTruncation {
+	return &TopicTruncation{reader: reader, deleter: deleter}
+}
+
+// Plan returns the truncations of the partitions for deleting the messages before the offset or time,
+// for all the partitions of the topic when none are passed. An offset after the newest offset deletes all the messages.
+func (t *TopicTruncation) Plan(topic string, partitions []int32, before OffsetSpec) ([]PartitionTruncation, error) {
+	topicPartitions, err := t.reader.Partitions(topic)
+	if err != nil {
+		return nil, fmt.Errorf("err while fetching partitions of topic %v - %v", topic, err)
+	}
+	if len(partitions) == 0 {
+		partitions = topicPartitions
+	}
+	if unknown := missingPartitions(partitions, topicPartitions); len(unknown) > 0 {
+		return nil, fmt.Errorf("topic %v does not have the partitions %v", topic, unknown)
+	}
+
+	truncations := make([]PartitionTruncation, 0, len(partitions))
+	for _, partition := range partitions {
+		truncation, truncationErr := t.partitionTruncation(topic, partition, before)
+		if truncationErr != nil {
+			return nil, fmt.Errorf("err while resolving offsets of partition %d - %v", partition, truncationErr)
+		}
+		truncations = append(truncations, truncation)
+	}
+	return truncations, nil
+}
+
+func (t *TopicTruncation) partitionTruncation(topic string, partition int32, before OffsetSpec) (PartitionTruncation, error) {
+	truncation := PartitionTruncation{Partition: partition}
+	var err error
+	if truncation.OldestOffset, err = t.reader.GetOffset(topic, partition, client.OffsetOldest); err != nil {
+		return truncation, err
+	}
+	newest, err := t.reader.GetOffset(topic, partition, client.OffsetNewest)
+	if err != nil {
+		return truncation, err
+	}
+	if truncation.Offset, err = before.Resolve(t.reader, topic, partition); err != nil {
+		return truncation, err
+	}
+	if truncation.Offset > newest {
+		truncation.Offset = newest
+	}
+	return truncation, nil
+}
+
+// Truncate deletes the messages of the truncations, skipping the partitions without messages to delete
+func (t *TopicTruncation) Truncate(topic string, truncations []PartitionTruncation) error {
+	partitionOffsets := make(map[int32]int64)
+	for _, truncation := range truncations {
+		if truncation.Messages() > 0 {
+			partitionOffsets[truncation.Partition] = truncation.Offset
+		}
+	}
+	if len(partitionOffsets) == 0 {
+		return nil
+	}
+	return t.deleter.DeleteRecords(topic, partitionOffsets)
+}
+
+func missingPartitions(partitions, topicPartitions []int32) []int32 {
+	existing := make(map[int32]bool, len(topicPartitions))
+	for _, partition := range topicPartitions {
+		existing[partition] = true
+	}
+	var missing []int32
+	for _, partition := range partitions {
+		if !existing[partition] {
+			missing = append(missing, partition)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,94 @@
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTopicOffsets(reader *client.MockMessageReader, topic string, partition int32, oldest, newest int64) {
+	reader.On("GetOffset", topic, partition, client.OffsetOldest).Return(oldest, nil)
+	reader.On("GetOffset", topic, partition, client.OffsetNewest).Return(newest, nil)
+}
+
+func TestTopicTruncation_PlanAllPartitions(t *testing.T) {
+	reader := &client.MockMessageReader{}
+	reader.On("Partitions", "topic").Return([]int32{0, 1, 2}, nil)
+	setupTopicOffsets(reader, "topic", 0, 10, 100)
+	setupTopicOffsets(reader, "topic", 1, 60, 80)
+	setupTopicOffsets(reader, "topic", 2, 0, 40)
+	truncation := NewTopicTruncation(reader, &client.MockMessageDeleter{})
+
+	truncations, err := truncation.Plan("topic", nil, OffsetSpec{offset: 50})
+
+	require.NoError(t, err)
+	assert.Equal(t, []PartitionTruncation{
+		{Partition: 0, OldestOffset: 10, Offset: 50},
+		{Partition: 1, OldestOffset: 60, Offset: 50},
+		{Partition: 2, OldestOffset: 0, Offset: 40},
+	}, truncations)
+	assert.Equal(t, []int64{40, 0, 40}, []int64{truncations[0].Messages(), truncations[1].Messages(), truncations[2].Messages()})
+	reader.AssertExpectations(t)
+}
+
+func TestTopicTruncation_PlanLatest(t *testing.T) {
+	reader := &client.MockMessageReader{}
+	reader.On("Partitions", "topic").Return([]int32{0, 1}, nil)
+	setupTopicOffsets(reader, "topic", 1, 5, 30)
+	truncation := NewTopicTruncation(reader, &client.MockMessageDeleter{})
+
+	truncations, err := truncation.Plan("topic", []int32{1}, OffsetSpecNewest)
+
+	require.NoError(t, err)
+	assert.Equal(t, []PartitionTruncation{{Partition: 1, OldestOffset: 5, Offset: 30}}, truncations)
+	reader.AssertNotCalled(t, "GetOffset", "topic", int32(0), mock.Anything)
+}
+
+func TestTopicTruncation_PlanFailsForUnknownPartitions(t *testing.T) {
+	reader := &client.MockMessageReader{}
+	reader.On("Partitions", "topic").Return([]int32{0, 1}, nil)
+	truncation := NewTopicTruncation(reader, &client.MockMessageDeleter{})
+
+	_, err := truncation.Plan("topic", []int32{1, 3}, OffsetSpecNewest)
+
+	assert.EqualError(t, err, "topic topic does not have the partitions [3]")
+}
+
+func TestTopicTruncation_PlanFailure(t *testing.T) {
+	reader := &client.MockMessageReader{}
+	reader.On("Partitions", "topic").Return([]int32{0}, nil)
+	reader.On("GetOffset", "topic", int32(0), client.OffsetOldest).Return(int64(0), errors.New("error"))
+	truncation := NewTopicTruncation(reader, &client.MockMessageDeleter{})
+
+	_, err := truncation.Plan("topic", nil, OffsetSpecNewest)
+
+	assert.EqualError(t, err, "err while resolving offsets of partition 0 - error")
+}
+
+func TestTopicTruncation_TruncateSkipsPartitionsWithoutMessages(t *testing.T) {
+	deleter := &client.MockMessageDeleter{}
+	deleter.On("DeleteRecords", "topic", map[int32]int64{0: 50}).Return(nil)
+	truncation := NewTopicTruncation(&client.MockMessageReader{}, deleter)
+
+	err := truncation.Truncate("topic", []PartitionTruncation{
+		{Partition: 0, OldestOffset: 10, Offset: 50},
+		{Partition: 1, OldestOffset: 60, Offset: 50},
+	})
+
+	assert.NoError(t, err)
+	deleter.AssertExpectations(t)
+}
+
+func TestTopicTruncation_TruncateWithoutMessages(t *testing.T) {
+	deleter := &client.MockMessageDeleter{}
+	truncation := NewTopicTruncation(&client.MockMessageReader{}, deleter)
+
+	err := truncation.Truncate("topic", []PartitionTruncation{{Partition: 0, OldestOffset: 10, Offset: 10}})
+
+	assert.NoError(t, err)
+	deleter.AssertNotCalled(t, "DeleteRecords", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,149 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gojek/kat/pkg/client"
+)
+
+// Usages of the topics, by whether they are written to and read from
+const (
+	UsageActive    = "active"
+	UsageWriteOnly = "write-only"
+	UsageReadOnly  = "read-only"
+	UsageUnused    = "unused"
+)
+
+// TopicUsage has the size, the last write time and the consumer groups of a topic, and its usage
+type TopicUsage struct {
+	Topic             string `json:"topic"`
+	Partitions        int32  `json:"partitions"`
+	ReplicationFactor int16  `json:"replicationFactor"`
+	// SizeBytes is the size of the logs of all the replicas
+	SizeBytes int64 `json:"sizeBytes"`
+	Messages  int64 `json:"messages"`
+	// LastWrite is the latest timestamp of the last messages of the partitions, nil when there are no messages
+	LastWrite *time.Time `json:"lastWrite"`
+	// ConsumerGroups are the groups with members assigned to the partitions of the topic, or with offsets committed for them
+	ConsumerGroups []string `json:"consumerGroups"`
+	Usage          string   `json:"usage"`
+}
+
+type topicSizeLister interface {
+	List() (map[string]client.TopicDetail, error)
+	TopicSizes() (map[string]int64, error)
+}
+
+type UsageReport struct {
+	topics  topicSizeLister
+	offsets *OffsetsReader
+	groups  client.SubscriptionLister
+}
+
+func NewUsageReport(topics topicSizeLister, reader client.MessageReader, groups client.SubscriptionLister, concurrency int) *UsageReport {
+	return &UsageReport{topics: topics, offsets: NewOffsetsReader(reader, concurrency), groups: groups}
+}
+
+// Usage returns the usage of the topics matching the regex, sorted by topic. A topic is written to when a message
+// was written since the time, and read from when any consumer group is assigned to it or has committed offsets for it.
+func (u *UsageReport) Usage(ctx context.Context, topicRegex string, since time.Time) ([]TopicUsage, error) {
+	topicDetails, err := u.topics.List()
+	if err != nil {
+		return nil, fmt.Errorf("err while listing topics - %v", err)
+	}
+	names := make([]string, 0, len(topicDetails))
+	for topic := range topicDetails {
+		names = append(names, topic)
+	}
+	if names, err = (ListUtil{List: names}).Filter(topicRegex, true); err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	sizes, err := u.topics.TopicSizes()
+	if err != nil {
+		return nil, fmt.Errorf("err while fetching topic sizes - %v", err)
+	}
+	consumerGroups, err := consumerGroups(u.groups)
+	if err != nil {
+		return nil, err
+	}
+	offsets, err := u.offsets.Offsets(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+
+	usages := make([]TopicUsage, 0, len(offsets))
+	for _, topicOffsets := range offsets {
+		usage := newTopicUsage(topicOffsets, topicDetails[topicOffsets.Topic], sizes[topicOffsets.Topic], consumerGroups[topicOffsets.Topic])
+		usage.Usage = classifyUsage(usage.LastWrite != nil && !usage.LastWrite.Before(since), len(usage.ConsumerGroups) > 0)
+		usages = append(usages, usage)
+	}
+	return usages, nil
+}
+
+func newTopicUsage(topicOffsets TopicOffsets, detail client.TopicDetail, size int64, consumerGroups []string) TopicUsage {
+	usage := TopicUsage{
+		Topic:             topicOffsets.Topic,
+		Partitions:        detail.NumPartitions,
+		ReplicationFactor: detail.ReplicationFactor,
+		SizeBytes:         size,
+		Messages:          topicOffsets.Messages(),
+		ConsumerGroups:    consumerGroups,
+	}
+	if lastWrite := topicOffsets.LastTimestamp(); !lastWrite.IsZero() {
+		usage.LastWrite = &lastWrite
+	}
+	if usage.ConsumerGroups == nil {
+		usage.ConsumerGroups = []string{}
+	}
+	return usage
+}
+
+// consumerGroups returns the groups assigned to or committing to each topic
+func consumerGroups(lister client.SubscriptionLister) (map[string][]string, error) {
+	subscribed, err := lister.ListSubscribedTopics()
+	if err != nil {
+		return nil, fmt.Errorf("err while describing consumer groups - %v", err)
+	}
+	committed, err := lister.ListCommittedTopics()
+	if err != nil {
+		return nil, fmt.Errorf("err while fetching consumer group offsets - %v", err)
+	}
+	groups := make(map[string][]string)
+	for _, topicGroups := range []map[string][]string{subscribed, committed} {
+		for topic, names := range topicGroups {
+			groups[topic] = append(groups[topic], names...)
+		}
+	}
+	for topic, names := range groups {
+		groups[topic] = uniqueSorted(names)
+	}
+	return groups, nil
+}
+
+func classifyUsage(written, read bool) string {
+	switch {
+	case written && read:
+		return UsageActive
+	case written:
+		return UsageWriteOnly
+	case read:
+		return UsageReadOnly
+	default:
+		return UsageUnused
+	}
+}
+
+func uniqueSorted(values []string) []string {
+	set := toSet(values)
+	result := make([]string, 0, len(set))
+	for value := range set {
+		result = append(result, value)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,62 @@
+package model
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTopicSizeLister struct {
+	mock.Mock
+}
+
+func (m *mockTopicSizeLister) List() (map[string]client.TopicDetail, error) {
+	args := m.Called()
+	return args.Get(0).(map[string]client.TopicDetail), args.Error(1)
+}
+
+func (m *mockTopicSizeLister) TopicSizes() (map[string]int64, error) {
+	args := m.Called()
+	return args.Get(0).(map[string]int64), args.Error(1)
+}
+
+func TestUsageReport_Usage(t *testing.T) {
+	topics := &mockTopicSizeLister{}
+	reader := &client.MockMessageReader{}
+	groups := &client.MockSubscriptionLister{}
+	since := time.Date(2021, 3, 4, 0, 0, 0, 0, time.Local)
+	recent, old := since.Add(time.Hour), since.Add(-time.Hour)
+	topics.On("List").Return(map[string]client.TopicDetail{
+		"active": {NumPartitions: 1, ReplicationFactor: 3}, "write-only": {NumPartitions: 1}, "read-only": {NumPartitions: 1},
+		"unused": {NumPartitions: 1}, "excluded": {NumPartitions: 1},
+	}, nil)
+	topics.On("TopicSizes").Return(map[string]int64{"active": 300, "unused": 10}, nil)
+	groups.On("ListSubscribedTopics").Return(map[string][]string{"active": {"group-2"}}, nil)
+	groups.On("ListCommittedTopics").Return(map[string][]string{"active": {"group-1", "group-2"}, "read-only": {"group-3"}}, nil)
+	for topic, timestamp := range map[string]time.Time{"active": recent, "write-only": recent, "read-only": old} {
+		reader.On("Partitions", topic).Return([]int32{0}, nil)
+		setupTopicOffsets(reader, topic, 0, 0, 1)
+		reader.On("ReadMessages", topic, int32(0), int64(0), int64(1)).Return([]*client.Message{{Timestamp: timestamp}}, nil)
+		setupLastTimestamp(reader, topic, 0, timestamp)
+	}
+	reader.On("Partitions", "unused").Return([]int32{0}, nil)
+	setupTopicOffsets(reader, "unused", 0, 0, 0)
+	report := NewUsageReport(topics, reader, groups, 2)
+
+	usages, err := report.Usage(context.Background(), "^(active|write-only|read-only|unused)$", since)
+
+	require.NoError(t, err)
+	assert.Equal(t, []TopicUsage{
+		{Topic: "active", Partitions: 1, ReplicationFactor: 3, SizeBytes: 300, Messages: 1, LastWrite: &recent,
+			ConsumerGroups: []string{"group-1", "group-2"}, Usage: UsageActive},
+		{Topic: "read-only", Partitions: 1, Messages: 1, LastWrite: &old, ConsumerGroups: []string{"group-3"}, Usage: UsageReadOnly},
+		{Topic: "unused", Partitions: 1, SizeBytes: 10, ConsumerGroups: []string{}, Usage: UsageUnused},
+		{Topic: "write-only", Partitions: 1, Messages: 1, LastWrite: &recent, ConsumerGroups: []string{}, Usage: UsageWriteOnly},
+	}, usages)
+	reader.AssertNotCalled(t, "Partitions", "excluded")
+}
@@ -0,0 +1,31 @@
+package ui
+
+type ACLRow struct {
+	ResourceType   string `json:"resourceType" yaml:"resourceType"`
+	ResourceName   string `json:"resourceName" yaml:"resourceName"`
+	PatternType    string `json:"patternType" yaml:"patternType"`
+	Principal      string `json:"principal" yaml:"principal"`
+	Host           string `json:"host" yaml:"host"`
+	Operation      string `json:"operation" yaml:"operation"`
+	PermissionType string `json:"permissionType" yaml:"permissionType"`
+}
+
+func ACL(resourceType, resourceName, patternType, principal, host, operation, permissionType string) ACLRow {
+	return ACLRow{
+		ResourceType:   resourceType,
+		ResourceName:   resourceName,
+		PatternType:    patternType,
+		Principal:      principal,
+		Host:           host,
+		Operation:      operation,
+		PermissionType: permissionType,
+	}
+}
+
+func (a ACLRow) FieldValues() []string {
+	return []string{a.ResourceType, a.ResourceName, a.PatternType, a.Principal, a.Host, a.Operation, a.PermissionType}
+}
+
+func (a ACLRow) Headers() []string {
+	return []string{"Resource Type", "Resource Name", "Pattern Type", "Principal", "Host", "Operation", "Permission"}
+}
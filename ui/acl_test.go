@@ -0,0 +1,14 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestACL(t *testing.T) {
+	row := ACL("Topic", "orders", "Literal", "User:alice", "*", "Read", "Allow")
+
+	assert.Equal(t, []string{"Topic", "orders", "Literal", "User:alice", "*", "Read", "Allow"}, row.FieldValues())
+	assert.Equal(t, []string{"Resource Type", "Resource Name", "Pattern Type", "Principal", "Host", "Operation", "Permission"}, row.Headers())
+}
@@ -0,0 +1,27 @@
+package ui
+
+type ClusterDifferenceRow struct {
+	Type        string `json:"type" yaml:"type"`
+	Resource    string `json:"resource" yaml:"resource"`
+	Field       string `json:"field,omitempty" yaml:"field,omitempty"`
+	Source      string `json:"source" yaml:"source"`
+	Destination string `json:"destination" yaml:"destination"`
+}
+
+func ClusterDifference(diffType, resource, field, source, destination string) ClusterDifferenceRow {
+	return ClusterDifferenceRow{
+		Type:        diffType,
+		Resource:    resource,
+		Field:       field,
+		Source:      source,
+		Destination: destination,
+	}
+}
+
+func (c ClusterDifferenceRow) FieldValues() []string {
+	return []string{c.Type, c.Resource, c.Field, c.Source, c.Destination}
+}
+
+func (c ClusterDifferenceRow) Headers() []string {
+	return []string{"Type", "Resource", "Field", "Source", "Destination"}
+}
@@ -0,0 +1,14 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClusterDifference(t *testing.T) {
+	row := ClusterDifference("Config", "topic-1", "retention.ms", "1000", "2000")
+
+	assert.Equal(t, []string{"Config", "topic-1", "retention.ms", "1000", "2000"}, row.FieldValues())
+	assert.Equal(t, []string{"Type", "Resource", "Field", "Source", "Destination"}, row.Headers())
+}
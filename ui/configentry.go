@@ -0,0 +1,84 @@
+package ui
+
+import "strings"
+
+const maskedValue = "******"
+
+type ConfigEntryRow struct {
+	Name     string   `json:"name" yaml:"name"`
+	Value    string   `json:"value" yaml:"value"`
+	Source   string   `json:"source" yaml:"source"`
+	Synonyms []string `json:"synonyms" yaml:"synonyms"`
+}
+
+// ConfigEntry masks the value and the synonyms of sensitive configs
+func ConfigEntry(name, value, source string, sensitive bool, synonyms []string) ConfigEntryRow {
+	if sensitive {
+		value = maskedValue
+		synonyms = nil
+	}
+	return ConfigEntryRow{
+		Name:     name,
+		Value:    value,
+		Source:   source,
+		Synonyms: synonyms,
+	}
+}
+
+func (c ConfigEntryRow) FieldValues() []string {
+	return []string{c.Name, c.Value, c.Source, strings.Join(c.Synonyms, ", ")}
+}
+
+func (c ConfigEntryRow) Headers() []string {
+	return []string{"Name", "Value", "Source", "Synonyms"}
+}
+
+// TopicConfigEntryRow is a config entry of one of the topics shown together
+type TopicConfigEntryRow struct {
+	Topic          string `json:"topic" yaml:"topic"`
+	ConfigEntryRow `yaml:",inline"`
+}
+
+func TopicConfigEntry(topic, name, value, source string, sensitive bool, synonyms []string) TopicConfigEntryRow {
+	return TopicConfigEntryRow{Topic: topic, ConfigEntryRow: ConfigEntry(name, value, source, sensitive, synonyms)}
+}
+
+func (c TopicConfigEntryRow) FieldValues() []string {
+	return append([]string{c.Topic}, c.ConfigEntryRow.FieldValues()...)
+}
+
+func (c TopicConfigEntryRow) Headers() []string {
+	return append([]string{"Topic"}, c.ConfigEntryRow.Headers()...)
+}
+
+type ConfigMatrixRow struct {
+	Topic string `json:"topic" yaml:"topic"`
+	// Configs are the values of the keys, the keys that are not set have an empty value
+	Configs map[string]string `json:"configs" yaml:"configs"`
+	keys    []string
+}
+
+// ConfigMatrix is a row per topic with a column per config key, used to compare configs across topics
+func ConfigMatrix(topic string, keys []string, values map[string]string, sensitive map[string]bool) ConfigMatrixRow {
+	row := ConfigMatrixRow{Topic: topic, Configs: make(map[string]string, len(keys)), keys: keys}
+	for _, key := range keys {
+		value := values[key]
+		if sensitive[key] {
+			value = maskedValue
+		}
+		row.Configs[key] = value
+	}
+	return row
+}
+
+func (c ConfigMatrixRow) FieldValues() []string {
+	values := []string{c.Topic}
+	for _, key := range c.keys {
+		values = append(values, c.Configs[key])
+	}
+	return values
+}
+
+func (c ConfigMatrixRow) Headers() []string {
+	return append([]string{"Topic"}, c.keys...)
+}
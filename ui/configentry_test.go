@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigEntry_FieldValues(t *testing.T) {
+	row := ConfigEntry("retention.ms", "1000", "Topic", false, []string{"retention.ms=1000(Topic)", "log.retention.ms=2000(StaticBroker)"})
+
+	assert.Equal(t, []string{"retention.ms", "1000", "Topic", "retention.ms=1000(Topic), log.retention.ms=2000(StaticBroker)"}, row.FieldValues())
+}
+
+func TestConfigEntry_MasksSensitiveValues(t *testing.T) {
+	row := ConfigEntry("sasl.jaas.config", "secret", "Topic", true, []string{"sasl.jaas.config=secret(Topic)"})
+
+	assert.Equal(t, []string{"sasl.jaas.config", "******", "Topic", ""}, row.FieldValues())
+}
+
+func TestConfigEntry_Headers(t *testing.T) {
+	row := ConfigEntry("retention.ms", "1000", "Topic", false, nil)
+
+	assert.Equal(t, []string{"Name", "Value", "Source", "Synonyms"}, row.Headers())
+}
+
+func TestConfigMatrix_FieldValuesAndHeaders(t *testing.T) {
+	keys := []string{"retention.ms", "sasl.jaas.config", "cleanup.policy"}
+	values := map[string]string{"retention.ms": "1000", "sasl.jaas.config": "secret"}
+
+	row := ConfigMatrix("topic-1", keys, values, map[string]bool{"sasl.jaas.config": true})
+
+	assert.Equal(t, []string{"topic-1", "1000", "******", ""}, row.FieldValues())
+	assert.Equal(t, []string{"Topic", "retention.ms", "sasl.jaas.config", "cleanup.policy"}, row.Headers())
+}
+
+func TestTopicConfigEntry_FieldValuesAndHeaders(t *testing.T) {
+	row := TopicConfigEntry("topic-1", "retention.ms", "1000", "Topic", false, nil)
+
+	assert.Equal(t, []string{"topic-1", "retention.ms", "1000", "Topic", ""}, row.FieldValues())
+	assert.Equal(t, []string{"Topic", "Name", "Value", "Source", "Synonyms"}, row.Headers())
+}
@@ -0,0 +1,56 @@
+package ui
+
+type ConfigViolationRow struct {
+	Topic    string `json:"topic" yaml:"topic"`
+	Config   string `json:"config" yaml:"config"`
+	Value    string `json:"value" yaml:"value"`
+	Expected string `json:"expected" yaml:"expected"`
+	Status   string `json:"status" yaml:"status"`
+	Reason   string `json:"reason,omitempty" yaml:"reason,omitempty"`
+}
+
+func ConfigViolation(topic, config, value, expected string, isFixable, isFix bool, err error) ConfigViolationRow {
+	var violationStatus auditStatus
+	var reason string
+	switch {
+	case !isFix:
+		violationStatus = violation
+	case !isFixable:
+		violationStatus = notFixable
+	case err != nil:
+		violationStatus = fixFailure
+		reason = err.Error()
+	default:
+		violationStatus = fixed
+	}
+
+	return ConfigViolationRow{
+		Topic:    topic,
+		Config:   config,
+		Value:    value,
+		Expected: expected,
+		Status:   violationStatus.String(),
+		Reason:   reason,
+	}
+}
+
+func (c ConfigViolationRow) FieldValues() []string {
+	return []string{c.Topic, c.Config, c.Value, c.Expected, c.Status, c.Reason}
+}
+
+func (c ConfigViolationRow) Headers() []string {
+	return []string{"Topic", "Config", "Value", "Expected", "Status", "Reason"}
+}
+
+type auditStatus int
+
+const (
+	violation auditStatus = iota
+	fixed
+	fixFailure
+	notFixable
+)
+
+func (s auditStatus) String() string {
+	return [...]string{"Violation", "Fixed", "FixFailure", "NotFixable"}[s]
+}
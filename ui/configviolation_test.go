@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigViolation_Violation(t *testing.T) {
+	row := ConfigViolation("topic-1", "retention.ms", "100", ">= 3600000", true, false, nil)
+
+	assert.Equal(t, []string{"topic-1", "retention.ms", "100", ">= 3600000", "Violation", ""}, row.FieldValues())
+}
+
+func TestConfigViolation_Fixed(t *testing.T) {
+	row := ConfigViolation("topic-1", "retention.ms", "100", ">= 3600000", true, true, nil)
+
+	assert.Equal(t, []string{"topic-1", "retention.ms", "100", ">= 3600000", "Fixed", ""}, row.FieldValues())
+}
+
+func TestConfigViolation_FixFailure(t *testing.T) {
+	row := ConfigViolation("topic-1", "retention.ms", "100", ">= 3600000", true, true, errors.New("error"))
+
+	assert.Equal(t, []string{"topic-1", "retention.ms", "100", ">= 3600000", "FixFailure", "error"}, row.FieldValues())
+}
+
+func TestConfigViolation_NotFixable(t *testing.T) {
+	row := ConfigViolation("topic-1", "retention.ms", "", "overridden", false, true, nil)
+
+	assert.Equal(t, []string{"topic-1", "retention.ms", "", "overridden", "NotFixable", ""}, row.FieldValues())
+}
+
+func TestConfigViolation_Headers(t *testing.T) {
+	row := ConfigViolation("topic-1", "retention.ms", "100", ">= 3600000", true, false, nil)
+
+	assert.Equal(t, []string{"Topic", "Config", "Value", "Expected", "Status", "Reason"}, row.Headers())
+}
@@ -0,0 +1,17 @@
+package ui
+
+type ConsumerGroupRow struct {
+	Group string `json:"group" yaml:"group"`
+}
+
+func ConsumerGroup(group string) ConsumerGroupRow {
+	return ConsumerGroupRow{Group: group}
+}
+
+func (c ConsumerGroupRow) FieldValues() []string {
+	return []string{c.Group}
+}
+
+func (c ConsumerGroupRow) Headers() []string {
+	return []string{"Consumer Group"}
+}
@@ -0,0 +1,12 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsumerGroup(t *testing.T) {
+	assert.Equal(t, []string{"group-1"}, ConsumerGroup("group-1").FieldValues())
+	assert.Equal(t, []string{"Consumer Group"}, ConsumerGroup("group-1").Headers())
+}
@@ -0,0 +1,180 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/gojek/kat/pkg/client"
+)
+
+// Decoders of the keys and values of the messages
+const (
+	DecoderString = "string"
+	DecoderHex    = "hex"
+	DecoderBase64 = "base64"
+	DecoderJSON   = "json"
+	// DecoderSchemaRegistry decodes the values in the schema registry wire format to json
+	DecoderSchemaRegistry = "schema-registry"
+)
+
+// Formats of the messages printed by the MessagePrinter
+const (
+	MessageFormatText      = "text"
+	MessageFormatJSONLines = "jsonl"
+)
+
+type decoder func(data []byte) string
+
+var decoders = map[string]decoder{
+	DecoderString: func(data []byte) string { return string(data) },
+	DecoderHex:    hex.EncodeToString,
+	DecoderBase64: base64.StdEncoding.EncodeToString,
+	DecoderJSON:   prettyJSON,
+}
+
+// prettyJSON indents the data when it is valid json, and returns it as is otherwise
+func prettyJSON(data []byte) string {
+	var out bytes.Buffer
+	if err := json.Indent(&out, data, "", "  "); err != nil {
+		return string(data)
+	}
+	return out.String()
+}
+
+type PrintOptions struct {
+	KeyDecoder   string
+	ValueDecoder string
+	// Format is one of text|jsonl, ignored when a Template is given
+	Format string
+	// Template is a go text/template executed for each message, with the fields of PrintedMessage
+	Template      string
+	PrintKey      bool
+	PrintHeaders  bool
+	PrintMetadata bool
+	// SchemaDecoder is needed for the schema-registry decoder
+	SchemaDecoder func(data []byte) ([]byte, error)
+}
+
+// PrintedMessage is a message with the key, value and headers decoded, as passed to the templates
+type PrintedMessage struct {
+	Topic     string            `json:"topic"`
+	Partition int32             `json:"partition"`
+	Offset    int64             `json:"offset"`
+	Timestamp time.Time         `json:"timestamp"`
+	Key       string            `json:"key"`
+	Value     string            `json:"value"`
+	Headers   map[string]string `json:"headers,omitempty"`
+}
+
+type MessagePrinter struct {
+	out          io.Writer
+	opts         PrintOptions
+	keyDecoder   decoder
+	valueDecoder decoder
+	template     *template.Template
+}
+
+func NewMessagePrinter(out io.Writer, opts PrintOptions) (*MessagePrinter, error) {
+	p := &MessagePrinter{out: out, opts: opts}
+	var err error
+	if p.keyDecoder, err = newDecoder(opts.KeyDecoder, opts.SchemaDecoder); err != nil {
+		return nil, fmt.Errorf("invalid key decoder - %v", err)
+	}
+	if p.valueDecoder, err = newDecoder(opts.ValueDecoder, opts.SchemaDecoder); err != nil {
+		return nil, fmt.Errorf("invalid value decoder - %v", err)
+	}
+	if opts.Template != "" {
+		tmpl, parseErr := template.New("message").Parse(opts.Template)
+		if parseErr != nil {
+			return nil, fmt.Errorf("invalid template - %v", parseErr)
+		}
+		p.template = tmpl
+	} else if opts.Format != MessageFormatText && opts.Format != MessageFormatJSONLines {
+		return nil, fmt.Errorf("invalid format %v, expected one of text|jsonl", opts.Format)
+	}
+	return p, nil
+}
+
+func newDecoder(name string, schemaDecoder func([]byte) ([]byte, error)) (decoder, error) {
+	if name != DecoderSchemaRegistry {
+		if d, ok := decoders[name]; ok {
+			return d, nil
+		}
+		return nil, fmt.Errorf("%v is not one of string|hex|base64|json|schema-registry", name)
+	}
+	if schemaDecoder == nil {
+		return nil, fmt.Errorf("schema registry url is needed for the schema-registry decoder")
+	}
+	// the data not in the wire format, like the string keys of the messages with avro values, is printed as a string
+	return func(data []byte) string {
+		decoded, err := schemaDecoder(data)
+		if err != nil {
+			return string(data)
+		}
+		return string(decoded)
+	}, nil
+}
+
+func (p *MessagePrinter) Print(msg *client.Message) error {
+	printed := p.decode(msg)
+	switch {
+	case p.template != nil:
+		if err := p.template.Execute(p.out, printed); err != nil {
+			return err
+		}
+		_, err := fmt.Fprintln(p.out)
+		return err
+	case p.opts.Format == MessageFormatJSONLines:
+		return json.NewEncoder(p.out).Encode(printed)
+	default:
+		_, err := fmt.Fprintln(p.out, p.text(printed))
+		return err
+	}
+}
+
+func (p *MessagePrinter) decode(msg *client.Message) PrintedMessage {
+	printed := PrintedMessage{
+		Topic:     msg.Topic,
+		Partition: msg.Partition,
+		Offset:    msg.Offset,
+		Timestamp: msg.Timestamp,
+		Key:       p.keyDecoder(msg.Key),
+		Value:     p.valueDecoder(msg.Value),
+	}
+	if len(msg.Headers) > 0 {
+		printed.Headers = make(map[string]string, len(msg.Headers))
+		for _, header := range msg.Headers {
+			printed.Headers[string(header.Key)] = string(header.Value)
+		}
+	}
+	return printed
+}
+
+// text prints the metadata, key and headers when enabled, followed by the value, separated by tabs
+func (p *MessagePrinter) text(msg PrintedMessage) string {
+	var fields []string
+	if p.opts.PrintMetadata {
+		fields = append(fields, fmt.Sprintf("partition:%d offset:%d timestamp:%v", msg.Partition, msg.Offset,
+			msg.Timestamp.Format(time.RFC3339)))
+	}
+	if p.opts.PrintKey {
+		fields = append(fields, msg.Key)
+	}
+	if p.opts.PrintHeaders {
+		headers := make([]string, 0, len(msg.Headers))
+		for key, value := range msg.Headers {
+			headers = append(headers, key+"="+value)
+		}
+		sort.Strings(headers)
+		fields = append(fields, strings.Join(headers, ","))
+	}
+	return strings.Join(append(fields, msg.Value), "\t")
+}
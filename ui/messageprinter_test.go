@@ -0,0 +1,92 @@
+package ui
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gojek/kat/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var printedMessage = &client.Message{
+	Topic:     "topic",
+	Partition: 1,
+	Offset:    10,
+	Key:       []byte("key"),
+	Value:     []byte(`{"id":1}`),
+	Headers:   []client.MessageHeader{{Key: []byte("b"), Value: []byte("2")}, {Key: []byte("a"), Value: []byte("1")}},
+	Timestamp: time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC),
+}
+
+func TestMessagePrinter_PrintText(t *testing.T) {
+	out := &bytes.Buffer{}
+	printer, err := NewMessagePrinter(out, PrintOptions{KeyDecoder: DecoderHex, ValueDecoder: DecoderString,
+		Format: MessageFormatText, PrintKey: true, PrintHeaders: true, PrintMetadata: true})
+	require.NoError(t, err)
+
+	require.NoError(t, printer.Print(printedMessage))
+	assert.Equal(t, "partition:1 offset:10 timestamp:2021-03-04T05:06:07Z\t6b6579\ta=1,b=2\t{\"id\":1}\n", out.String())
+}
+
+func TestMessagePrinter_PrintPrettyJSON(t *testing.T) {
+	out := &bytes.Buffer{}
+	printer, err := NewMessagePrinter(out, PrintOptions{KeyDecoder: DecoderString, ValueDecoder: DecoderJSON, Format: MessageFormatText})
+	require.NoError(t, err)
+
+	require.NoError(t, printer.Print(printedMessage))
+	require.NoError(t, printer.Print(&client.Message{Value: []byte("not json")}))
+	assert.Equal(t, "{\n  \"id\": 1\n}\nnot json\n", out.String())
+}
+
+func TestMessagePrinter_PrintJSONLines(t *testing.T) {
+	out := &bytes.Buffer{}
+	printer, err := NewMessagePrinter(out, PrintOptions{KeyDecoder: DecoderString, ValueDecoder: DecoderBase64, Format: MessageFormatJSONLines})
+	require.NoError(t, err)
+
+	require.NoError(t, printer.Print(printedMessage))
+	assert.Equal(t, `{"topic":"topic","partition":1,"offset":10,"timestamp":"2021-03-04T05:06:07Z","key":"key",`+
+		`"value":"eyJpZCI6MX0=","headers":{"a":"1","b":"2"}}`+"\n", out.String())
+}
+
+func TestMessagePrinter_PrintTemplate(t *testing.T) {
+	out := &bytes.Buffer{}
+	printer, err := NewMessagePrinter(out, PrintOptions{KeyDecoder: DecoderString, ValueDecoder: DecoderString,
+		Template: `{{.Partition}}:{{.Offset}} {{.Key}}={{.Value}} {{index .Headers "a"}}`})
+	require.NoError(t, err)
+
+	require.NoError(t, printer.Print(printedMessage))
+	assert.Equal(t, "1:10 key={\"id\":1} 1\n", out.String())
+}
+
+func TestNewMessagePrinter_Invalid(t *testing.T) {
+	_, err := NewMessagePrinter(&bytes.Buffer{}, PrintOptions{KeyDecoder: "avro", ValueDecoder: DecoderString, Format: MessageFormatText})
+	assert.EqualError(t, err, "invalid key decoder - avro is not one of string|hex|base64|json|schema-registry")
+
+	_, err = NewMessagePrinter(&bytes.Buffer{}, PrintOptions{KeyDecoder: DecoderString, ValueDecoder: DecoderSchemaRegistry, Format: MessageFormatText})
+	assert.EqualError(t, err, "invalid value decoder - schema registry url is needed for the schema-registry decoder")
+
+	_, err = NewMessagePrinter(&bytes.Buffer{}, PrintOptions{KeyDecoder: DecoderString, ValueDecoder: DecoderString, Format: "csv"})
+	assert.EqualError(t, err, "invalid format csv, expected one of text|jsonl")
+
+	_, err = NewMessagePrinter(&bytes.Buffer{}, PrintOptions{KeyDecoder: DecoderString, ValueDecoder: DecoderString, Template: "{{.Key"})
+	assert.Error(t, err)
+}
+
+func TestMessagePrinter_PrintWithSchemaDecoder(t *testing.T) {
+	out := &bytes.Buffer{}
+	schemaDecoder := func(data []byte) ([]byte, error) {
+		if data[0] != 0 {
+			return nil, errors.New("not in the wire format")
+		}
+		return []byte(`{"id":1}`), nil
+	}
+	printer, err := NewMessagePrinter(out, PrintOptions{KeyDecoder: DecoderSchemaRegistry, ValueDecoder: DecoderSchemaRegistry,
+		Format: MessageFormatText, PrintKey: true, SchemaDecoder: schemaDecoder})
+	require.NoError(t, err)
+
+	require.NoError(t, printer.Print(&client.Message{Key: []byte("key"), Value: []byte{0, 0, 0, 0, 1, 2}}))
+	assert.Equal(t, "key\t{\"id\":1}\n", out.String())
+}
@@ -1,24 +1,30 @@
 package ui
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 type MirrorStatusRow struct {
-	topic             string
-	action            action
-	configChange      string
-	oldPartitionCount int32
-	newPartitionCount int32
-	status            status
-	reason            string
+	Topic             string         `json:"topic" yaml:"topic"`
+	Action            action         `json:"action" yaml:"action"`
+	ConfigChanges     []ConfigChange `json:"configs" yaml:"configs"`
+	OldPartitionCount int32          `json:"oldPartitionCount" yaml:"oldPartitionCount"`
+	NewPartitionCount int32          `json:"newPartitionCount" yaml:"newPartitionCount"`
+	Status            status         `json:"status" yaml:"status"`
+	Reason            string         `json:"reason,omitempty" yaml:"reason,omitempty"`
 }
 
-func MirrorStatus(topic, config string, oldPartitionCount, newPartitionCount int32, isCreate, isDryRun bool, err error) MirrorStatusRow {
+func MirrorStatus(topic string, configChanges []ConfigChange, oldPartitionCount, newPartitionCount int32, isCreate, isDryRun bool, err error) MirrorStatusRow {
 	var actionType action
 	if isCreate {
 		actionType = create
 	} else {
 		actionType = update
 	}
+	if configChanges == nil {
+		configChanges = []ConfigChange{}
+	}
 	var mirrorStatus status
 	var reason string
 	if isDryRun {
@@ -33,24 +39,47 @@ func MirrorStatus(topic, config string, oldPartitionCount, newPartitionCount int
 	}
 
 	return MirrorStatusRow{
-		topic:             topic,
-		action:            actionType,
-		configChange:      config,
-		oldPartitionCount: oldPartitionCount,
-		newPartitionCount: newPartitionCount,
-		status:            mirrorStatus,
-		reason:            reason,
+		Topic:             topic,
+		Action:            actionType,
+		ConfigChanges:     configChanges,
+		OldPartitionCount: oldPartitionCount,
+		NewPartitionCount: newPartitionCount,
+		Status:            mirrorStatus,
+		Reason:            reason,
 	}
 }
 
 func (m MirrorStatusRow) FieldValues() []string {
-	return []string{m.topic, m.action.String(), m.configChange, fmt.Sprint(m.oldPartitionCount), fmt.Sprint(m.newPartitionCount), m.status.String(), m.reason}
+	return []string{m.Topic, m.Action.String(), configChangesString(m.ConfigChanges), fmt.Sprint(m.OldPartitionCount),
+		fmt.Sprint(m.NewPartitionCount), m.Status.String(), m.Reason}
 }
 
 func (m MirrorStatusRow) Headers() []string {
 	return []string{"topic", "Action", "Configs", "OldPartitionCount", "NewPartitionCount", "Status", "Reason"}
 }
 
+// ConfigChange is a config set on the destination topic, From is empty for a config that was not set before
+type ConfigChange struct {
+	Config string `json:"config" yaml:"config"`
+	From   string `json:"from,omitempty" yaml:"from,omitempty"`
+	To     string `json:"to" yaml:"to"`
+}
+
+func (c ConfigChange) String() string {
+	if c.From == "" {
+		return fmt.Sprintf("%v=%v", c.Config, c.To)
+	}
+	return fmt.Sprintf("%v=%v->%v", c.Config, c.From, c.To)
+}
+
+func configChangesString(changes []ConfigChange) string {
+	values := make([]string, 0, len(changes))
+	for _, change := range changes {
+		values = append(values, change.String())
+	}
+	return strings.Join(values, ", ")
+}
+
 type action int
 
 const (
@@ -62,6 +91,10 @@ func (s action) String() string {
 	return [...]string{"Create", "Update"}[s]
 }
 
+func (s action) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
 type status int
 
 const (
@@ -73,3 +106,7 @@ const (
 func (s status) String() string {
 	return [...]string{"DryRun", "Success", "Failure"}[s]
 }
+
+func (s status) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
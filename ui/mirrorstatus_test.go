@@ -1,15 +1,17 @@
 package ui
 
 import (
+	"bytes"
 	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMirrorStatus_CreateSuccess(t *testing.T) {
 	topic := "topic-1"
-	config := "config-1"
+	config := []ConfigChange{{Config: "retention.ms", From: "1000", To: "2000"}, {Config: "segment.bytes", To: "100"}}
 	oldNumOfPartitions := int32(10)
 	newNumOfPartitions := int32(20)
 	isCreate := true
@@ -17,12 +19,12 @@ func TestMirrorStatus_CreateSuccess(t *testing.T) {
 
 	mirrorStatus := MirrorStatus(topic, config, oldNumOfPartitions, newNumOfPartitions, isCreate, isDryRun, nil)
 
-	assert.Equal(t, []string{"topic-1", "Create", "config-1", "10", "20", "Success", ""}, mirrorStatus.FieldValues())
+	assert.Equal(t, []string{"topic-1", "Create", "retention.ms=1000->2000, segment.bytes=100", "10", "20", "Success", ""}, mirrorStatus.FieldValues())
 }
 
 func TestMirrorStatus_CreateFailure(t *testing.T) {
 	topic := "topic-1"
-	config := "config-1"
+	config := []ConfigChange{{Config: "retention.ms", From: "1000", To: "2000"}, {Config: "segment.bytes", To: "100"}}
 	oldNumOfPartitions := int32(10)
 	newNumOfPartitions := int32(20)
 	isCreate := true
@@ -31,12 +33,12 @@ func TestMirrorStatus_CreateFailure(t *testing.T) {
 
 	mirrorStatus := MirrorStatus(topic, config, oldNumOfPartitions, newNumOfPartitions, isCreate, isDryRun, err)
 
-	assert.Equal(t, []string{"topic-1", "Create", "config-1", "10", "20", "Failure", "error"}, mirrorStatus.FieldValues())
+	assert.Equal(t, []string{"topic-1", "Create", "retention.ms=1000->2000, segment.bytes=100", "10", "20", "Failure", "error"}, mirrorStatus.FieldValues())
 }
 
 func TestMirrorStatus_CreateDryRun(t *testing.T) {
 	topic := "topic-1"
-	config := "config-1"
+	config := []ConfigChange{{Config: "retention.ms", From: "1000", To: "2000"}, {Config: "segment.bytes", To: "100"}}
 	oldNumOfPartitions := int32(10)
 	newNumOfPartitions := int32(20)
 	isCreate := true
@@ -44,12 +46,12 @@ func TestMirrorStatus_CreateDryRun(t *testing.T) {
 
 	mirrorStatus := MirrorStatus(topic, config, oldNumOfPartitions, newNumOfPartitions, isCreate, isDryRun, nil)
 
-	assert.Equal(t, []string{"topic-1", "Create", "config-1", "10", "20", "DryRun", ""}, mirrorStatus.FieldValues())
+	assert.Equal(t, []string{"topic-1", "Create", "retention.ms=1000->2000, segment.bytes=100", "10", "20", "DryRun", ""}, mirrorStatus.FieldValues())
 }
 
 func TestMirrorStatus_UpdateSuccess(t *testing.T) {
 	topic := "topic-1"
-	config := "config-1"
+	config := []ConfigChange{{Config: "retention.ms", From: "1000", To: "2000"}, {Config: "segment.bytes", To: "100"}}
 	oldNumOfPartitions := int32(10)
 	newNumOfPartitions := int32(20)
 	isCreate := false
@@ -57,12 +59,12 @@ func TestMirrorStatus_UpdateSuccess(t *testing.T) {
 
 	mirrorStatus := MirrorStatus(topic, config, oldNumOfPartitions, newNumOfPartitions, isCreate, isDryRun, nil)
 
-	assert.Equal(t, []string{"topic-1", "Update", "config-1", "10", "20", "Success", ""}, mirrorStatus.FieldValues())
+	assert.Equal(t, []string{"topic-1", "Update", "retention.ms=1000->2000, segment.bytes=100", "10", "20", "Success", ""}, mirrorStatus.FieldValues())
 }
 
 func TestMirrorStatus_UpdateFailure(t *testing.T) {
 	topic := "topic-1"
-	config := "config-1"
+	config := []ConfigChange{{Config: "retention.ms", From: "1000", To: "2000"}, {Config: "segment.bytes", To: "100"}}
 	oldNumOfPartitions := int32(10)
 	newNumOfPartitions := int32(20)
 	isCreate := false
@@ -71,12 +73,12 @@ func TestMirrorStatus_UpdateFailure(t *testing.T) {
 
 	mirrorStatus := MirrorStatus(topic, config, oldNumOfPartitions, newNumOfPartitions, isCreate, isDryRun, err)
 
-	assert.Equal(t, []string{"topic-1", "Update", "config-1", "10", "20", "Failure", "error"}, mirrorStatus.FieldValues())
+	assert.Equal(t, []string{"topic-1", "Update", "retention.ms=1000->2000, segment.bytes=100", "10", "20", "Failure", "error"}, mirrorStatus.FieldValues())
 }
 
 func TestMirrorStatus_UpdateDryRun(t *testing.T) {
 	topic := "topic-1"
-	config := "config-1"
+	config := []ConfigChange{{Config: "retention.ms", From: "1000", To: "2000"}, {Config: "segment.bytes", To: "100"}}
 	oldNumOfPartitions := int32(10)
 	newNumOfPartitions := int32(20)
 	isCreate := false
@@ -84,12 +86,12 @@ func TestMirrorStatus_UpdateDryRun(t *testing.T) {
 
 	mirrorStatus := MirrorStatus(topic, config, oldNumOfPartitions, newNumOfPartitions, isCreate, isDryRun, nil)
 
-	assert.Equal(t, []string{"topic-1", "Update", "config-1", "10", "20", "DryRun", ""}, mirrorStatus.FieldValues())
+	assert.Equal(t, []string{"topic-1", "Update", "retention.ms=1000->2000, segment.bytes=100", "10", "20", "DryRun", ""}, mirrorStatus.FieldValues())
 }
 
 func TestMirrorStatus_Headers(t *testing.T) {
 	topic := "topic-1"
-	config := "config-1"
+	config := []ConfigChange{{Config: "retention.ms", From: "1000", To: "2000"}, {Config: "segment.bytes", To: "100"}}
 	oldNumOfPartitions := int32(10)
 	newNumOfPartitions := int32(20)
 	isCreate := false
@@ -99,3 +101,14 @@ func TestMirrorStatus_Headers(t *testing.T) {
 
 	assert.Equal(t, []string{"topic", "Action", "Configs", "OldPartitionCount", "NewPartitionCount", "Status", "Reason"}, mirrorStatus.Headers())
 }
+
+func TestMirrorStatus_RendersConfigChangesAsJSON(t *testing.T) {
+	config := []ConfigChange{{Config: "retention.ms", From: "1000", To: "2000"}, {Config: "segment.bytes", To: "100"}}
+	out := &bytes.Buffer{}
+	renderer, _ := NewRenderer(OutputJSON, out)
+
+	require.NoError(t, renderer.Render([]Row{MirrorStatus("topic-1", config, 10, 10, false, true, nil)}))
+
+	assert.JSONEq(t, `[{"topic": "topic-1", "action": "Update", "configs": [{"config": "retention.ms", "from": "1000", "to": "2000"},
+		{"config": "segment.bytes", "to": "100"}], "oldPartitionCount": 10, "newPartitionCount": 10, "status": "DryRun"}]`, out.String())
+}
@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+)
+
+type PartitionMetadataRow struct {
+	Topic           string  `json:"topic" yaml:"topic"`
+	Internal        bool    `json:"internal" yaml:"internal"`
+	Partition       int32   `json:"partition" yaml:"partition"`
+	Leader          int32   `json:"leader" yaml:"leader"`
+	Replicas        []int32 `json:"replicas" yaml:"replicas"`
+	ISR             []int32 `json:"isr" yaml:"isr"`
+	OfflineReplicas []int32 `json:"offlineReplicas" yaml:"offlineReplicas"`
+}
+
+func PartitionMetadata(topic string, internal bool, partition, leader int32, replicas, isr, offlineReplicas []int32) PartitionMetadataRow {
+	return PartitionMetadataRow{
+		Topic:           topic,
+		Internal:        internal,
+		Partition:       partition,
+		Leader:          leader,
+		Replicas:        replicas,
+		ISR:             isr,
+		OfflineReplicas: offlineReplicas,
+	}
+}
+
+func (p PartitionMetadataRow) FieldValues() []string {
+	return []string{p.Topic, strconv.Itoa(int(p.Partition)), strconv.Itoa(int(p.Leader)), fmt.Sprint(p.Replicas), fmt.Sprint(p.ISR)}
+}
+
+func (p PartitionMetadataRow) Headers() []string {
+	return []string{"Topic", "Partition", "Leader", "Replicas", "ISR"}
+}
+
+func (p PartitionMetadataRow) WideFieldValues() []string {
+	return []string{fmt.Sprint(p.OfflineReplicas), strconv.FormatBool(p.Internal)}
+}
+
+func (p PartitionMetadataRow) WideHeaders() []string {
+	return []string{"Offline Replicas", "Internal"}
+}
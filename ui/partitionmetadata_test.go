@@ -0,0 +1,16 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPartitionMetadata(t *testing.T) {
+	row := PartitionMetadata("topic-1", false, 0, 1, []int32{1, 2}, []int32{1}, []int32{2})
+
+	assert.Equal(t, []string{"topic-1", "0", "1", "[1 2]", "[1]"}, row.FieldValues())
+	assert.Equal(t, []string{"Topic", "Partition", "Leader", "Replicas", "ISR"}, row.Headers())
+	assert.Equal(t, []string{"[2]", "false"}, row.WideFieldValues())
+	assert.Equal(t, []string{"Offline Replicas", "Internal"}, row.WideHeaders())
+}
@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"strconv"
+	"time"
+)
+
+// totalPartition is shown in place of the partition for the totals of a topic
+const totalPartition = "total"
+
+// PartitionOffsetsRow is the offsets of a partition, or the totals of a topic when the partition is nil
+type PartitionOffsetsRow struct {
+	Topic          string     `json:"topic" yaml:"topic"`
+	Partition      *int32     `json:"partition,omitempty" yaml:"partition,omitempty"`
+	OldestOffset   *int64     `json:"earliestOffset,omitempty" yaml:"earliestOffset,omitempty"`
+	NewestOffset   *int64     `json:"latestOffset,omitempty" yaml:"latestOffset,omitempty"`
+	Messages       int64      `json:"messages" yaml:"messages"`
+	FirstTimestamp *time.Time `json:"firstMessageTime,omitempty" yaml:"firstMessageTime,omitempty"`
+	LastTimestamp  *time.Time `json:"lastMessageTime,omitempty" yaml:"lastMessageTime,omitempty"`
+}
+
+func PartitionOffsets(topic string, partition int32, oldestOffset, newestOffset, messages int64, firstTimestamp, lastTimestamp time.Time) PartitionOffsetsRow {
+	return PartitionOffsetsRow{
+		Topic:          topic,
+		Partition:      &partition,
+		OldestOffset:   &oldestOffset,
+		NewestOffset:   &newestOffset,
+		Messages:       messages,
+		FirstTimestamp: timestamp(firstTimestamp),
+		LastTimestamp:  timestamp(lastTimestamp),
+	}
+}
+
+// TopicOffsetsTotal is the row with the totals of the partitions of a topic
+func TopicOffsetsTotal(topic string, messages int64, firstTimestamp, lastTimestamp time.Time) PartitionOffsetsRow {
+	return PartitionOffsetsRow{
+		Topic:          topic,
+		Messages:       messages,
+		FirstTimestamp: timestamp(firstTimestamp),
+		LastTimestamp:  timestamp(lastTimestamp),
+	}
+}
+
+func (p PartitionOffsetsRow) FieldValues() []string {
+	partition, oldestOffset, newestOffset := totalPartition, "", ""
+	if p.Partition != nil {
+		partition = strconv.Itoa(int(*p.Partition))
+		oldestOffset = strconv.FormatInt(*p.OldestOffset, 10)
+		newestOffset = strconv.FormatInt(*p.NewestOffset, 10)
+	}
+	return []string{p.Topic, partition, oldestOffset, newestOffset, strconv.FormatInt(p.Messages, 10),
+		formatTimestamp(p.FirstTimestamp), formatTimestamp(p.LastTimestamp)}
+}
+
+func (p PartitionOffsetsRow) Headers() []string {
+	return []string{"Topic", "Partition", "Earliest Offset", "Latest Offset", "Messages", "First Message Time", "Last Message Time"}
+}
+
+// timestamp returns nil for the zero time, so that it is left out of json and yaml
+func timestamp(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
+
+func formatTimestamp(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,25 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPartitionOffsets(t *testing.T) {
+	first := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	row := PartitionOffsets("topic", 1, 10, 50, 40, first, time.Time{})
+
+	assert.Equal(t, []string{"topic", "1", "10", "50", "40", "2021-03-04T05:06:07Z", ""}, row.FieldValues())
+	assert.Equal(t, []string{"Topic", "Partition", "Earliest Offset", "Latest Offset", "Messages", "First Message Time", "Last Message Time"},
+		row.Headers())
+}
+
+func TestTopicOffsetsTotal(t *testing.T) {
+	first := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	last := first.Add(time.Hour)
+	row := TopicOffsetsTotal("topic", 90, first, last)
+
+	assert.Equal(t, []string{"topic", "total", "", "", "90", "2021-03-04T05:06:07Z", "2021-03-04T06:06:07Z"}, row.FieldValues())
+}
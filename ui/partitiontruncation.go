@@ -0,0 +1,28 @@
+package ui
+
+import "strconv"
+
+type PartitionTruncationRow struct {
+	Partition    int32 `json:"partition" yaml:"partition"`
+	OldestOffset int64 `json:"oldestOffset" yaml:"oldestOffset"`
+	// Offset is the offset before which the messages are deleted
+	Offset   int64 `json:"deleteBeforeOffset" yaml:"deleteBeforeOffset"`
+	Messages int64 `json:"messagesToDelete" yaml:"messagesToDelete"`
+}
+
+func PartitionTruncation(partition int32, oldestOffset, offset, messages int64) PartitionTruncationRow {
+	return PartitionTruncationRow{Partition: partition, OldestOffset: oldestOffset, Offset: offset, Messages: messages}
+}
+
+func (p PartitionTruncationRow) FieldValues() []string {
+	return []string{
+		strconv.Itoa(int(p.Partition)),
+		strconv.FormatInt(p.OldestOffset, 10),
+		strconv.FormatInt(p.Offset, 10),
+		strconv.FormatInt(p.Messages, 10),
+	}
+}
+
+func (p PartitionTruncationRow) Headers() []string {
+	return []string{"Partition", "Oldest Offset", "Delete Before Offset", "Messages To Delete"}
+}
@@ -0,0 +1,14 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPartitionTruncation(t *testing.T) {
+	row := PartitionTruncation(2, 10, 50, 40)
+
+	assert.Equal(t, []string{"2", "10", "50", "40"}, row.FieldValues())
+	assert.Equal(t, []string{"Partition", "Oldest Offset", "Delete Before Offset", "Messages To Delete"}, row.Headers())
+}
@@ -0,0 +1,129 @@
+package ui
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats of the results of the commands
+const (
+	OutputTable = "table"
+	// OutputWide is a table with the additional columns of the rows
+	OutputWide = "wide"
+	OutputJSON = "json"
+	OutputYAML = "yaml"
+	// OutputCSV has a header line, and the additional columns of the rows
+	OutputCSV = "csv"
+	// OutputMarkdown is a markdown table with the additional columns of the rows, for reports pasted in reviews
+	OutputMarkdown = "markdown"
+)
+
+// WideRow is a row with additional columns, shown by the wide and csv outputs
+type WideRow interface {
+	Row
+	WideHeaders() []string
+	WideFieldValues() []string
+}
+
+// Renderer writes the rows of a command in the output format.
+// The rows are marshalled as they are in json and yaml, so their fields are the typed results of the command.
+type Renderer struct {
+	format string
+	out    io.Writer
+}
+
+func NewRenderer(format string, out io.Writer) (*Renderer, error) {
+	switch format {
+	case OutputTable, OutputWide, OutputJSON, OutputYAML, OutputCSV, OutputMarkdown:
+		return &Renderer{format: format, out: out}, nil
+	default:
+		return nil, fmt.Errorf("invalid output format %v, expected one of table|wide|json|yaml|csv|markdown", format)
+	}
+}
+
+func (r *Renderer) Format() string {
+	return r.format
+}
+
+// IsMachineReadable returns true for the formats meant to be parsed or saved as reports, which should not be mixed with logs
+func (r *Renderer) IsMachineReadable() bool {
+	return r.format == OutputJSON || r.format == OutputYAML || r.format == OutputCSV || r.format == OutputMarkdown
+}
+
+// Render writes the rows. An empty list is written for json and yaml when there are no rows, and nothing for the other formats.
+func (r *Renderer) Render(rows []Row) error {
+	if rows == nil {
+		rows = []Row{}
+	}
+	switch r.format {
+	case OutputJSON:
+		encoder := json.NewEncoder(r.out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(rows)
+	case OutputYAML:
+		encoder := yaml.NewEncoder(r.out)
+		if err := encoder.Encode(rows); err != nil {
+			return err
+		}
+		return encoder.Close()
+	case OutputCSV:
+		return r.renderCSV(rows)
+	case OutputMarkdown:
+		tw := &TableWriter{}
+		for _, row := range rows {
+			tw.AddRow(wide(row))
+		}
+		tw.RenderMarkdown(r.out)
+	case OutputWide:
+		tw := &TableWriter{}
+		for _, row := range rows {
+			tw.AddRow(wide(row))
+		}
+		tw.RenderTo(r.out)
+	default:
+		tw := &TableWriter{rows: rows}
+		tw.RenderTo(r.out)
+	}
+	return nil
+}
+
+func (r *Renderer) renderCSV(rows []Row) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	writer := csv.NewWriter(r.out)
+	if err := writer.Write(wide(rows[0]).Headers()); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := writer.Write(wide(row).FieldValues()); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+type wideRow struct {
+	row WideRow
+}
+
+// wide returns the row with its additional columns if it has any
+func wide(row Row) Row {
+	if withColumns, ok := row.(WideRow); ok {
+		return wideRow{row: withColumns}
+	}
+	return row
+}
+
+func (w wideRow) Headers() []string {
+	return append(w.row.Headers(), w.row.WideHeaders()...)
+}
+
+func (w wideRow) FieldValues() []string {
+	return append(w.row.FieldValues(), w.row.WideFieldValues()...)
+}
@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func render(t *testing.T, format string, rows []Row) string {
+	var out bytes.Buffer
+	renderer, err := NewRenderer(format, &out)
+	require.NoError(t, err)
+	require.NoError(t, renderer.Render(rows))
+	return out.String()
+}
+
+func TestRenderer_RendersTable(t *testing.T) {
+	out := render(t, OutputTable, []Row{Topic("topic-1", 3, 2)})
+
+	assert.Contains(t, out, "TOPIC")
+	assert.Contains(t, out, "topic-1")
+	assert.NotContains(t, out, "PARTITIONS")
+}
+
+func TestRenderer_RendersWideTable(t *testing.T) {
+	out := render(t, OutputWide, []Row{Topic("topic-1", 3, 2)})
+
+	assert.Contains(t, out, "REPLICATION FACTOR")
+	assert.Contains(t, out, "topic-1")
+}
+
+func TestRenderer_RendersJSON(t *testing.T) {
+	out := render(t, OutputJSON, []Row{Topic("topic-1", 3, 2), Topic("topic-2", 1, 1)})
+
+	assert.JSONEq(t, `[{"topic": "topic-1", "partitions": 3, "replicationFactor": 2}, {"topic": "topic-2", "partitions": 1, "replicationFactor": 1}]`, out)
+}
+
+func TestRenderer_RendersJSONOfTheReportRows(t *testing.T) {
+	out := render(t, OutputJSON, []Row{
+		ConfigViolation("orders", "retention.ms", "1000", "2000", true, false, nil),
+		PartitionTruncation(0, 10, 20, 10),
+		RestoreAction("Create", "Topic", "orders", "1 partitions"),
+		TopicDeletion("orders", nil),
+	})
+
+	assert.JSONEq(t, `[
+		{"topic": "orders", "config": "retention.ms", "value": "1000", "expected": "2000", "status": "Violation"},
+		{"partition": 0, "oldestOffset": 10, "deleteBeforeOffset": 20, "messagesToDelete": 10},
+		{"action": "Create", "resourceType": "Topic", "resource": "orders", "detail": "1 partitions"},
+		{"topic": "orders", "result": "Deleted"}
+	]`, out)
+}
+
+func TestRenderer_RendersEmptyListForNoRows(t *testing.T) {
+	assert.Equal(t, "[]\n", render(t, OutputJSON, nil))
+	assert.Equal(t, "[]\n", render(t, OutputYAML, nil))
+	assert.Empty(t, render(t, OutputCSV, nil))
+}
+
+func TestRenderer_RendersYAML(t *testing.T) {
+	out := render(t, OutputYAML, []Row{TopicConfigEntry("topic-1", "retention.ms", "1000", "Topic", false, nil)})
+
+	assert.Equal(t, "- topic: topic-1\n  name: retention.ms\n  value: \"1000\"\n  source: Topic\n  synonyms: []\n", out)
+}
+
+func TestRenderer_RendersCSVWithWideColumns(t *testing.T) {
+	out := render(t, OutputCSV, []Row{Topic("topic-1", 3, 2), Topic("topic,2", 1, 1)})
+
+	assert.Equal(t, "Topic,Partitions,Replication Factor\ntopic-1,3,2\n\"topic,2\",1,1\n", out)
+}
+
+func TestRenderer_RendersMarkdownWithWideColumns(t *testing.T) {
+	out := render(t, OutputMarkdown, []Row{Topic("topic-1", 3, 2)})
+
+	assert.Equal(t, "|  Topic  | Partitions | Replication Factor |\n|---------|------------|--------------------|\n"+
+		"| topic-1 |          3 |                  2 |\n", out)
+}
+
+func TestRenderer_IsMachineReadable(t *testing.T) {
+	for format, machineReadable := range map[string]bool{OutputTable: false, OutputWide: false, OutputJSON: true, OutputYAML: true, OutputCSV: true,
+		OutputMarkdown: true} {
+		renderer, err := NewRenderer(format, &bytes.Buffer{})
+		require.NoError(t, err)
+		assert.Equal(t, machineReadable, renderer.IsMachineReadable(), format)
+	}
+}
+
+func TestNewRenderer_FailsForInvalidFormat(t *testing.T) {
+	_, err := NewRenderer("xml", &bytes.Buffer{})
+
+	assert.EqualError(t, err, "invalid output format xml, expected one of table|wide|json|yaml|csv|markdown")
+}
@@ -0,0 +1,20 @@
+package ui
+
+type RestoreActionRow struct {
+	Action       string `json:"action" yaml:"action"`
+	ResourceType string `json:"resourceType" yaml:"resourceType"`
+	Resource     string `json:"resource" yaml:"resource"`
+	Detail       string `json:"detail" yaml:"detail"`
+}
+
+func RestoreAction(action, resourceType, resource, detail string) RestoreActionRow {
+	return RestoreActionRow{Action: action, ResourceType: resourceType, Resource: resource, Detail: detail}
+}
+
+func (r RestoreActionRow) FieldValues() []string {
+	return []string{r.Action, r.ResourceType, r.Resource, r.Detail}
+}
+
+func (r RestoreActionRow) Headers() []string {
+	return []string{"Action", "Resource Type", "Resource", "Detail"}
+}
@@ -0,0 +1,14 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRestoreAction(t *testing.T) {
+	row := RestoreAction("Create", "Topic", "orders", "1 partitions")
+
+	assert.Equal(t, []string{"Create", "Topic", "orders", "1 partitions"}, row.FieldValues())
+	assert.Equal(t, []string{"Action", "Resource Type", "Resource", "Detail"}, row.Headers())
+}
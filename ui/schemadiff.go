@@ -0,0 +1,27 @@
+package ui
+
+import "strconv"
+
+type SchemaDiffRow struct {
+	FromVersion int    `json:"fromVersion" yaml:"fromVersion"`
+	ToVersion   int    `json:"toVersion" yaml:"toVersion"`
+	Change      string `json:"change" yaml:"change"`
+	Line        string `json:"line" yaml:"line"`
+}
+
+func SchemaDiff(fromVersion, toVersion int, change, line string) SchemaDiffRow {
+	return SchemaDiffRow{
+		FromVersion: fromVersion,
+		ToVersion:   toVersion,
+		Change:      change,
+		Line:        line,
+	}
+}
+
+func (s SchemaDiffRow) FieldValues() []string {
+	return []string{strconv.Itoa(s.FromVersion), strconv.Itoa(s.ToVersion), s.Change, s.Line}
+}
+
+func (s SchemaDiffRow) Headers() []string {
+	return []string{"From Version", "To Version", "Change", "Line"}
+}
@@ -0,0 +1,14 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaDiff(t *testing.T) {
+	row := SchemaDiff(1, 3, "Added", `  "type": "long"`)
+
+	assert.Equal(t, []string{"1", "3", "Added", `  "type": "long"`}, row.FieldValues())
+	assert.Equal(t, []string{"From Version", "To Version", "Change", "Line"}, row.Headers())
+}
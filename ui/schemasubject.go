@@ -0,0 +1,29 @@
+package ui
+
+import "strconv"
+
+type SchemaSubjectRow struct {
+	Subject       string `json:"subject" yaml:"subject"`
+	Topic         string `json:"topic" yaml:"topic"`
+	LatestVersion int    `json:"latestVersion" yaml:"latestVersion"`
+	SchemaType    string `json:"schemaType" yaml:"schemaType"`
+	SchemaID      int    `json:"schemaId" yaml:"schemaId"`
+}
+
+func SchemaSubject(subject, topic string, version int, schemaType string, id int) SchemaSubjectRow {
+	return SchemaSubjectRow{
+		Subject:       subject,
+		Topic:         topic,
+		LatestVersion: version,
+		SchemaType:    schemaType,
+		SchemaID:      id,
+	}
+}
+
+func (s SchemaSubjectRow) FieldValues() []string {
+	return []string{s.Subject, s.Topic, strconv.Itoa(s.LatestVersion), s.SchemaType, strconv.Itoa(s.SchemaID)}
+}
+
+func (s SchemaSubjectRow) Headers() []string {
+	return []string{"Subject", "Topic", "Latest Version", "Type", "Schema ID"}
+}
@@ -0,0 +1,14 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaSubject(t *testing.T) {
+	row := SchemaSubject("orders-value", "orders", 3, "AVRO", 12)
+
+	assert.Equal(t, []string{"orders-value", "orders", "3", "AVRO", "12"}, row.FieldValues())
+	assert.Equal(t, []string{"Subject", "Topic", "Latest Version", "Type", "Schema ID"}, row.Headers())
+}
@@ -0,0 +1,37 @@
+package ui
+
+import "strconv"
+
+type SchemaVersionRow struct {
+	Subject    string `json:"subject" yaml:"subject"`
+	Version    int    `json:"version" yaml:"version"`
+	SchemaType string `json:"schemaType" yaml:"schemaType"`
+	SchemaID   int    `json:"schemaId" yaml:"schemaId"`
+	Schema     string `json:"schema" yaml:"schema"`
+}
+
+func SchemaVersion(subject string, version int, schemaType string, id int, schema string) SchemaVersionRow {
+	return SchemaVersionRow{
+		Subject:    subject,
+		Version:    version,
+		SchemaType: schemaType,
+		SchemaID:   id,
+		Schema:     schema,
+	}
+}
+
+func (s SchemaVersionRow) FieldValues() []string {
+	return []string{s.Subject, strconv.Itoa(s.Version), s.SchemaType, strconv.Itoa(s.SchemaID)}
+}
+
+func (s SchemaVersionRow) Headers() []string {
+	return []string{"Subject", "Version", "Type", "Schema ID"}
+}
+
+func (s SchemaVersionRow) WideFieldValues() []string {
+	return []string{s.Schema}
+}
+
+func (s SchemaVersionRow) WideHeaders() []string {
+	return []string{"Schema"}
+}
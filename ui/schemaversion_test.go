@@ -0,0 +1,16 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaVersion(t *testing.T) {
+	row := SchemaVersion("orders-value", 3, "AVRO", 12, `"string"`)
+
+	assert.Equal(t, []string{"orders-value", "3", "AVRO", "12"}, row.FieldValues())
+	assert.Equal(t, []string{"Subject", "Version", "Type", "Schema ID"}, row.Headers())
+	assert.Equal(t, []string{`"string"`}, row.WideFieldValues())
+	assert.Equal(t, []string{"Schema"}, row.WideHeaders())
+}
@@ -0,0 +1,21 @@
+package ui
+
+type SnapshotDifferenceRow struct {
+	Type     string `json:"type" yaml:"type"`
+	Resource string `json:"resource" yaml:"resource"`
+	Field    string `json:"field,omitempty" yaml:"field,omitempty"`
+	Before   string `json:"before" yaml:"before"`
+	After    string `json:"after" yaml:"after"`
+}
+
+func SnapshotDifference(diffType, resource, field, before, after string) SnapshotDifferenceRow {
+	return SnapshotDifferenceRow{Type: diffType, Resource: resource, Field: field, Before: before, After: after}
+}
+
+func (s SnapshotDifferenceRow) FieldValues() []string {
+	return []string{s.Type, s.Resource, s.Field, s.Before, s.After}
+}
+
+func (s SnapshotDifferenceRow) Headers() []string {
+	return []string{"Type", "Resource", "Field", "Before", "After"}
+}
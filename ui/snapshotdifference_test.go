@@ -0,0 +1,14 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotDifference(t *testing.T) {
+	row := SnapshotDifference("Assignment", "orders", "0", "[1 2]", "[2 3]")
+
+	assert.Equal(t, []string{"Assignment", "orders", "0", "[1 2]", "[2 3]"}, row.FieldValues())
+	assert.Equal(t, []string{"Type", "Resource", "Field", "Before", "After"}, row.Headers())
+}
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"io"
 	"os"
 
 	"github.com/olekukonko/tablewriter"
@@ -20,13 +21,36 @@ func (w *TableWriter) AddRow(row Row) {
 }
 
 func (w *TableWriter) Render() {
+	w.RenderTo(os.Stdout)
+}
+
+// RenderTo renders the rows as a table to the writer
+func (w *TableWriter) RenderTo(out io.Writer) {
+	if len(w.rows) == 0 {
+		return
+	}
+	table := w.table(out)
+	table.Render()
+}
+
+// RenderMarkdown renders the rows as a markdown table
+func (w *TableWriter) RenderMarkdown(out io.Writer) {
 	if len(w.rows) == 0 {
 		return
 	}
-	table := tablewriter.NewWriter(os.Stdout)
+	table := w.table(out)
+	table.SetAutoFormatHeaders(false)
+	table.SetAutoWrapText(false)
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetCenterSeparator("|")
+	table.Render()
+}
+
+func (w *TableWriter) table(out io.Writer) *tablewriter.Table {
+	table := tablewriter.NewWriter(out)
 	table.SetHeader(w.rows[0].Headers())
 	for _, row := range w.rows {
 		table.Append(row.FieldValues())
 	}
-	table.Render()
+	return table
 }
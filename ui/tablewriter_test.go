@@ -0,0 +1,20 @@
+package ui
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTableWriter_RenderMarkdown(t *testing.T) {
+	tw := &TableWriter{}
+	tw.AddRow(ClusterDifference("Config", "topic-1", "retention.ms", "1000", "2000"))
+	var out bytes.Buffer
+
+	tw.RenderMarkdown(&out)
+
+	assert.Equal(t, "|  Type  | Resource |    Field     | Source | Destination |\n"+
+		"|--------|----------|--------------|--------|-------------|\n"+
+		"| Config | topic-1  | retention.ms |   1000 |        2000 |\n", out.String())
+}
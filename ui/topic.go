@@ -0,0 +1,29 @@
+package ui
+
+import "strconv"
+
+type TopicRow struct {
+	Topic             string `json:"topic" yaml:"topic"`
+	Partitions        int32  `json:"partitions" yaml:"partitions"`
+	ReplicationFactor int16  `json:"replicationFactor" yaml:"replicationFactor"`
+}
+
+func Topic(topic string, partitions int32, replicationFactor int16) TopicRow {
+	return TopicRow{Topic: topic, Partitions: partitions, ReplicationFactor: replicationFactor}
+}
+
+func (t TopicRow) FieldValues() []string {
+	return []string{t.Topic}
+}
+
+func (t TopicRow) Headers() []string {
+	return []string{"Topic"}
+}
+
+func (t TopicRow) WideFieldValues() []string {
+	return []string{strconv.Itoa(int(t.Partitions)), strconv.Itoa(int(t.ReplicationFactor))}
+}
+
+func (t TopicRow) WideHeaders() []string {
+	return []string{"Partitions", "Replication Factor"}
+}
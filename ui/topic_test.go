@@ -0,0 +1,16 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTopic(t *testing.T) {
+	row := Topic("topic-1", 3, 2)
+
+	assert.Equal(t, []string{"topic-1"}, row.FieldValues())
+	assert.Equal(t, []string{"Topic"}, row.Headers())
+	assert.Equal(t, []string{"3", "2"}, row.WideFieldValues())
+	assert.Equal(t, []string{"Partitions", "Replication Factor"}, row.WideHeaders())
+}
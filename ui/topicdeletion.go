@@ -0,0 +1,27 @@
+package ui
+
+const (
+	deletionSucceeded = "Deleted"
+	deletionFailed    = "Failed"
+)
+
+type TopicDeletionRow struct {
+	Topic  string `json:"topic" yaml:"topic"`
+	Result string `json:"result" yaml:"result"`
+	Error  string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+func TopicDeletion(topic string, err error) TopicDeletionRow {
+	if err != nil {
+		return TopicDeletionRow{Topic: topic, Result: deletionFailed, Error: err.Error()}
+	}
+	return TopicDeletionRow{Topic: topic, Result: deletionSucceeded}
+}
+
+func (t TopicDeletionRow) FieldValues() []string {
+	return []string{t.Topic, t.Result, t.Error}
+}
+
+func (t TopicDeletionRow) Headers() []string {
+	return []string{"Topic", "Result", "Error"}
+}
@@ -0,0 +1,14 @@
+package ui
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTopicDeletion(t *testing.T) {
+	assert.Equal(t, []string{"topic-1", "Deleted", ""}, TopicDeletion("topic-1", nil).FieldValues())
+	assert.Equal(t, []string{"topic-2", "Failed", "not authorized"}, TopicDeletion("topic-2", errors.New("not authorized")).FieldValues())
+	assert.Equal(t, []string{"Topic", "Result", "Error"}, TopicDeletion("topic-1", nil).Headers())
+}
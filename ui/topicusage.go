@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+type TopicUsageRow struct {
+	Topic             string `json:"topic" yaml:"topic"`
+	Usage             string `json:"usage" yaml:"usage"`
+	Partitions        int32  `json:"partitions" yaml:"partitions"`
+	ReplicationFactor int16  `json:"replicationFactor" yaml:"replicationFactor"`
+	// SizeBytes is the size of the logs of all the replicas
+	SizeBytes      int64      `json:"sizeBytes" yaml:"sizeBytes"`
+	Messages       int64      `json:"messages" yaml:"messages"`
+	LastWrite      *time.Time `json:"lastWrite" yaml:"lastWrite"`
+	ConsumerGroups []string   `json:"consumerGroups" yaml:"consumerGroups"`
+}
+
+func TopicUsage(topic, usage string, partitions int32, replicationFactor int16, sizeBytes, messages int64, lastWrite *time.Time,
+	consumerGroups []string) TopicUsageRow {
+	if lastWrite != nil {
+		lastWrite = timestamp(*lastWrite)
+	}
+	return TopicUsageRow{
+		Topic:             topic,
+		Usage:             usage,
+		Partitions:        partitions,
+		ReplicationFactor: replicationFactor,
+		SizeBytes:         sizeBytes,
+		Messages:          messages,
+		LastWrite:         lastWrite,
+		ConsumerGroups:    consumerGroups,
+	}
+}
+
+func (t TopicUsageRow) FieldValues() []string {
+	return []string{t.Topic, t.Usage, strconv.Itoa(int(t.Partitions)), strconv.Itoa(int(t.ReplicationFactor)),
+		strconv.FormatInt(t.SizeBytes, 10), strconv.FormatInt(t.Messages, 10), formatTimestamp(t.LastWrite),
+		strings.Join(t.ConsumerGroups, ",")}
+}
+
+func (t TopicUsageRow) Headers() []string {
+	return []string{"Topic", "Usage", "Partitions", "Replication Factor", "Size Bytes", "Messages", "Last Write", "Consumer Groups"}
+}
@@ -0,0 +1,23 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTopicUsage(t *testing.T) {
+	lastWrite := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	row := TopicUsage("orders", "active", 3, 2, 100, 10, &lastWrite, []string{"group-1", "group-2"})
+
+	assert.Equal(t, []string{"orders", "active", "3", "2", "100", "10", "2021-03-04T05:06:07Z", "group-1,group-2"}, row.FieldValues())
+	assert.Equal(t, []string{"Topic", "Usage", "Partitions", "Replication Factor", "Size Bytes", "Messages", "Last Write", "Consumer Groups"},
+		row.Headers())
+}
+
+func TestTopicUsage_WithoutLastWrite(t *testing.T) {
+	row := TopicUsage("payments", "unused", 1, 1, 0, 0, nil, []string{})
+
+	assert.Equal(t, []string{"payments", "unused", "1", "1", "0", "0", "", ""}, row.FieldValues())
+}
@@ -30,3 +30,14 @@ func (u UserInput) AskForConfirmation(question string) bool {
 		}
 	}
 }
+
+func (u UserInput) AskForText(question string) string {
+	reader := bufio.NewReader(os.Stdin)
+	fmt.Printf("%s: ", question)
+
+	response, err := reader.ReadString('\n')
+	if err != nil {
+		log.Fatal(err)
+	}
+	return strings.TrimSpace(response)
+}